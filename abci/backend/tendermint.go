@@ -148,6 +148,10 @@ func (b *TendermintBackend) parseConfig() (*cfg.Config, error) {
 	if b.OverrideCfg.MempoolWalEnabled {
 		conf.Mempool.WalPath = "data/mempool.wal"
 	}
+	if b.OverrideCfg.ReplicaMode {
+		// Replicas don't accept txs, so there's nothing in their mempools worth gossiping
+		conf.Mempool.Broadcast = false
+	}
 
 	cfg.EnsureRoot(b.RootPath)
 	return conf, err
@@ -163,6 +167,7 @@ type OverrideConfig struct {
 	P2PPort                  int32
 	CreateEmptyBlocks        bool
 	MempoolWalEnabled        bool
+	ReplicaMode              bool
 	HsmConfig                *hsmpv.HsmConfig
 	FnConsensusReactorConfig *fnConsensus.ReactorConfigParsable
 }
@@ -403,7 +408,7 @@ func (b *TendermintBackend) Start(app abci.Application) error {
 
 	dbProvider := node.DefaultDBProvider
 	var fnConsensusReactor *fnConsensus.FnConsensusReactor
-	if b.FnRegistry != nil {
+	if b.FnRegistry != nil && !b.OverrideCfg.ReplicaMode {
 		reactorConfig := b.OverrideCfg.FnConsensusReactorConfig
 		if reactorConfig.IsValidator {
 			dbProvider, err = CreateNewCachedDBProvider(cfg)
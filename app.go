@@ -16,7 +16,10 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/gogo/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/auth"
 	cctypes "github.com/loomnetwork/go-loom/builtin/types/chainconfig"
 	"github.com/loomnetwork/go-loom/plugin"
 	"github.com/loomnetwork/go-loom/types"
@@ -105,6 +108,11 @@ func (s *StoreState) Range(prefix []byte) plugin.RangeData {
 	return s.store.Range(prefix)
 }
 
+// RangeFrom implements store.RangeFromReader.
+func (s *StoreState) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return store.RangeFrom(s.store, prefix, start, limit)
+}
+
 func (s *StoreState) Get(key []byte) []byte {
 	return s.store.Get(key)
 }
@@ -290,6 +298,11 @@ type readOnlyKVStoreAdapter struct {
 	store.KVReader
 }
 
+// RangeFrom implements store.RangeFromReader.
+func (s *readOnlyKVStoreAdapter) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return store.RangeFrom(s.KVReader, prefix, start, limit)
+}
+
 func (s *readOnlyKVStoreAdapter) Set(key, value []byte) {
 	panic("kvStoreSnapshotAdapter.Set not implemented")
 }
@@ -298,6 +311,19 @@ func (s *readOnlyKVStoreAdapter) Delete(key []byte) {
 	panic("kvStoreSnapshotAdapter.Delete not implemented")
 }
 
+// NewThrowawayState creates a writable state on top of the given read-only state. All writes are
+// buffered in memory and are never flushed to the underlying state, so this can be used to execute
+// txs against a snapshot (e.g. for gas estimation) without affecting the app state.
+func NewThrowawayState(ctx context.Context, state ReadOnlyState) State {
+	return &StoreState{
+		ctx:        ctx,
+		store:      store.WrapAtomic(&readOnlyKVStoreAdapter{state}).BeginTx(),
+		block:      state.Block(),
+		validators: loom.NewValidatorSet(state.Validators()...),
+		config:     state.Config(),
+	}
+}
+
 type TxHandler interface {
 	ProcessTx(state State, txBytes []byte, isCheckTx bool) (TxHandlerResult, error)
 }
@@ -333,6 +359,13 @@ type ValidatorsManager interface {
 type ChainConfigManager interface {
 	EnableFeatures(blockHeight int64) error
 	UpdateConfig() (int, error)
+	ApplyContractUpgrades() error
+}
+
+type GovernanceManager interface {
+	HarvestProposals() ([]uint64, error)
+	ExecuteProposal(id uint64) error
+	SetProposalExecuted(id uint64, execErr error) error
 }
 
 type GetValidatorSet func(state State) (loom.ValidatorSet, error)
@@ -341,9 +374,10 @@ type ValidatorsManagerFactoryFunc func(state State) (ValidatorsManager, error)
 
 type ChainConfigManagerFactoryFunc func(state State) (ChainConfigManager, error)
 
+type GovernanceManagerFactoryFunc func(state State) (GovernanceManager, error)
+
 type CommittedTx struct {
 	result TxHandlerResult
-	txHash []byte
 }
 
 type Application struct {
@@ -360,6 +394,9 @@ type Application struct {
 	blockindex.BlockIndexStore
 	CreateValidatorManager   ValidatorsManagerFactoryFunc
 	CreateChainConfigManager ChainConfigManagerFactoryFunc
+	// Callback function used to construct a governance manager at the end of each block, should
+	// return a nil manager when the Governance contract is disabled or hasn't been deployed yet.
+	CreateGovernanceManager GovernanceManagerFactoryFunc
 	// Callback function used to construct a contract upkeep handler at the start of each block,
 	// should return a nil handler when the contract upkeep feature is disabled.
 	CreateContractUpkeepHandler func(state State) (KarmaHandler, error)
@@ -369,10 +406,18 @@ type Application struct {
 	childTxRefs                 []evmaux.ChildTxRef // links Tendermint txs to EVM txs
 	ReceiptsVersion             int32
 	committedTxs                []CommittedTx
+	// Callback function invoked after each block is committed with the newly committed state.
+	BlockCommitted func(state ReadOnlyState)
+	// Hashes of the EVM txs that have already been announced to the newPendingTransactions
+	// subscribers, Tendermint rechecks the txs that remain in the mempool after each block.
+	pendingTxHashes *lru.Cache
 }
 
 var _ abci.Application = &Application{}
 
+// Max number of pending tx hashes tracked to avoid announcing the same tx more than once.
+const maxPendingTxHashes = 10000
+
 //Metrics
 var (
 	deliverTxLatency     metrics.Histogram
@@ -565,6 +610,10 @@ func (a *Application) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginB
 			// invalidate cached config so it's reloaded next time it's accessed
 			a.config = nil
 		}
+
+		if err := chainConfigManager.ApplyContractUpgrades(); err != nil {
+			panic(err)
+		}
 	}
 
 	storeTx.Commit()
@@ -602,6 +651,14 @@ func (a *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
 		a.GetValidatorSet,
 	).WithOnChainConfig(a.config)
 
+	// Proposals must be executed before the validator manager runs, so that any DPOS params
+	// changed by a proposal take effect in this block's election.
+	if a.CreateGovernanceManager != nil {
+		if err := a.executeGovernanceProposals(storeTx); err != nil {
+			panic(err)
+		}
+	}
+
 	validatorManager, err := a.CreateValidatorManager(state)
 	if err != registry.ErrNotFound {
 		if err != nil {
@@ -623,11 +680,55 @@ func (a *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
 			ValidatorUpdates: validators,
 		}
 	}
+	storeTx.Commit()
+
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: []abci.ValidatorUpdate{},
 	}
 }
 
+// executeGovernanceProposals executes the governance proposals whose voting period has ended and
+// which passed. Each proposal is executed on top of its own atomic store, so the changes made by a
+// proposal that fails to execute are discarded, and don't affect any other proposals.
+func (a *Application) executeGovernanceProposals(storeTx store.KVStoreTx) error {
+	newState := func(s store.KVStore) State {
+		return NewStoreState(
+			context.Background(),
+			s,
+			a.curBlockHeader,
+			nil,
+			a.GetValidatorSet,
+		).WithOnChainConfig(a.config)
+	}
+
+	governanceManager, err := a.CreateGovernanceManager(newState(storeTx))
+	if err != nil || governanceManager == nil {
+		return err
+	}
+	ids, err := governanceManager.HarvestProposals()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		proposalStoreTx := store.WrapAtomic(storeTx).BeginTx()
+		proposalManager, err := a.CreateGovernanceManager(newState(proposalStoreTx))
+		if err != nil {
+			return err
+		}
+		execErr := proposalManager.ExecuteProposal(id)
+		if execErr != nil {
+			proposalStoreTx.Rollback()
+		} else {
+			proposalStoreTx.Commit()
+		}
+		if err := governanceManager.SetProposalExecuted(id, execErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *Application) CheckTx(txBytes []byte) abci.ResponseCheckTx {
 	var err error
 	defer func(begin time.Time) {
@@ -650,7 +751,7 @@ func (a *Application) CheckTx(txBytes []byte) abci.ResponseCheckTx {
 	defer storeTx.Rollback()
 
 	state := NewStoreState(
-		context.Background(),
+		WithTxBytes(context.Background(), txBytes),
 		storeTx,
 		a.curBlockHeader,
 		a.curBlockHash,
@@ -663,14 +764,64 @@ func (a *Application) CheckTx(txBytes []byte) abci.ResponseCheckTx {
 	defer a.EventHandler.Rollback()
 
 	_, err = a.TxHandler.ProcessTx(state, txBytes, true)
+	if err == ErrTxQueued {
+		log.Debug("CheckTx", "tx", hex.EncodeToString(ttypes.Tx(txBytes).Hash()), "err", err)
+		return abci.ResponseCheckTx{Code: CodeTypeTxQueued, Log: err.Error()}
+	}
 	if err != nil {
 		log.Error("CheckTx", "tx", hex.EncodeToString(ttypes.Tx(txBytes).Hash()), "err", err)
 		return abci.ResponseCheckTx{Code: 1, Log: err.Error()}
 	}
 
+	a.emitPendingTxEvent(txBytes)
 	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
 }
 
+// emitPendingTxEvent notifies the newPendingTransactions subscribers of an EVM tx that was added to
+// the mempool, txs that have already been announced are skipped.
+func (a *Application) emitPendingTxEvent(txBytes []byte) {
+	evmTxHash := a.pendingEvmTxHash(txBytes)
+	if evmTxHash == nil {
+		return
+	}
+	if a.pendingTxHashes == nil {
+		a.pendingTxHashes, _ = lru.New(maxPendingTxHashes)
+	}
+	if seen, _ := a.pendingTxHashes.ContainsOrAdd(string(ttypes.Tx(txBytes).Hash()), true); seen {
+		return
+	}
+	if err := a.EventHandler.EthSubscriptionSet().EmitTxEvent(evmTxHash); err != nil {
+		log.Error("failed to emit tx event to subscribers", "err", err)
+	}
+}
+
+// pendingEvmTxHash returns the hash that can be used to look up the given EVM tx, or nil if the tx
+// isn't an EVM tx.
+func (a *Application) pendingEvmTxHash(txBytes []byte) []byte {
+	// Loom EVM txs are executed in CheckTx, so the hash in the receipt can be used.
+	if receipt := a.ReceiptHandlerProvider.Reader().GetCurrentReceipt(); receipt != nil {
+		return receipt.TxHash
+	}
+	// Ethereum txs aren't executed in CheckTx so there's no receipt, the Tendermint tx hash is used
+	// instead since that's the hash eth_sendRawTransaction returns to the client.
+	var signedTx auth.SignedTx
+	if err := proto.Unmarshal(txBytes, &signedTx); err != nil {
+		return nil
+	}
+	var nonceTx auth.NonceTx
+	if err := proto.Unmarshal(signedTx.Inner, &nonceTx); err != nil {
+		return nil
+	}
+	var tx types.Transaction
+	if err := proto.Unmarshal(nonceTx.Inner, &tx); err != nil {
+		return nil
+	}
+	if types.TxID(tx.Id) != types.TxID_ETHEREUM {
+		return nil
+	}
+	return ttypes.Tx(txBytes).Hash()
+}
+
 func (a *Application) DeliverTx(txBytes []byte) abci.ResponseDeliverTx {
 	var txFailed, isEvmTx bool
 	defer func(begin time.Time) {
@@ -750,9 +901,6 @@ func (a *Application) processTx(storeTx store.KVStoreTx, txBytes []byte, isCheck
 			reader := a.ReceiptHandlerProvider.Reader()
 			if reader.GetCurrentReceipt() != nil {
 				receiptTxHash := reader.GetCurrentReceipt().TxHash
-				if err := a.EventHandler.EthSubscriptionSet().EmitTxEvent(receiptTxHash); err != nil {
-					log.Error("failed to emit tx event to subscribers", "err", err)
-				}
 				txHash := ttypes.Tx(txBytes).Hash()
 				// If a receipt was generated for an EVM tx add a link between the TM tx hash and the EVM tx hash
 				// so that we can use it to lookup relevant events using the TM tx hash.
@@ -787,9 +935,8 @@ func (a *Application) deliverTx2(storeTx store.KVStoreTx, txBytes []byte) abci.R
 	r, txErr := a.TxHandler.ProcessTx(state, txBytes, false)
 
 	// Store the receipt even if the tx itself failed
-	var receiptTxHash []byte
 	if a.ReceiptHandlerProvider.Reader().GetCurrentReceipt() != nil {
-		receiptTxHash = a.ReceiptHandlerProvider.Reader().GetCurrentReceipt().TxHash
+		receiptTxHash := a.ReceiptHandlerProvider.Reader().GetCurrentReceipt().TxHash
 		txHash := ttypes.Tx(txBytes).Hash()
 		// If a receipt was generated for an EVM tx add a link between the TM tx hash and the EVM tx hash
 		// so that we can use it to lookup relevant events using the TM tx hash.
@@ -815,7 +962,6 @@ func (a *Application) deliverTx2(storeTx store.KVStoreTx, txBytes []byte) abci.R
 
 	a.committedTxs = append(a.committedTxs, CommittedTx{
 		result: r,
-		txHash: receiptTxHash,
 	})
 
 	return abci.ResponseDeliverTx{Code: abci.CodeTypeOK, Data: r.Data, Tags: r.Tags, Info: r.Info}
@@ -853,6 +999,10 @@ func (a *Application) Commit() abci.ResponseCommit {
 	// the latest committed state as soon as they receive an event.
 	a.lastBlockHeader = a.curBlockHeader
 
+	if a.BlockCommitted != nil {
+		a.BlockCommitted(a.ReadOnlyState())
+	}
+
 	go func(height int64, blockHeader abci.Header, committedTxs []CommittedTx) {
 		if err := a.EventHandler.EmitBlockTx(uint64(height), blockHeader.Time); err != nil {
 			log.Error("Emit Block Event error", "err", err)
@@ -867,12 +1017,6 @@ func (a *Application) Commit() abci.ResponseCommit {
 			if err := a.EventHandler.LegacyEthSubscriptionSet().EmitTxEvent(tx.result.Data, tx.result.Info); err != nil {
 				log.Error("Emit Tx Event error", "err", err)
 			}
-
-			if len(tx.txHash) > 0 {
-				if err := a.EventHandler.EthSubscriptionSet().EmitTxEvent(tx.txHash); err != nil {
-					log.Error("failed to emit tx event to subscribers", "err", err)
-				}
-			}
 		}
 	}(height, a.curBlockHeader, a.committedTxs)
 	a.committedTxs = nil
@@ -914,3 +1058,17 @@ func (a *Application) ReadOnlyState() State {
 		a.GetValidatorSet,
 	)
 }
+
+// ReadOnlyStateAt returns a read-only snapshot of the state as it was after the block at the given
+// height was committed, the given header will be returned by State.Block().
+func (a *Application) ReadOnlyStateAt(height int64, header abci.Header) (State, error) {
+	historicalStore, ok := a.Store.(store.HistoricalKVStore)
+	if !ok {
+		return nil, store.ErrHistoricalSnapshotsNotSupported
+	}
+	snap, err := historicalStore.GetSnapshotAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreStateSnapshot(nil, snap, header, nil, a.GetValidatorSet), nil
+}
@@ -2,14 +2,32 @@ package loomchain
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
 	"testing"
 	"time"
 
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/loomnetwork/go-loom/auth"
 	cctypes "github.com/loomnetwork/go-loom/builtin/types/chainconfig"
+	ptypes "github.com/loomnetwork/go-loom/plugin/types"
+	ltypes "github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/vm"
 	"github.com/loomnetwork/loomchain/db"
+	"github.com/loomnetwork/loomchain/eth/subs"
+	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/store"
+	"github.com/pkg/errors"
+	"github.com/posener/wstest"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	ttypes "github.com/tendermint/tendermint/types"
 )
 
 var (
@@ -53,6 +71,33 @@ func TestOnChainConfig(t *testing.T) {
 	require.Equal(t, uint64(5000), state.WithOnChainConfig(curCfg).Config().Evm.GasLimit)
 }
 
+func TestThrowawayState(t *testing.T) {
+	kvStore := store.NewMemStore()
+	kvStore.Set([]byte("key1"), []byte("value1"))
+	kvStore.Set([]byte("key2"), []byte("value2"))
+	require.NoError(t, store.SaveOnChainConfig(kvStore, &cctypes.Config{}))
+
+	header := abci.Header{
+		Height: blockHeight,
+		Time:   blockTime,
+	}
+	state := NewStoreState(context.Background(), kvStore, header, nil, nil)
+	throwaway := NewThrowawayState(context.Background(), state)
+	require.Equal(t, blockHeight, throwaway.Block().Height)
+
+	throwaway.Set([]byte("key1"), []byte("changed"))
+	throwaway.Delete([]byte("key2"))
+	throwaway.Set([]byte("key3"), []byte("value3"))
+	require.Equal(t, []byte("changed"), throwaway.Get([]byte("key1")))
+	require.False(t, throwaway.Has([]byte("key2")))
+	require.Equal(t, []byte("value3"), throwaway.Get([]byte("key3")))
+
+	// none of the changes should be visible in the underlying state
+	require.Equal(t, []byte("value1"), state.Get([]byte("key1")))
+	require.Equal(t, []byte("value2"), state.Get([]byte("key2")))
+	require.False(t, state.Has([]byte("key3")))
+}
+
 func mockMultiWriterStore(flushInterval int64) (*store.MultiWriterAppStore, error) {
 	memDb, _ := db.LoadMemDB()
 	iavlStore, err := store.NewIAVLStore(memDb, 0, 0, flushInterval)
@@ -67,3 +112,109 @@ func mockMultiWriterStore(flushInterval int64) (*store.MultiWriterAppStore, erro
 	}
 	return multiWriterStore, nil
 }
+
+func TestCheckTxEmitsPendingEthTx(t *testing.T) {
+	kvStore, err := mockMultiWriterStore(10)
+	require.NoError(t, err)
+	eventHandler := NewDefaultEventHandler(nil)
+	app := &Application{
+		Store:          kvStore,
+		curBlockHeader: abci.Header{Height: blockHeight, Time: blockTime},
+		// Ethereum txs are only validated in CheckTx, so no receipt is generated for them.
+		TxHandler: TxHandlerFunc(func(state State, txBytes []byte, isCheckTx bool) (TxHandlerResult, error) {
+			return TxHandlerResult{}, nil
+		}),
+		EventHandler:           eventHandler,
+		ReceiptHandlerProvider: &mockReceiptHandlerProvider{},
+	}
+
+	subscribed := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		conn := eth.NewWSConn(wsConn, eth.DefaultWebSocketConfig())
+		_, err = eventHandler.EthSubscriptionSet().AddSubscription(
+			subs.NewPendingTransactions, eth.EthFilter{}, conn,
+		)
+		require.NoError(t, err)
+		close(subscribed)
+	})
+	client, _, err := wstest.NewDialer(handler).Dial("ws://localhost/eth", nil)
+	require.NoError(t, err)
+	defer client.Close()
+	<-subscribed
+
+	txBytes := mockSignedEthTx(t)
+	require.Equal(t, abci.CodeTypeOK, app.CheckTx(txBytes).Code)
+
+	var resp struct {
+		Params struct {
+			Result json.RawMessage `json:"result"`
+		} `json:"params"`
+		Method string `json:"method"`
+	}
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, client.ReadJSON(&resp))
+	require.Equal(t, "eth_subscription", resp.Method)
+	var txHash string
+	require.NoError(t, json.Unmarshal(resp.Params.Result, &txHash))
+	require.Equal(t, hex.EncodeToString(ttypes.Tx(txBytes).Hash()), txHash)
+
+	// Tendermint rechecks the txs that remain in the mempool, the tx shouldn't be announced again.
+	require.Equal(t, abci.CodeTypeOK, app.CheckTx(txBytes).Code)
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(500*time.Millisecond)))
+	require.Error(t, client.ReadJSON(&resp))
+}
+
+// mockSignedEthTx wraps a signed Ethereum tx the same way eth_sendRawTransaction does.
+func mockSignedEthTx(t *testing.T) []byte {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ethTx, err := etypes.SignTx(
+		etypes.NewTransaction(0, [20]byte{1}, big.NewInt(0), 0, big.NewInt(0), nil),
+		etypes.NewEIP155Signer(big.NewInt(1)),
+		key,
+	)
+	require.NoError(t, err)
+	ethTxBytes, err := rlp.EncodeToBytes(ethTx)
+	require.NoError(t, err)
+	msgBytes, err := proto.Marshal(&vm.MessageTx{Data: ethTxBytes})
+	require.NoError(t, err)
+	txBytes, err := proto.Marshal(&ltypes.Transaction{Id: uint32(ltypes.TxID_ETHEREUM), Data: msgBytes})
+	require.NoError(t, err)
+	nonceTxBytes, err := proto.Marshal(&auth.NonceTx{Inner: txBytes, Sequence: 1})
+	require.NoError(t, err)
+	signedTxBytes, err := proto.Marshal(&auth.SignedTx{Inner: nonceTxBytes})
+	require.NoError(t, err)
+	return signedTxBytes
+}
+
+type mockReceiptHandlerProvider struct {
+	currentReceipt *ptypes.EvmTxReceipt
+}
+
+func (p *mockReceiptHandlerProvider) Store() ReceiptHandlerStore     { return p }
+func (p *mockReceiptHandlerProvider) Reader() ReadReceiptHandler     { return p }
+func (p *mockReceiptHandlerProvider) Writer() WriteReceiptHandler    { return nil }
+func (p *mockReceiptHandlerProvider) CommitBlock(height int64) error { return nil }
+func (p *mockReceiptHandlerProvider) CommitCurrentReceipt()          {}
+func (p *mockReceiptHandlerProvider) DiscardCurrentReceipt()         { p.currentReceipt = nil }
+func (p *mockReceiptHandlerProvider) ClearData() error               { return nil }
+func (p *mockReceiptHandlerProvider) Close() error                   { return nil }
+
+func (p *mockReceiptHandlerProvider) GetReceipt(txHash []byte) (ptypes.EvmTxReceipt, error) {
+	return ptypes.EvmTxReceipt{}, errors.New("not found")
+}
+
+func (p *mockReceiptHandlerProvider) GetPendingReceipt(txHash []byte) (ptypes.EvmTxReceipt, error) {
+	return ptypes.EvmTxReceipt{}, errors.New("not found")
+}
+
+func (p *mockReceiptHandlerProvider) GetPendingTxHashList() [][]byte {
+	return nil
+}
+
+func (p *mockReceiptHandlerProvider) GetCurrentReceipt() *ptypes.EvmTxReceipt {
+	return p.currentReceipt
+}
@@ -14,6 +14,7 @@ import (
 	loom "github.com/loomnetwork/go-loom"
 	"github.com/loomnetwork/go-loom/util"
 	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/log"
 	"github.com/loomnetwork/loomchain/store"
 )
 
@@ -98,12 +99,23 @@ func Nonce(state loomchain.ReadOnlyState, addr loom.Address) uint64 {
 type NonceHandler struct {
 	nonceCache map[string]uint64 // stores the next nonce expected to be seen for each account
 	lastHeight int64
+	queue      *NonceQueue // holds txs with future nonces, nil if queueing is disabled
+	// accounts that had txs delivered in the current block, their queued txs are released once
+	// the block is committed
+	delivered map[string]loom.Address
 }
 
 func NewNonceHandler() *NonceHandler {
 	return &NonceHandler{nonceCache: make(map[string]uint64), lastHeight: 0}
 }
 
+// NewNonceHandlerWithQueue creates a nonce handler that holds txs with future nonces in the given
+// queue during CheckTx instead of discarding them, the queued txs are released once the txs
+// preceding them pass CheckTx, or are committed in a block.
+func NewNonceHandlerWithQueue(queue *NonceQueue) *NonceHandler {
+	return &NonceHandler{nonceCache: make(map[string]uint64), lastHeight: 0, queue: queue}
+}
+
 func (n *NonceHandler) Nonce(
 	state loomchain.State,
 	kvStore store.KVStore,
@@ -159,7 +171,32 @@ func (n *NonceHandler) Nonce(
 		}
 	}
 
+	if !isCheckTx && n.queue != nil {
+		if n.delivered == nil {
+			n.delivered = make(map[string]loom.Address)
+		}
+		n.delivered[origin.String()] = origin
+	}
+
 	if tx.Sequence != seq {
+		if isCheckTx && n.queue != nil && tx.Sequence > seq {
+			// The tx is still rejected so it doesn't enter the mempool before the txs preceding it,
+			// it'll be resubmitted once the gap is filled.
+			txBytes := loomchain.TxBytesFromContext(state.Context())
+			if err := n.queue.Add(origin.String(), seq, &tx, txBytes); err != nil {
+				nonceErrorCount.Add(1)
+				return r, fmt.Errorf(
+					"sequence number does not match expected %d got %d, failed to queue tx: %v",
+					seq, tx.Sequence, err,
+				)
+			}
+			log.Log(state.Context()).Debug(
+				"Queued tx with future nonce", "origin", origin.String(), "nonce", tx.Sequence, "expected", seq,
+			)
+			// The error is reported to the client as a success, the tx will be executed once the
+			// preceding txs are received.
+			return r, loomchain.ErrTxQueued
+		}
 		nonceErrorCount.Add(1)
 		return r, fmt.Errorf("sequence number does not match expected %d got %d", seq, tx.Sequence)
 	}
@@ -188,9 +225,27 @@ func (n *NonceHandler) IncNonce(
 	} else {
 		n.nonceCache[origin.String()] = n.nonceCache[origin.String()] + 1
 	}
+
+	if isCheckTx && n.queue != nil {
+		n.queue.Release(origin.String(), n.nonceCache[origin.String()])
+	}
 	return nil
 }
 
+// ReleaseCommitted releases the queued txs that follow the txs committed in the last block, it
+// should be called after each block is committed. Without this txs queued on this node would only
+// be released when the txs preceding them pass CheckTx on this node, which doesn't happen when
+// those txs are submitted to another node and end up in a block before reaching this node.
+func (n *NonceHandler) ReleaseCommitted(state loomchain.ReadOnlyState) {
+	if n.queue == nil {
+		return
+	}
+	for account, addr := range n.delivered {
+		n.queue.Release(account, Nonce(state, addr)+1)
+	}
+	n.delivered = nil
+}
+
 func (n *NonceHandler) TxMiddleware(kvStore store.KVStore) loomchain.TxMiddlewareFunc {
 	return loomchain.TxMiddlewareFunc(func(
 		state loomchain.State,
@@ -3,6 +3,8 @@ package auth
 type Config struct {
 	// Per-chain tx signing config, indexed by chain ID
 	Chains map[string]ChainConfig
+	// Queueing of txs with future nonces in CheckTx
+	NonceQueue *NonceQueueConfig
 }
 
 type ChainConfig struct {
@@ -16,6 +18,7 @@ func DefaultConfig() *Config {
 			// NOTE: <chainID>: ChainConfig{TxType: "loom"} is auto-added by ChainConfigMiddleware
 			"eth": ChainConfig{TxType: "eth", AccountType: 1},
 		},
+		NonceQueue: DefaultNonceQueueConfig(),
 	}
 }
 
@@ -29,6 +32,7 @@ func (c *Config) Clone() *Config {
 	for k, v := range c.Chains {
 		clone.Chains[k] = v
 	}
+	clone.NonceQueue = c.NonceQueue.Clone()
 	return &clone
 }
 
@@ -94,3 +94,12 @@ func VerifyWrappedEthTx(chainID string, signedTx SignedTx, _ []evmcompat.Signatu
 
 	return from.Bytes(), err
 }
+
+// getEthTxPriority extracts the value & gas price of the given RLP encoded Ethereum tx.
+func getEthTxPriority(txBytes []byte) (txPriority, error) {
+	var tx etypes.Transaction
+	if err := rlp.DecodeBytes(txBytes, &tx); err != nil {
+		return txPriority{}, err
+	}
+	return txPriority{Value: tx.Value(), GasPrice: tx.GasPrice()}, nil
+}
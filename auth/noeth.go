@@ -23,3 +23,7 @@ func verifyBinance(_ string, _ SignedTx, _ []evmcompat.SignatureType) ([]byte, e
 func VerifyWrappedEthTx(_ string, signedTx SignedTx, _ []evmcompat.SignatureType) ([]byte, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+
+func getEthTxPriority(_ []byte) (txPriority, error) {
+	return txPriority{}, fmt.Errorf("not implemented")
+}
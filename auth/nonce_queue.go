@@ -0,0 +1,340 @@
+package auth
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/vm"
+	"github.com/pkg/errors"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/loomnetwork/loomchain/log"
+)
+
+var (
+	// ErrNonceQueueFull is returned when an account already has the maximum number of txs queued.
+	ErrNonceQueueFull = errors.New("nonce queue is full")
+	// ErrNonceTooFarAhead is returned when a tx nonce is too far ahead of the expected nonce to be queued.
+	ErrNonceTooFarAhead = errors.New("nonce is too far ahead of the expected nonce")
+	// ErrReplacementUnderpriced is returned when a tx with the same nonce as a queued tx doesn't
+	// have a higher value or gas price than the queued tx.
+	ErrReplacementUnderpriced = errors.New("replacement tx must have a higher value or gas price")
+
+	nonceQueueSize     metrics.Gauge
+	nonceQueueEvents   metrics.Counter
+	nonceQueueReleased metrics.Counter
+
+	logger = log.Module("auth")
+)
+
+func init() {
+	nonceQueueSize = kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "loomchain",
+		Subsystem: "nonce_queue",
+		Name:      "queued_txs",
+		Help:      "Number of txs with future nonces currently held in the nonce queue.",
+	}, []string{})
+	nonceQueueEvents = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "loomchain",
+		Subsystem: "nonce_queue",
+		Name:      "events",
+		Help:      "Number of txs queued, replaced, rejected, or expired by the nonce queue.",
+	}, []string{"event"})
+	nonceQueueReleased = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "loomchain",
+		Subsystem: "nonce_queue",
+		Name:      "released_txs",
+		Help:      "Number of queued txs resubmitted to the mempool.",
+	}, []string{})
+}
+
+type NonceQueueConfig struct {
+	// Enables queueing of txs with future nonces in CheckTx
+	Enabled bool
+	// Maximum number of txs that can be queued for a single account
+	MaxTxsPerAccount int
+	// Maximum distance between the nonce of a queued tx and the expected nonce
+	MaxNonceGap uint64
+	// Number of seconds a tx can remain in the queue before it's dropped
+	TTL int64
+}
+
+func DefaultNonceQueueConfig() *NonceQueueConfig {
+	return &NonceQueueConfig{
+		Enabled:          false,
+		MaxTxsPerAccount: 16,
+		MaxNonceGap:      64,
+		TTL:              60,
+	}
+}
+
+// Clone returns a deep clone of the config.
+func (c *NonceQueueConfig) Clone() *NonceQueueConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+// TxReleaseFunc resubmits a previously queued tx to the mempool.
+type TxReleaseFunc func(txBytes []byte) error
+
+type queuedTx struct {
+	txBytes   []byte
+	priority  txPriority
+	expiresAt time.Time
+}
+
+// NonceQueue holds txs with future nonces that were received by CheckTx before the txs that
+// precede them, this allows clients to submit txs without waiting for the previous ones to be
+// accepted by the node. Once the tx with the expected nonce is accepted the next queued tx from
+// the same account is resubmitted to the mempool, so queued txs are released in nonce order.
+// Queued txs that aren't released before their TTL expires are dropped.
+type NonceQueue struct {
+	cfg *NonceQueueConfig
+	now func() time.Time
+
+	mutex    sync.Mutex
+	accounts map[string]map[uint64]*queuedTx // queued txs indexed by account & nonce
+	size     int
+	released [][]byte // txs waiting to be resubmitted to the mempool, in release order
+
+	notifyCh chan struct{}
+	quitCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+func NewNonceQueue(cfg *NonceQueueConfig) *NonceQueue {
+	return &NonceQueue{
+		cfg:      cfg,
+		now:      time.Now,
+		accounts: make(map[string]map[uint64]*queuedTx),
+		notifyCh: make(chan struct{}, 1),
+	}
+}
+
+// Add queues a tx with a future nonce, expectedNonce is the nonce the account's next tx must have,
+// and txBytes are the raw bytes of the tx that will be resubmitted to the mempool when the tx is
+// released. If a tx with the same nonce is already queued it's replaced by the given tx, but only
+// if the given tx has a higher value or gas price.
+func (q *NonceQueue) Add(account string, expectedNonce uint64, tx *NonceTx, txBytes []byte) error {
+	nonce := tx.Sequence
+	if nonce <= expectedNonce {
+		return errors.Errorf("nonce %d isn't a future nonce", nonce)
+	}
+	if nonce-expectedNonce > q.cfg.MaxNonceGap {
+		nonceQueueEvents.With("event", "rejected").Add(1)
+		return ErrNonceTooFarAhead
+	}
+	if len(txBytes) == 0 {
+		return errors.New("tx bytes not available")
+	}
+
+	priority, err := getTxPriority(tx.Inner)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode tx")
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.removeStale(account, expectedNonce)
+	txs := q.accounts[account]
+	if txs == nil {
+		txs = make(map[uint64]*queuedTx)
+		q.accounts[account] = txs
+	}
+	qtx := &queuedTx{
+		txBytes:   txBytes,
+		priority:  priority,
+		expiresAt: q.now().Add(time.Duration(q.cfg.TTL) * time.Second),
+	}
+	if existing, ok := txs[nonce]; ok {
+		if !priority.higherThan(existing.priority) {
+			nonceQueueEvents.With("event", "rejected").Add(1)
+			return ErrReplacementUnderpriced
+		}
+		txs[nonce] = qtx
+		nonceQueueEvents.With("event", "replaced").Add(1)
+		return nil
+	}
+	if len(txs) >= q.cfg.MaxTxsPerAccount {
+		nonceQueueEvents.With("event", "rejected").Add(1)
+		return ErrNonceQueueFull
+	}
+	txs[nonce] = qtx
+	q.size++
+	nonceQueueSize.Set(float64(q.size))
+	nonceQueueEvents.With("event", "queued").Add(1)
+	return nil
+}
+
+// Release should be called when the account's expected nonce changes, if a tx with the new
+// expected nonce is queued it's removed from the queue and scheduled for resubmission.
+// Returns true if a tx was released.
+func (q *NonceQueue) Release(account string, expectedNonce uint64) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.removeStale(account, expectedNonce)
+	txs := q.accounts[account]
+	tx, ok := txs[expectedNonce]
+	if !ok {
+		return false
+	}
+	q.removeTx(account, expectedNonce)
+	q.released = append(q.released, tx.txBytes)
+
+	select {
+	case q.notifyCh <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Len returns the number of txs queued for the given account.
+func (q *NonceQueue) Len(account string) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.accounts[account])
+}
+
+// Start starts a goroutine that resubmits released txs to the mempool via the given function,
+// and periodically drops expired txs from the queue.
+func (q *NonceQueue) Start(release TxReleaseFunc) {
+	q.quitCh = make(chan struct{})
+	q.doneCh = make(chan struct{})
+	go q.run(release)
+}
+
+// Stop stops the goroutine started by Start.
+func (q *NonceQueue) Stop() {
+	close(q.quitCh)
+	<-q.doneCh
+}
+
+func (q *NonceQueue) run(release TxReleaseFunc) {
+	defer close(q.doneCh)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.notifyCh:
+			for _, txBytes := range q.takeReleased() {
+				if err := release(txBytes); err != nil {
+					logger.Error("Failed to resubmit queued tx", "err", err)
+					continue
+				}
+				nonceQueueReleased.Add(1)
+			}
+		case <-ticker.C:
+			q.removeExpired()
+		case <-q.quitCh:
+			return
+		}
+	}
+}
+
+func (q *NonceQueue) takeReleased() [][]byte {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	released := q.released
+	q.released = nil
+	return released
+}
+
+func (q *NonceQueue) removeExpired() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.now()
+	for account, txs := range q.accounts {
+		for nonce, tx := range txs {
+			if now.After(tx.expiresAt) {
+				q.removeTx(account, nonce)
+				nonceQueueEvents.With("event", "expired").Add(1)
+			}
+		}
+	}
+}
+
+// removeStale drops any queued txs that can no longer be executed because their nonce is lower
+// than the expected nonce, along with any expired txs.
+func (q *NonceQueue) removeStale(account string, expectedNonce uint64) {
+	now := q.now()
+	for nonce, tx := range q.accounts[account] {
+		if nonce < expectedNonce {
+			q.removeTx(account, nonce)
+		} else if now.After(tx.expiresAt) {
+			q.removeTx(account, nonce)
+			nonceQueueEvents.With("event", "expired").Add(1)
+		}
+	}
+}
+
+func (q *NonceQueue) removeTx(account string, nonce uint64) {
+	txs := q.accounts[account]
+	if _, ok := txs[nonce]; !ok {
+		return
+	}
+	delete(txs, nonce)
+	if len(txs) == 0 {
+		delete(q.accounts, account)
+	}
+	q.size--
+	nonceQueueSize.Set(float64(q.size))
+}
+
+// txPriority is used to decide whether a tx can replace a queued tx with the same nonce.
+type txPriority struct {
+	Value    *big.Int
+	GasPrice *big.Int
+}
+
+func (p txPriority) higherThan(other txPriority) bool {
+	return p.GasPrice.Cmp(other.GasPrice) > 0 || p.Value.Cmp(other.Value) > 0
+}
+
+// getTxPriority extracts the value & gas price of the given tx.
+func getTxPriority(txBytes []byte) (txPriority, error) {
+	priority := txPriority{Value: big.NewInt(0), GasPrice: big.NewInt(0)}
+
+	var tx types.Transaction
+	if err := proto.Unmarshal(txBytes, &tx); err != nil {
+		return priority, err
+	}
+	var msg vm.MessageTx
+	if err := proto.Unmarshal(tx.Data, &msg); err != nil {
+		return priority, err
+	}
+
+	var value *types.BigUInt
+	switch types.TxID(tx.Id) {
+	case types.TxID_DEPLOY:
+		var deployTx vm.DeployTx
+		if err := proto.Unmarshal(msg.Data, &deployTx); err != nil {
+			return priority, err
+		}
+		value = deployTx.Value
+	case types.TxID_CALL:
+		var callTx vm.CallTx
+		if err := proto.Unmarshal(msg.Data, &callTx); err != nil {
+			return priority, err
+		}
+		value = callTx.Value
+	case types.TxID_ETHEREUM:
+		return getEthTxPriority(msg.Data)
+	}
+	if value != nil && value.Value.Int != nil {
+		priority.Value = value.Value.Int
+	}
+	return priority, nil
+}
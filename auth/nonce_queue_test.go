@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/config"
+	"github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/vm"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+func makeQueueTestTx(t *testing.T, nonce uint64, value int64) *NonceTx {
+	callTxBytes, err := proto.Marshal(&vm.CallTx{
+		VmType: vm.VMType_PLUGIN,
+		Value:  &types.BigUInt{Value: *loom.NewBigUIntFromInt(value)},
+	})
+	require.NoError(t, err)
+	msgBytes, err := proto.Marshal(&vm.MessageTx{Data: callTxBytes})
+	require.NoError(t, err)
+	txBytes, err := proto.Marshal(&types.Transaction{Id: uint32(types.TxID_CALL), Data: msgBytes})
+	require.NoError(t, err)
+	return &NonceTx{Inner: txBytes, Sequence: nonce}
+}
+
+func TestNonceQueueAddRelease(t *testing.T) {
+	cfg := DefaultNonceQueueConfig()
+	cfg.MaxTxsPerAccount = 2
+	cfg.MaxNonceGap = 3
+	q := NewNonceQueue(cfg)
+	account := "default:0x1"
+
+	// only future nonces within the max gap can be queued
+	require.Error(t, q.Add(account, 5, makeQueueTestTx(t, 5, 0), []byte("tx5")))
+	require.Equal(t, ErrNonceTooFarAhead, q.Add(account, 1, makeQueueTestTx(t, 5, 0), []byte("tx5")))
+
+	require.NoError(t, q.Add(account, 1, makeQueueTestTx(t, 3, 0), []byte("tx3")))
+	require.NoError(t, q.Add(account, 1, makeQueueTestTx(t, 2, 0), []byte("tx2")))
+	require.Equal(t, ErrNonceQueueFull, q.Add(account, 1, makeQueueTestTx(t, 4, 0), []byte("tx4")))
+	require.Equal(t, 2, q.Len(account))
+
+	// replacements must have a higher value
+	require.Equal(t, ErrReplacementUnderpriced, q.Add(account, 1, makeQueueTestTx(t, 2, 0), []byte("tx2b")))
+	require.NoError(t, q.Add(account, 1, makeQueueTestTx(t, 2, 10), []byte("tx2b")))
+	require.Equal(t, 2, q.Len(account))
+
+	require.False(t, q.Release(account, 1))
+	require.True(t, q.Release(account, 2))
+	require.True(t, q.Release(account, 3))
+	require.Equal(t, 0, q.Len(account))
+	require.Equal(t, [][]byte{[]byte("tx2b"), []byte("tx3")}, q.takeReleased())
+}
+
+func TestNonceQueueExpiry(t *testing.T) {
+	cfg := DefaultNonceQueueConfig()
+	cfg.TTL = 10
+	q := NewNonceQueue(cfg)
+	now := time.Now()
+	q.now = func() time.Time { return now }
+	account := "default:0x1"
+
+	require.NoError(t, q.Add(account, 1, makeQueueTestTx(t, 2, 0), []byte("tx2")))
+	now = now.Add(5 * time.Second)
+	require.NoError(t, q.Add(account, 1, makeQueueTestTx(t, 3, 0), []byte("tx3")))
+
+	now = now.Add(6 * time.Second)
+	q.removeExpired()
+	require.Equal(t, 1, q.Len(account))
+	require.False(t, q.Release(account, 2))
+
+	// txs with nonces below the expected nonce are dropped
+	require.False(t, q.Release(account, 4))
+	require.Equal(t, 0, q.Len(account))
+}
+
+func TestNonceHandlerQueuesFutureNonces(t *testing.T) {
+	q := NewNonceQueue(DefaultNonceQueueConfig())
+	nonceTxHandler := NewNonceHandlerWithQueue(q)
+	postCommit := nonceTxHandler.PostCommitMiddleware()
+
+	pubkey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	origin := loom.Address{
+		ChainID: "default",
+		Local:   loom.LocalAddressFromPublicKey(pubkey),
+	}
+
+	cfg := config.DefaultConfig()
+	next := func(state loomchain.State, txBytes []byte, isCheckTx bool) (loomchain.TxHandlerResult, error) {
+		return loomchain.TxHandlerResult{}, nil
+	}
+	checkTx := func(nonce uint64) error {
+		txBytes, err := proto.Marshal(makeQueueTestTx(t, nonce, 0))
+		require.NoError(t, err)
+		ctx := context.WithValue(context.Background(), ContextKeyOrigin, origin)
+		ctx = loomchain.WithTxBytes(ctx, []byte(fmt.Sprintf("tx%d", nonce)))
+		// CheckTx state changes are never persisted
+		kvStore := store.NewMemStore()
+		state := loomchain.NewStoreState(ctx, kvStore, abci.Header{Height: 27}, nil, nil).WithOnChainConfig(cfg)
+		if _, err := nonceTxHandler.Nonce(state, kvStore, txBytes, next, true); err != nil {
+			return err
+		}
+		return postCommit(state, txBytes, loomchain.TxHandlerResult{}, nil, true)
+	}
+
+	// txs 2 & 3 arrive before tx 1, so they should be queued
+	require.Equal(t, loomchain.ErrTxQueued, checkTx(3))
+	require.Equal(t, loomchain.ErrTxQueued, checkTx(2))
+	require.Equal(t, 2, q.Len(origin.String()))
+
+	require.NoError(t, checkTx(1))
+	require.Equal(t, [][]byte{[]byte("tx2")}, q.takeReleased())
+	require.NoError(t, checkTx(2))
+	require.Equal(t, [][]byte{[]byte("tx3")}, q.takeReleased())
+	require.NoError(t, checkTx(3))
+	require.Equal(t, 0, q.Len(origin.String()))
+}
+
+func TestNonceHandlerReleasesCommittedNonces(t *testing.T) {
+	q := NewNonceQueue(DefaultNonceQueueConfig())
+	nonceTxHandler := NewNonceHandlerWithQueue(q)
+	postCommit := nonceTxHandler.PostCommitMiddleware()
+
+	pubkey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	origin := loom.Address{
+		ChainID: "default",
+		Local:   loom.LocalAddressFromPublicKey(pubkey),
+	}
+
+	cfg := config.DefaultConfig()
+	next := func(state loomchain.State, txBytes []byte, isCheckTx bool) (loomchain.TxHandlerResult, error) {
+		return loomchain.TxHandlerResult{}, nil
+	}
+	committed := store.NewMemStore()
+	runTx := func(nonce uint64, height int64, isCheckTx bool) error {
+		txBytes, err := proto.Marshal(makeQueueTestTx(t, nonce, 0))
+		require.NoError(t, err)
+		ctx := context.WithValue(context.Background(), ContextKeyOrigin, origin)
+		ctx = loomchain.WithTxBytes(ctx, []byte(fmt.Sprintf("tx%d", nonce)))
+		kvStore := committed
+		if isCheckTx {
+			kvStore = store.NewMemStore()
+		}
+		state := loomchain.NewStoreState(ctx, kvStore, abci.Header{Height: height}, nil, nil).WithOnChainConfig(cfg)
+		if _, err := nonceTxHandler.Nonce(state, kvStore, txBytes, next, isCheckTx); err != nil {
+			return err
+		}
+		return postCommit(state, txBytes, loomchain.TxHandlerResult{}, nil, isCheckTx)
+	}
+
+	// tx 2 arrives before tx 1, and tx 1 never passes CheckTx on this node because it was submitted
+	// to another node, so tx 2 must be released once tx 1 is committed.
+	require.Equal(t, loomchain.ErrTxQueued, runTx(2, 27, true))
+	require.NoError(t, runTx(1, 28, false))
+	require.Len(t, q.takeReleased(), 0)
+
+	state := loomchain.NewStoreState(context.Background(), committed, abci.Header{Height: 28}, nil, nil)
+	nonceTxHandler.ReleaseCommitted(state)
+	require.Equal(t, [][]byte{[]byte("tx2")}, q.takeReleased())
+	require.Equal(t, 0, q.Len(origin.String()))
+
+	// accounts are only tracked until the block is committed
+	nonceTxHandler.ReleaseCommitted(state)
+	require.Len(t, q.takeReleased(), 0)
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/loomnetwork/go-loom/common/evmcompat"
 	"github.com/loomnetwork/go-loom/plugin"
 	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/types"
 	"github.com/loomnetwork/go-loom/util"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/loomnetwork/loomchain/features"
 	ssha "github.com/miguelmota/go-solidity-sha3"
 	"github.com/pkg/errors"
@@ -25,7 +27,6 @@ type (
 
 	InitRequest               = amtypes.AddressMapperInitRequest
 	AddIdentityMappingRequest = amtypes.AddressMapperAddIdentityMappingRequest
-	RemoveMappingRequest      = amtypes.AddressMapperRemoveMappingRequest
 	GetMappingRequest         = amtypes.AddressMapperGetMappingRequest
 	GetMappingResponse        = amtypes.AddressMapperGetMappingResponse
 
@@ -46,14 +47,29 @@ var (
 	// ErrAlreadyRegistered indicates that from and/or to are already registered in
 	// address mapper contract.
 	ErrAlreadyRegistered = errors.New("[Address Mapper] identity mapping already exists")
+	// ErrMappingNotFound indicates that the address specified in the request isn't mapped to any
+	// other address.
+	ErrMappingNotFound = errors.New("[Address Mapper] identity mapping not found")
 
 	AddressPrefix = "addr"
+	// Prefix of the keys used to store the number of times a mapping was removed for an address.
+	RemovalNoncePrefix = "rmnonce"
+)
+
+const (
+	// RemoveMappingEventTopic is the topic of the event emitted when a mapping is removed,
+	// the event data is an AddressMapping.
+	RemoveMappingEventTopic = "addressmapper:removemapping"
 )
 
 func addressKey(addr loom.Address) []byte {
 	return util.PrefixKey([]byte(AddressPrefix), addr.Bytes())
 }
 
+func removalNonceKey(addr loom.Address) []byte {
+	return util.PrefixKey([]byte(RemovalNoncePrefix), addr.Bytes())
+}
+
 type AddressMapper struct {
 }
 
@@ -83,16 +99,7 @@ func (am *AddressMapper) AddIdentityMapping(ctx contract.Context, req *AddIdenti
 		return ErrInvalidRequest
 	}
 
-	allowedSigTypes := []evmcompat.SignatureType{
-		evmcompat.SignatureType_EIP712,
-		evmcompat.SignatureType_GETH,
-		evmcompat.SignatureType_TREZOR,
-		evmcompat.SignatureType_TRON,
-	}
-	if ctx.FeatureEnabled(features.AddressMapperVersion1_1, false) {
-		allowedSigTypes = append(allowedSigTypes, evmcompat.SignatureType_BINANCE)
-	}
-
+	allowedSigTypes := getAllowedSigTypes(ctx)
 	callerAddr := ctx.Message().Sender
 	if callerAddr.Compare(from) == 0 {
 		if err := verifySig(from, to, to.ChainID, req.Signature, allowedSigTypes); err != nil {
@@ -137,9 +144,77 @@ func (am *AddressMapper) AddIdentityMapping(ctx contract.Context, req *AddIdenti
 	return nil
 }
 
-func (am *AddressMapper) RemoveMapping(ctx contract.StaticContext, req *RemoveMappingRequest) error {
-	// TODO
-	return nil
+// RemoveMapping removes the mapping between a DAppChain account and a foreign account.
+// The caller must be one of the mapped accounts, or provide a signature generated by the private key
+// of the foreign account (see SignRemoveMapping).
+func (am *AddressMapper) RemoveMapping(ctx contract.Context, req *RemoveMappingRequest) error {
+	if !ctx.FeatureEnabled(features.AddressMapperVersion1_2, false) {
+		return errors.New("[Address Mapper] RemoveMapping is not enabled")
+	}
+	if req.From == nil {
+		return ErrInvalidRequest
+	}
+
+	from := loom.UnmarshalAddressPB(req.From)
+	var mapping AddressMapping
+	if err := ctx.Get(addressKey(from), &mapping); err != nil {
+		if err == contract.ErrNotFound {
+			return ErrMappingNotFound
+		}
+		return errors.Wrapf(err, "[Address Mapper] failed to load mapping for address: %v", from)
+	}
+	to := loom.UnmarshalAddressPB(mapping.To)
+
+	// The DAppChain account is the one that's on the same chain as this contract.
+	localAddr, foreignAddr := from, to
+	if from.ChainID != ctx.ContractAddress().ChainID {
+		localAddr, foreignAddr = to, from
+	}
+
+	nonce, err := loadRemovalNonce(ctx, foreignAddr)
+	if err != nil {
+		return err
+	}
+
+	callerAddr := ctx.Message().Sender
+	if callerAddr.Compare(from) != 0 && callerAddr.Compare(to) != 0 {
+		if len(req.Signature) == 0 {
+			return ErrNotAuthorized
+		}
+		err := verifyRemoveMappingSig(
+			ctx.ContractAddress(), localAddr, foreignAddr, nonce, req.Signature, getAllowedSigTypes(ctx),
+		)
+		if err != nil {
+			return errors.Wrap(err, ErrNotAuthorized.Error())
+		}
+	}
+
+	ctx.Delete(addressKey(from))
+	ctx.Delete(addressKey(to))
+
+	// Bump the nonce so the signature (if any) can't be used to remove a future mapping
+	if err := ctx.Set(removalNonceKey(foreignAddr), &types.BigUInt{
+		Value: *loom.NewBigUIntFromInt(int64(nonce + 1)),
+	}); err != nil {
+		return err
+	}
+
+	return emitRemoveMappingEvent(ctx, &mapping)
+}
+
+// GetRemovalNonce returns the nonce that must be used to sign a request to remove the mapping of the
+// given foreign address.
+func (am *AddressMapper) GetRemovalNonce(
+	ctx contract.StaticContext, req *GetMappingRequest,
+) (*types.BigUInt, error) {
+	if req.From == nil {
+		return nil, ErrInvalidRequest
+	}
+	nonce, err := loadRemovalNonce(ctx, loom.UnmarshalAddressPB(req.From))
+	if err != nil {
+		return nil, err
+	}
+	return &types.BigUInt{Value: *loom.NewBigUIntFromInt(int64(nonce))}, nil
 }
 
 func (am *AddressMapper) ListMapping(ctx contract.StaticContext, req *ListMappingRequest) (*ListMappingResponse, error) {
@@ -166,6 +241,39 @@ func (am *AddressMapper) ListMapping(ctx contract.StaticContext, req *ListMappin
 	return &listMappingResponse, nil
 }
 
+// ListMappingPage returns a page of the identity mappings along with the cursor of the next page.
+// Like ListMapping each mapping is only returned once, even though it's stored under both addresses,
+// so a page may contain fewer mappings than the limit.
+func (am *AddressMapper) ListMappingPage(
+	ctx contract.StaticContext, req *pagination.PageRequest,
+) (*ListMappingPageResponse, error) {
+	mappingRange, nextCursor, err := pagination.Range(ctx, []byte(AddressPrefix), req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := []*AddressMapping{}
+	for _, m := range mappingRange {
+		var mapping AddressMapping
+		if err := proto.Unmarshal(m.Value, &mapping); err != nil {
+			return nil, errors.Wrap(err, "unmarshal mapping")
+		}
+		// Only keep the mapping stored under the lower address, unless the reverse mapping is missing.
+		to := loom.UnmarshalAddressPB(mapping.To)
+		if bytes.Compare(m.Key, to.Bytes()) > 0 && ctx.Has(addressKey(to)) {
+			continue
+		}
+		mappings = append(mappings, &AddressMapping{
+			From: mapping.From,
+			To:   mapping.To,
+		})
+	}
+	return &ListMappingPageResponse{
+		Mappings:   mappings,
+		NextCursor: nextCursor,
+	}, nil
+}
+
 func (am *AddressMapper) HasMapping(ctx contract.StaticContext, req *HasMappingRequest) (*HasMappingResponse, error) {
 	if req.From == nil {
 		return nil, ErrInvalidRequest
@@ -201,6 +309,39 @@ func (am *AddressMapper) GetMapping(ctx contract.StaticContext, req *GetMappingR
 	}, nil
 }
 
+func getAllowedSigTypes(ctx contract.StaticContext) []evmcompat.SignatureType {
+	allowedSigTypes := []evmcompat.SignatureType{
+		evmcompat.SignatureType_EIP712,
+		evmcompat.SignatureType_GETH,
+		evmcompat.SignatureType_TREZOR,
+		evmcompat.SignatureType_TRON,
+	}
+	if ctx.FeatureEnabled(features.AddressMapperVersion1_1, false) {
+		allowedSigTypes = append(allowedSigTypes, evmcompat.SignatureType_BINANCE)
+	}
+	return allowedSigTypes
+}
+
+func loadRemovalNonce(ctx contract.StaticContext, addr loom.Address) (uint64, error) {
+	var nonce types.BigUInt
+	if err := ctx.Get(removalNonceKey(addr), &nonce); err != nil {
+		if err == contract.ErrNotFound {
+			return 0, nil
+		}
+		return 0, errors.Wrapf(err, "[Address Mapper] failed to load removal nonce for address: %v", addr)
+	}
+	return nonce.Value.Uint64(), nil
+}
+
+func emitRemoveMappingEvent(ctx contract.Context, mapping *AddressMapping) error {
+	marshalled, err := proto.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	ctx.EmitTopics(marshalled, RemoveMappingEventTopic)
+	return nil
+}
+
 func verifySig(from, to loom.Address, chainID string, sig []byte, allowedSigTypes []evmcompat.SignatureType) error {
 	if (chainID != from.ChainID) && (chainID != to.ChainID) {
 		return fmt.Errorf("chain ID %s doesn't match either address", chainID)
@@ -250,4 +391,59 @@ func SignIdentityMapping(from, to loom.Address, key *ecdsa.PrivateKey, sigType e
 	return evmcompat.GenerateTypedSig(hash, key, sigType)
 }
 
+// removeMappingHash returns the hash that must be signed to authorize the removal of a mapping,
+// the hash includes the chain ID & the address of the contract so the signature can't be replayed
+// on another chain or against another contract.
+func removeMappingHash(
+	contractAddr, localAddr, foreignAddr loom.Address, nonce uint64, sigType evmcompat.SignatureType,
+) []byte {
+	if sigType == evmcompat.SignatureType_BINANCE {
+		return evmcompat.GenSHA256(
+			ssha.String("RemoveMapping"),
+			ssha.String(contractAddr.ChainID),
+			ssha.Address(common.BytesToAddress(contractAddr.Local)),
+			ssha.Address(common.BytesToAddress(localAddr.Local)),
+			ssha.Address(common.BytesToAddress(foreignAddr.Local)),
+			ssha.Uint64(nonce),
+		)
+	}
+	return ssha.SoliditySHA3(
+		ssha.String("RemoveMapping"),
+		ssha.String(contractAddr.ChainID),
+		ssha.Address(common.BytesToAddress(contractAddr.Local)),
+		ssha.Address(common.BytesToAddress(localAddr.Local)),
+		ssha.Address(common.BytesToAddress(foreignAddr.Local)),
+		ssha.Uint64(nonce),
+	)
+}
+
+func verifyRemoveMappingSig(
+	contractAddr, localAddr, foreignAddr loom.Address, nonce uint64, sig []byte,
+	allowedSigTypes []evmcompat.SignatureType,
+) error {
+	hash := removeMappingHash(contractAddr, localAddr, foreignAddr, nonce, evmcompat.SignatureType(sig[0]))
+	signerAddr, err := evmcompat.RecoverAddressFromTypedSig(hash, sig, allowedSigTypes)
+	if err != nil {
+		return err
+	}
+	if bytes.Compare(signerAddr.Bytes(), foreignAddr.Local) != 0 {
+		return fmt.Errorf("signer address doesn't match, %s != %s", signerAddr.Hex(), foreignAddr.Local.String())
+	}
+	return nil
+}
+
+// SignRemoveMapping generates the signature that authorizes the removal of the mapping between the
+// given DAppChain and foreign accounts, the key must be the private key of the foreign account, and
+// the contract address must be the address of the Address Mapper contract the request will be sent to.
+func SignRemoveMapping(
+	contractAddr, localAddr, foreignAddr loom.Address, nonce uint64, key *ecdsa.PrivateKey,
+	sigType evmcompat.SignatureType,
+) ([]byte, error) {
+	hash := removeMappingHash(contractAddr, localAddr, foreignAddr, nonce, sigType)
+	if sigType == evmcompat.SignatureType_TRON {
+		hash = evmcompat.PrefixHeader(hash, evmcompat.SignatureType_TRON)
+	}
+	return evmcompat.GenerateTypedSig(hash, key, sigType)
+}
+
 var Contract plugin.Contract = contract.MakePluginContract(&AddressMapper{})
@@ -15,6 +15,7 @@ import (
 	"github.com/loomnetwork/go-loom/common/evmcompat"
 	"github.com/loomnetwork/go-loom/plugin"
 	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/loomnetwork/loomchain/features"
 	ssha "github.com/miguelmota/go-solidity-sha3"
 	"github.com/stretchr/testify/suite"
@@ -255,6 +256,63 @@ func (s *AddressMapperTestSuite) TestListMapping() {
 	s.Equal(1, len(resp.Mappings))
 }
 
+func (s *AddressMapperTestSuite) TestListMappingPage() {
+	r := s.Require()
+	fakeCtx := plugin.CreateFakeContext(s.validDAppAddr /*caller*/, loom.RootAddress("chain") /*contract*/)
+
+	amContract := &AddressMapper{}
+	r.NoError(amContract.Init(contract.WrapPluginContext(fakeCtx), &InitRequest{}))
+
+	expected := map[string]string{}
+	for _, dappAddr := range []loom.Address{addr1, addr2, addr3, addr4} {
+		ethKey, err := crypto.GenerateKey()
+		r.NoError(err)
+		ethLocalAddr, err := loom.LocalAddressFromHexString(crypto.PubkeyToAddress(ethKey.PublicKey).Hex())
+		r.NoError(err)
+		ethAddr := loom.Address{ChainID: "eth", Local: ethLocalAddr}
+
+		sig, err := SignIdentityMapping(ethAddr, dappAddr, ethKey, sigType)
+		r.NoError(err)
+		r.NoError(amContract.AddIdentityMapping(contract.WrapPluginContext(fakeCtx.WithSender(dappAddr)), &AddIdentityMappingRequest{
+			From:      ethAddr.MarshalPB(),
+			To:        dappAddr.MarshalPB(),
+			Signature: sig,
+		}))
+		expected[ethAddr.String()] = dappAddr.String()
+	}
+
+	ctx := contract.WrapPluginContext(fakeCtx)
+	listResp, err := amContract.ListMapping(ctx, &ListMappingRequest{})
+	r.NoError(err)
+	r.Len(listResp.Mappings, len(expected))
+
+	actual := map[string]string{}
+	cursor := ""
+	for {
+		resp, err := amContract.ListMappingPage(ctx, &pagination.PageRequest{Cursor: cursor, Limit: 3})
+		r.NoError(err)
+		r.True(len(resp.Mappings) <= 3)
+		for _, m := range resp.Mappings {
+			from, to := loom.UnmarshalAddressPB(m.From), loom.UnmarshalAddressPB(m.To)
+			// Each mapping should only be returned once, in either direction.
+			if from.ChainID != "eth" {
+				from, to = to, from
+			}
+			_, seen := actual[from.String()]
+			r.False(seen)
+			actual[from.String()] = to.String()
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	s.Equal(expected, actual)
+
+	_, err = amContract.ListMappingPage(ctx, &pagination.PageRequest{Cursor: "not-a-cursor"})
+	r.Error(err)
+}
+
 // Same as the other test case but the from/to inverted when adding the mapping,
 // since the mapping is bi-directional the end result should be identical to the first test case.
 func (s *AddressMapperTestSuite) TestAddressMapperAddNewInvertedIdentityMapping() {
@@ -419,3 +477,93 @@ func (s *AddressMapperTestSuite) TestBinanceSigRecovery() {
 	r.NoError(err)
 	r.Equal(common.HexToAddress("0x131cD1A71cBc107b773c1763e7c9E11b26548F0c").Hex(), addr.Hex())
 }
+
+func (s *AddressMapperTestSuite) TestAddressMapperRemoveMapping() {
+	r := s.Require()
+	amAddr := loom.RootAddress("chain")
+	pctx := plugin.CreateFakeContext(s.validDAppAddr /*caller*/, amAddr /*contract*/)
+	ctx := contract.WrapPluginContext(pctx)
+
+	amContract := &AddressMapper{}
+	r.NoError(amContract.Init(ctx, &InitRequest{}))
+
+	sig, err := SignIdentityMapping(s.validEthAddr, s.validDAppAddr, s.validEthKey, sigType)
+	r.NoError(err)
+	r.NoError(amContract.AddIdentityMapping(ctx, &AddIdentityMappingRequest{
+		From:      s.validEthAddr.MarshalPB(),
+		To:        s.validDAppAddr.MarshalPB(),
+		Signature: sig,
+	}))
+
+	r.Error(amContract.RemoveMapping(ctx, &RemoveMappingRequest{
+		From: s.validEthAddr.MarshalPB(),
+	}), "should error if the feature flag isn't enabled")
+
+	pctx.SetFeature(features.AddressMapperVersion1_2, true)
+
+	// a third party can't remove the mapping without a signature from the foreign key
+	otherCtx := contract.WrapPluginContext(pctx.WithSender(addr2))
+	r.Equal(ErrNotAuthorized, amContract.RemoveMapping(otherCtx, &RemoveMappingRequest{
+		From: s.validEthAddr.MarshalPB(),
+	}))
+	badSig, err := SignRemoveMapping(amAddr, s.validDAppAddr, s.validEthAddr, 0, s.invalidEthKey, sigType)
+	r.NoError(err)
+	r.Error(amContract.RemoveMapping(otherCtx, &RemoveMappingRequest{
+		From:      s.validEthAddr.MarshalPB(),
+		Signature: badSig,
+	}))
+	// signatures generated for another contract, or for a contract on another chain, are rejected
+	for _, otherAmAddr := range []loom.Address{
+		{ChainID: amAddr.ChainID, Local: addr2.Local},
+		loom.RootAddress("otherchain"),
+	} {
+		replayedSig, err := SignRemoveMapping(otherAmAddr, s.validDAppAddr, s.validEthAddr, 0, s.validEthKey, sigType)
+		r.NoError(err)
+		r.Error(amContract.RemoveMapping(otherCtx, &RemoveMappingRequest{
+			From:      s.validEthAddr.MarshalPB(),
+			Signature: replayedSig,
+		}))
+	}
+
+	// the owner of the DAppChain account can remove the mapping
+	r.NoError(amContract.RemoveMapping(ctx, &RemoveMappingRequest{
+		From: s.validEthAddr.MarshalPB(),
+	}))
+	for _, addr := range []loom.Address{s.validEthAddr, s.validDAppAddr} {
+		resp, err := amContract.HasMapping(ctx, &HasMappingRequest{From: addr.MarshalPB()})
+		r.NoError(err)
+		r.False(resp.HasMapping)
+	}
+	r.Equal(ErrMappingNotFound, amContract.RemoveMapping(ctx, &RemoveMappingRequest{
+		From: s.validEthAddr.MarshalPB(),
+	}))
+
+	// a third party can remove the mapping with a signature from the foreign key
+	r.NoError(amContract.AddIdentityMapping(ctx, &AddIdentityMappingRequest{
+		From:      s.validEthAddr.MarshalPB(),
+		To:        s.validDAppAddr.MarshalPB(),
+		Signature: sig,
+	}))
+	nonce, err := amContract.GetRemovalNonce(ctx, &GetMappingRequest{From: s.validEthAddr.MarshalPB()})
+	r.NoError(err)
+	r.Equal(uint64(1), nonce.Value.Uint64())
+	removalSig, err := SignRemoveMapping(amAddr, s.validDAppAddr, s.validEthAddr, 1, s.validEthKey, sigType)
+	r.NoError(err)
+	r.NoError(amContract.RemoveMapping(otherCtx, &RemoveMappingRequest{
+		From:      s.validDAppAddr.MarshalPB(),
+		Signature: removalSig,
+	}))
+	_, err = amContract.GetMapping(ctx, &GetMappingRequest{From: s.validDAppAddr.MarshalPB()})
+	r.Error(err)
+
+	// the same signature can't be used to remove a new mapping
+	r.NoError(amContract.AddIdentityMapping(ctx, &AddIdentityMappingRequest{
+		From:      s.validEthAddr.MarshalPB(),
+		To:        s.validDAppAddr.MarshalPB(),
+		Signature: sig,
+	}))
+	r.Error(amContract.RemoveMapping(otherCtx, &RemoveMappingRequest{
+		From:      s.validDAppAddr.MarshalPB(),
+		Signature: removalSig,
+	}))
+}
@@ -1,6 +1,7 @@
 package chainconfig
 
 import (
+	"encoding/hex"
 	"sort"
 
 	"github.com/gogo/protobuf/proto"
@@ -12,6 +13,7 @@ import (
 	plugintypes "github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/go-loom/util"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/loomnetwork/loomchain/features"
 	"github.com/loomnetwork/loomchain/registry"
 	"github.com/pkg/errors"
@@ -158,7 +160,7 @@ func (c *ChainConfig) SetParams(ctx contract.Context, req *SetParamsRequest) err
 		return ErrInvalidRequest
 	}
 
-	if ok, _ := ctx.HasPermission(setParamsPerm, []string{ownerRole}); !ok {
+	if ok, _ := ctx.HasPermission(setParamsPerm, []string{ownerRole}); !ok && !isGovernance(ctx) {
 		return ErrNotAuthorized
 	}
 
@@ -274,6 +276,70 @@ func (c *ChainConfig) ListFeatures(ctx contract.StaticContext, req *ListFeatures
 	}, nil
 }
 
+// ListFeaturesPage returns info about a page of the currently known features, ordered by name, along
+// with the cursor of the next page.
+func (c *ChainConfig) ListFeaturesPage(
+	ctx contract.StaticContext, req *pagination.PageRequest,
+) (*ListFeaturesPageResponse, error) {
+	curValidators, err := getCurrentValidators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	featureRange, nextCursor, err := pagination.Range(ctx, []byte(featurePrefix), req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	// Features enabled without going through this contract (e.g. via a migration tx) are merged
+	// into the page, but if there are more features in this contract then only those that come
+	// before the last feature in the page can be merged in, the rest belong to later pages.
+	var lastName string
+	names := make([]string, 0, len(featureRange))
+	for _, m := range featureRange {
+		names = append(names, string(m.Key))
+		lastName = string(m.Key)
+	}
+	cursor, err := hex.DecodeString(req.Cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	featuresFromState := make(map[string]bool)
+	for _, feature := range ctx.EnabledFeatures() {
+		if featuresFromState[feature] || feature <= string(cursor) ||
+			(nextCursor != "" && feature > lastName) || ctx.Has(featureKey(feature)) {
+			continue
+		}
+		featuresFromState[feature] = true
+		names = append(names, feature)
+	}
+	sort.Strings(names)
+	if limit := int(pagination.Limit(req.Limit)); len(names) > limit {
+		names = names[:limit]
+		nextCursor = hex.EncodeToString([]byte(names[limit-1]))
+	}
+
+	features := make([]*Feature, 0, len(names))
+	for _, name := range names {
+		if featuresFromState[name] {
+			features = append(features, &Feature{
+				Name:        name,
+				BlockHeight: 0,
+				BuildNumber: 0,
+				Status:      cctypes.Feature_ENABLED,
+			})
+			continue
+		}
+		feature, err := getFeature(ctx, name, curValidators)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, feature)
+	}
+	return &ListFeaturesPageResponse{
+		Features:   features,
+		NextCursor: nextCursor,
+	}, nil
+}
+
 // GetFeature returns info about a specific feature.
 func (c *ChainConfig) GetFeature(ctx contract.StaticContext, req *GetFeatureRequest) (*GetFeatureResponse, error) {
 	if req.Name == "" {
@@ -15,6 +15,7 @@ import (
 	"github.com/loomnetwork/loomchain/builtin/plugins/coin"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv2"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/loomnetwork/loomchain/features"
 	"github.com/stretchr/testify/suite"
 )
@@ -673,3 +674,203 @@ func (c *ChainConfigTestSuite) TestCfgSettingFourValidators() {
 	require.NoError(err)
 
 }
+
+func (c *ChainConfigTestSuite) TestListFeaturesPage() {
+	require := c.Require()
+	encoder := base64.StdEncoding
+	pubKeyB64_1, _ := encoder.DecodeString(pubKey1)
+	chainID := "default"
+	addr1 := loom.Address{ChainID: chainID, Local: loom.LocalAddressFromPublicKey(pubKeyB64_1)}
+	validators := []*loom.Validator{
+		&loom.Validator{
+			PubKey: pubKeyB64_1,
+			Power:  10,
+		},
+	}
+	pctx := plugin.CreateFakeContext(addr1, addr1).WithBlock(loom.BlockHeader{
+		ChainID: chainID,
+		Time:    time.Now().Unix(),
+	}).WithValidators(validators)
+
+	//Init fake coin contract
+	coinContract := &coin.Coin{}
+	coinAddr := pctx.CreateContract(coin.Contract)
+	coinCtx := pctx.WithAddress(coinAddr)
+	err := coinContract.Init(contractpb.WrapPluginContext(coinCtx), &coin.InitRequest{
+		Accounts: []*coin.InitialAccount{},
+	})
+	require.NoError(err)
+
+	//Init fake dposv2 contract
+	dposv2Contract := dposv2.DPOS{}
+	dposv2Addr := pctx.CreateContract(dposv2.Contract)
+	pctx = pctx.WithAddress(dposv2Addr)
+	ctx := contractpb.WrapPluginContext(pctx)
+
+	err = dposv2Contract.Init(ctx, &dposv2.InitRequest{
+		Params: &dposv2.Params{
+			ValidatorCount: 21,
+		},
+		Validators: validators,
+	})
+	require.NoError(err)
+
+	//setup chainconfig contract
+	chainconfigContract := &ChainConfig{}
+	err = chainconfigContract.Init(ctx, &InitRequest{
+		Owner: addr1.MarshalPB(),
+		Params: &Params{
+			VoteThreshold:         66,
+			NumBlockConfirmations: 10,
+		},
+	})
+	require.NoError(err)
+
+	err = chainconfigContract.AddFeature(ctx, &AddFeatureRequest{
+		Names: []string{"a-ft", "c-ft", "e-ft"},
+	})
+	require.NoError(err)
+	// Features that have been enabled without going through the contract should be merged into
+	// the pages in the right order.
+	pctx.SetFeature("b-ft", true)
+	pctx.SetFeature("d-ft", true)
+
+	listResp, err := chainconfigContract.ListFeatures(ctx, &ListFeaturesRequest{})
+	require.NoError(err)
+	require.Len(listResp.Features, 5)
+
+	var pages [][]string
+	cursor := ""
+	for {
+		resp, err := chainconfigContract.ListFeaturesPage(ctx, &pagination.PageRequest{Cursor: cursor, Limit: 2})
+		require.NoError(err)
+		var page []string
+		for _, feature := range resp.Features {
+			page = append(page, feature.Name)
+		}
+		pages = append(pages, page)
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	require.Equal([][]string{{"a-ft", "b-ft"}, {"c-ft", "d-ft"}, {"e-ft"}}, pages)
+
+	_, err = chainconfigContract.ListFeaturesPage(ctx, &pagination.PageRequest{Cursor: "not-a-cursor"})
+	require.Error(err)
+}
+
+func (c *ChainConfigTestSuite) TestContractUpgradeProposals() {
+	require := c.Require()
+	encoder := base64.StdEncoding
+	chainID := "default"
+	var addrs []loom.Address
+	var validators []*loom.Validator
+	for _, pubKey := range []string{pubKey1, pubKey2, pubKey3, pubKey4} {
+		pubKeyBytes, err := encoder.DecodeString(pubKey)
+		require.NoError(err)
+		addrs = append(addrs, loom.Address{ChainID: chainID, Local: loom.LocalAddressFromPublicKey(pubKeyBytes)})
+		validators = append(validators, &loom.Validator{PubKey: pubKeyBytes, Power: 10})
+	}
+	addr1, addr2, addr3, addr4 := addrs[0], addrs[1], addrs[2], addrs[3]
+	// addr4 isn't a validator
+	validators = validators[:3]
+
+	pctx := plugin.CreateFakeContext(addr1, addr1).WithBlock(loom.BlockHeader{
+		ChainID: chainID,
+		Height:  10,
+		Time:    time.Now().Unix(),
+	}).WithValidators(validators)
+	pctx.SetFeature(features.ChainCfgVersion1_1, true)
+
+	coinAddr := pctx.CreateContract(coin.Contract)
+	pctx.RegisterContract("coin", coinAddr, addr4)
+	otherAddr := pctx.CreateContract(coin.Contract)
+	pctx.RegisterContract("othercoin", otherAddr, addr4)
+
+	chainconfigContract := &ChainConfig{}
+	chainconfigAddr := pctx.CreateContract(Contract)
+	pctx = pctx.WithAddress(chainconfigAddr)
+	err := chainconfigContract.Init(contractpb.WrapPluginContext(pctx), &InitRequest{
+		Owner: addr1.MarshalPB(),
+		Params: &Params{
+			VoteThreshold:         66,
+			NumBlockConfirmations: 10,
+		},
+	})
+	require.NoError(err)
+
+	propose := func(sender, contractAddr loom.Address, blockHeight int64) error {
+		return chainconfigContract.ProposeContractUpgrade(
+			contractpb.WrapPluginContext(pctx.WithSender(sender)),
+			&ProposeContractUpgradeRequest{
+				ContractAddress: contractAddr.MarshalPB(),
+				PluginName:      "coin:2.0.0",
+				BlockHeight:     blockHeight,
+			},
+		)
+	}
+	approve := func(sender, contractAddr loom.Address) error {
+		return chainconfigContract.ApproveContractUpgrade(
+			contractpb.WrapPluginContext(pctx.WithSender(sender)),
+			&ApproveContractUpgradeRequest{ContractAddress: contractAddr.MarshalPB()},
+		)
+	}
+	listProposals := func() []*ContractUpgradeProposal {
+		resp, err := chainconfigContract.ListContractUpgradeProposals(
+			contractpb.WrapPluginContext(pctx), &ListContractUpgradeProposalsRequest{},
+		)
+		require.NoError(err)
+		return resp.Proposals
+	}
+
+	require.Equal(ErrFeatureNotEnabled, propose(addr4, coinAddr, 20))
+	pctx.SetFeature(features.ContractUpgradeFeature, true)
+
+	// only the contract creator & the ChainConfig owner can propose upgrades
+	require.Equal(ErrNotAuthorized, propose(addr2, coinAddr, 20))
+	require.Error(propose(addr4, coinAddr, 10))
+	require.NoError(propose(addr4, coinAddr, 20))
+	require.Equal(ErrUpgradeAlreadyProposed, propose(addr4, coinAddr, 30))
+	require.NoError(propose(addr1, otherAddr, 20))
+
+	// proposals from the contract creator must still be approved by the validators
+	proposals := listProposals()
+	require.Len(proposals, 2)
+	for _, proposal := range proposals {
+		require.False(proposal.Approved)
+	}
+	approved, err := HarvestContractUpgrades(contractpb.WrapPluginContext(pctx))
+	require.NoError(err)
+	require.Len(approved, 0)
+	require.Len(listProposals(), 2)
+
+	require.Equal(ErrNotAuthorized, approve(addr4, coinAddr))
+	require.Equal(ErrUpgradeNotFound, approve(addr1, chainconfigAddr))
+	require.NoError(approve(addr1, coinAddr))
+	require.Equal(ErrUpgradeAlreadyApproved, approve(addr1, coinAddr))
+	approved, err = HarvestContractUpgrades(contractpb.WrapPluginContext(pctx))
+	require.NoError(err)
+	require.Len(approved, 0)
+
+	// 2 out of 3 validators reaches the threshold
+	require.NoError(approve(addr2, coinAddr))
+	require.Equal(ErrUpgradeAlreadyApproved, approve(addr3, coinAddr))
+
+	approved, err = HarvestContractUpgrades(contractpb.WrapPluginContext(pctx))
+	require.NoError(err)
+	require.Len(approved, 1)
+	require.Equal(0, loom.UnmarshalAddressPB(approved[0].ContractAddress).Compare(coinAddr))
+	require.Equal("coin:2.0.0", approved[0].PluginName)
+	require.Equal(int64(20), approved[0].BlockHeight)
+
+	// the unapproved proposal is dropped once its block height is reached
+	proposals = listProposals()
+	require.Len(proposals, 1)
+	require.Equal(0, loom.UnmarshalAddressPB(proposals[0].ContractAddress).Compare(otherAddr))
+	pctx = pctx.WithBlock(loom.BlockHeader{ChainID: chainID, Height: 20, Time: time.Now().Unix()})
+	approved, err = HarvestContractUpgrades(contractpb.WrapPluginContext(pctx))
+	require.NoError(err)
+	require.Len(approved, 0)
+	require.Len(listProposals(), 0)
+}
@@ -0,0 +1,61 @@
+package chainconfig
+
+import (
+	"math/big"
+
+	loom "github.com/loomnetwork/go-loom"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/types"
+
+	"github.com/loomnetwork/loomchain/features"
+)
+
+var (
+	minGasPriceKey = []byte("mingasprice")
+)
+
+// SetMinGasPrice sets the minimum gas price of Ethereum txs, txs with a lower gas price will be
+// rejected, and eth_gasPrice will never report a lower price.
+func (c *ChainConfig) SetMinGasPrice(ctx contract.Context, req *SetMinGasPriceRequest) error {
+	if !ctx.FeatureEnabled(features.ChainCfgVersion1_5, false) {
+		return ErrFeatureNotEnabled
+	}
+
+	if req.MinGasPrice == nil || req.MinGasPrice.Value.Int == nil {
+		return ErrInvalidRequest
+	}
+
+	if ok, _ := ctx.HasPermission(setParamsPerm, []string{ownerRole}); !ok && !isGovernance(ctx) {
+		return ErrNotAuthorized
+	}
+
+	return ctx.Set(minGasPriceKey, req.MinGasPrice)
+}
+
+// GetMinGasPrice returns the minimum gas price of Ethereum txs.
+func (c *ChainConfig) GetMinGasPrice(
+	ctx contract.StaticContext, req *GetMinGasPriceRequest,
+) (*GetMinGasPriceResponse, error) {
+	minGasPrice, err := GetMinGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GetMinGasPriceResponse{
+		MinGasPrice: &types.BigUInt{Value: *loom.NewBigUInt(minGasPrice)},
+	}, nil
+}
+
+// GetMinGasPrice returns the minimum gas price of Ethereum txs, or zero if it hasn't been set.
+func GetMinGasPrice(ctx contract.StaticContext) (*big.Int, error) {
+	var minGasPrice types.BigUInt
+	if err := ctx.Get(minGasPriceKey, &minGasPrice); err != nil {
+		if err == contract.ErrNotFound {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	if minGasPrice.Value.Int == nil {
+		return big.NewInt(0), nil
+	}
+	return new(big.Int).Set(minGasPrice.Value.Int), nil
+}
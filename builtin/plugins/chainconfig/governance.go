@@ -0,0 +1,50 @@
+package chainconfig
+
+import (
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/loomchain/features"
+)
+
+// isGovernance checks if the caller is the Governance contract, which is allowed to change the
+// params of this contract when it executes a param change proposal that passed.
+func isGovernance(ctx contract.StaticContext) bool {
+	if !ctx.FeatureEnabled(features.GovernanceFeature, false) {
+		return false
+	}
+	governanceAddr, err := ctx.Resolve("governance")
+	return err == nil && ctx.Message().Sender.Compare(governanceAddr) == 0
+}
+
+// ActivateFeature is called when a governance proposal to activate a feature passes, it skips the
+// validator vote and puts the feature into the WAITING state, so the feature will be enabled by
+// EnableFeatures once enough blocks have been confirmed, just like a feature the validators voted
+// for. Only features that have been added to this contract can be activated.
+func ActivateFeature(ctx contract.Context, name string, blockHeight uint64) error {
+	if name == "" {
+		return ErrInvalidRequest
+	}
+	var feature Feature
+	if err := ctx.Get(featureKey(name), &feature); err != nil {
+		if err == contract.ErrNotFound {
+			return ErrFeatureNotFound
+		}
+		return err
+	}
+	if feature.Status != FeaturePending {
+		return ErrFeatureAlreadyEnabled
+	}
+	feature.Status = FeatureWaiting
+	feature.BlockHeight = blockHeight
+	if err := ctx.Set(featureKey(name), &feature); err != nil {
+		return err
+	}
+	ctx.Logger().Info(
+		"[Feature status changed]",
+		"name", feature.Name,
+		"from", FeaturePending,
+		"to", FeatureWaiting,
+		"block_height", blockHeight,
+		"governance", true,
+	)
+	return nil
+}
@@ -0,0 +1,188 @@
+package chainconfig
+
+import (
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/features"
+)
+
+const (
+	upgradeProposalPrefix = "cup"
+)
+
+var (
+	// ErrUpgradeAlreadyProposed is returned when an upgrade is proposed for a contract that already
+	// has an upgrade proposal pending.
+	ErrUpgradeAlreadyProposed = errors.New("[ChainConfig] contract upgrade already proposed")
+	// ErrUpgradeNotFound is returned if there's no upgrade proposal pending for a contract.
+	ErrUpgradeNotFound = errors.New("[ChainConfig] contract upgrade proposal not found")
+	// ErrUpgradeAlreadyApproved is returned if a validator tries to approve an upgrade that has
+	// already been approved.
+	ErrUpgradeAlreadyApproved = errors.New("[ChainConfig] contract upgrade already approved")
+)
+
+func upgradeProposalKey(contractAddr loom.Address) []byte {
+	return util.PrefixKey([]byte(upgradeProposalPrefix), contractAddr.Bytes())
+}
+
+// ProposeContractUpgrade proposes switching an existing Go contract to a different version of its
+// code at some future block height. Upgrades can be proposed by the owner of the contract being
+// upgraded, or by the owner of the ChainConfig contract, and must be approved by the validators
+// via ApproveContractUpgrade before they're scheduled. The owner of a contract can't schedule an
+// upgrade on their own, since every validator has to run the new code, and an upgrade to code the
+// validators don't have would leave the contract unusable.
+func (c *ChainConfig) ProposeContractUpgrade(ctx contract.Context, req *ProposeContractUpgradeRequest) error {
+	if !ctx.FeatureEnabled(features.ContractUpgradeFeature, false) {
+		return ErrFeatureNotEnabled
+	}
+
+	if req.ContractAddress == nil || req.PluginName == "" {
+		return ErrInvalidRequest
+	}
+
+	if req.BlockHeight <= ctx.Block().Height {
+		return errors.Wrap(ErrInvalidRequest, "upgrade block height must be in the future")
+	}
+
+	contractAddr := loom.UnmarshalAddressPB(req.ContractAddress)
+	rec, err := ctx.ContractRecord(contractAddr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load record of contract %s", contractAddr.String())
+	}
+
+	sender := ctx.Message().Sender
+	if rec.CreatorAddress.Compare(sender) != 0 {
+		if ok, _ := ctx.HasPermission(addFeaturePerm, []string{ownerRole}); !ok {
+			return ErrNotAuthorized
+		}
+	}
+
+	if ctx.Has(upgradeProposalKey(contractAddr)) {
+		return ErrUpgradeAlreadyProposed
+	}
+
+	return ctx.Set(upgradeProposalKey(contractAddr), &ContractUpgradeProposal{
+		ContractAddress: req.ContractAddress,
+		PluginName:      req.PluginName,
+		BlockHeight:     req.BlockHeight,
+		Proposer:        sender.MarshalPB(),
+	})
+}
+
+// ApproveContractUpgrade should be called by a validator to approve a pending upgrade proposal.
+// The proposal is approved once the percentage of validators that have approved it reaches the
+// vote threshold.
+func (c *ChainConfig) ApproveContractUpgrade(ctx contract.Context, req *ApproveContractUpgradeRequest) error {
+	if !ctx.FeatureEnabled(features.ContractUpgradeFeature, false) {
+		return ErrFeatureNotEnabled
+	}
+
+	if req.ContractAddress == nil {
+		return ErrInvalidRequest
+	}
+
+	curValidators, err := getCurrentValidators(ctx)
+	if err != nil {
+		return err
+	}
+	sender := ctx.Message().Sender
+	if !isValidator(sender, curValidators) {
+		return ErrNotAuthorized
+	}
+
+	contractAddr := loom.UnmarshalAddressPB(req.ContractAddress)
+	var proposal ContractUpgradeProposal
+	if err := ctx.Get(upgradeProposalKey(contractAddr), &proposal); err != nil {
+		if err == contract.ErrNotFound {
+			return ErrUpgradeNotFound
+		}
+		return err
+	}
+
+	if proposal.Approved {
+		return ErrUpgradeAlreadyApproved
+	}
+
+	for _, v := range proposal.Votes {
+		if sender.Compare(loom.UnmarshalAddressPB(v)) == 0 {
+			return ErrUpgradeAlreadyApproved
+		}
+	}
+	proposal.Votes = append(proposal.Votes, sender.MarshalPB())
+
+	params, err := getParams(ctx)
+	if err != nil {
+		return err
+	}
+	numVotes := 0
+	for _, v := range proposal.Votes {
+		if isValidator(loom.UnmarshalAddressPB(v), curValidators) {
+			numVotes++
+		}
+	}
+	if uint64((numVotes*100)/len(curValidators)) >= params.VoteThreshold {
+		proposal.Approved = true
+	}
+
+	return ctx.Set(upgradeProposalKey(contractAddr), &proposal)
+}
+
+// ListContractUpgradeProposals returns all the upgrade proposals that haven't been scheduled yet.
+func (c *ChainConfig) ListContractUpgradeProposals(
+	ctx contract.StaticContext, req *ListContractUpgradeProposalsRequest,
+) (*ListContractUpgradeProposalsResponse, error) {
+	proposals, err := listUpgradeProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ListContractUpgradeProposalsResponse{
+		Proposals: proposals,
+	}, nil
+}
+
+// HarvestContractUpgrades returns a list of upgrade proposals that have been approved, so they can
+// be scheduled in the contract registry. The returned proposals are removed from the contract, as
+// are any unapproved proposals that can no longer be scheduled because their block height has been
+// reached.
+func HarvestContractUpgrades(ctx contract.Context) ([]*ContractUpgradeProposal, error) {
+	proposals, err := listUpgradeProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := make([]*ContractUpgradeProposal, 0)
+	for _, proposal := range proposals {
+		if proposal.Approved {
+			approved = append(approved, proposal)
+		} else if proposal.BlockHeight > ctx.Block().Height {
+			continue
+		}
+		ctx.Delete(upgradeProposalKey(loom.UnmarshalAddressPB(proposal.ContractAddress)))
+	}
+	return approved, nil
+}
+
+func listUpgradeProposals(ctx contract.StaticContext) ([]*ContractUpgradeProposal, error) {
+	proposals := make([]*ContractUpgradeProposal, 0)
+	for _, m := range ctx.Range([]byte(upgradeProposalPrefix)) {
+		var proposal ContractUpgradeProposal
+		if err := proto.Unmarshal(m.Value, &proposal); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal ContractUpgradeProposal %x", m.Key)
+		}
+		proposals = append(proposals, &proposal)
+	}
+	return proposals, nil
+}
+
+func isValidator(addr loom.Address, validators []loom.Address) bool {
+	for _, v := range validators {
+		if addr.Compare(v) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/loomnetwork/go-loom/plugin"
 	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
 	"github.com/loomnetwork/go-loom/util"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/pkg/errors"
 )
 
@@ -233,6 +234,30 @@ func (dw *DeployerWhitelist) ListDeployers(ctx contract.StaticContext, req *List
 	}, nil
 }
 
+// ListDeployersPage returns a page of the whitelisted deployers along with the cursor of the next page.
+func (dw *DeployerWhitelist) ListDeployersPage(
+	ctx contract.StaticContext, req *pagination.PageRequest,
+) (*ListDeployersPageResponse, error) {
+	deployerRange, nextCursor, err := pagination.Range(ctx, []byte(deployerPrefix), req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deployers := make([]*Deployer, 0, len(deployerRange))
+	for _, m := range deployerRange {
+		var deployer Deployer
+		if err := proto.Unmarshal(m.Value, &deployer); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal deployer %x", m.Key)
+		}
+		deployers = append(deployers, &deployer)
+	}
+
+	return &ListDeployersPageResponse{
+		Deployers:  deployers,
+		NextCursor: nextCursor,
+	}, nil
+}
+
 // GetDeployer is called by DeployerWhitelist middleware to retrieve deployer's permission
 func GetDeployer(ctx contract.StaticContext, deployerAddr loom.Address) (*Deployer, error) {
 	var deployer Deployer
@@ -11,6 +11,8 @@ import (
 	"github.com/loomnetwork/go-loom/plugin"
 	"github.com/loomnetwork/go-loom/plugin/contractpb"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 )
 
 var (
@@ -223,3 +225,56 @@ func (dw *DeployerWhitelistTestSuite) TestDeployerWhitelistFlagUtil() {
 		require.Equal(true, IsFlagSet(packedFlag, f))
 	}
 }
+
+func (dw *DeployerWhitelistTestSuite) TestListDeployersPage() {
+	require := dw.Require()
+
+	pctx := plugin.CreateFakeContext(addr1, addr1).WithBlock(loom.BlockHeader{
+		ChainID: chainId,
+		Time:    time.Now().Unix(),
+	})
+	ctx := contractpb.WrapPluginContext(pctx)
+
+	deployerContract := &DeployerWhitelist{}
+	err := deployerContract.Init(ctx, &InitRequest{
+		Owner: addr1.MarshalPB(),
+		Deployers: []*Deployer{
+			&Deployer{Address: addr2.MarshalPB(), Flags: uint32(AllowEVMDeployFlag)},
+			&Deployer{Address: addr3.MarshalPB(), Flags: uint32(AllowEVMDeployFlag)},
+			&Deployer{Address: addr4.MarshalPB(), Flags: uint32(AllowEVMDeployFlag)},
+			&Deployer{Address: addr5.MarshalPB(), Flags: uint32(AllowEVMDeployFlag)},
+		},
+	})
+	require.NoError(err)
+
+	all, err := deployerContract.ListDeployers(ctx, &ListDeployersRequest{})
+	require.NoError(err)
+	require.Equal(5, len(all.Deployers))
+
+	seen := map[string]bool{}
+	cursor := ""
+	pages := 0
+	for {
+		page, err := deployerContract.ListDeployersPage(ctx, &pagination.PageRequest{
+			Cursor: cursor,
+			Limit:  2,
+		})
+		require.NoError(err)
+		require.True(len(page.Deployers) <= 2)
+		for _, deployer := range page.Deployers {
+			addr := loom.UnmarshalAddressPB(deployer.Address).String()
+			require.False(seen[addr])
+			seen[addr] = true
+		}
+		pages++
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	require.Equal(3, pages)
+	require.Equal(5, len(seen))
+
+	_, err = deployerContract.ListDeployersPage(ctx, &pagination.PageRequest{Cursor: "xyz"})
+	require.Error(err)
+}
@@ -0,0 +1,86 @@
+package dposv3
+
+import (
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/common"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	types "github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/loomnetwork/loomchain/features"
+	"github.com/pkg/errors"
+)
+
+// SetAutoCompound changes whether the rewards earned by the caller's delegations are added to those
+// delegations at the end of each election cycle, instead of being accumulated in the rewards
+// delegation. If a validator address is specified the setting only applies to the caller's
+// delegations to that validator, otherwise it applies to all the caller's delegations that don't
+// have a validator specific setting.
+func (c *DPOS) SetAutoCompound(ctx contract.Context, req *SetAutoCompoundRequest) error {
+	if !ctx.FeatureEnabled(features.DPOSVersion3_12, false) {
+		return errors.New("DPOS v3.12 is not enabled")
+	}
+
+	delegator := ctx.Message().Sender
+	ctx.Logger().Info("DPOSv3 SetAutoCompound", "delegator", delegator, "request", req)
+
+	var validator loom.Address
+	if req.ValidatorAddress != nil {
+		validator = loom.UnmarshalAddressPB(req.ValidatorAddress)
+		if validator.Compare(LimboValidatorAddress(ctx)) == 0 {
+			err := errors.New("Rewards can't be compounded into limbo validator delegations.")
+			return logDposError(ctx, err, req.String())
+		}
+	}
+	return setAutoCompound(ctx, delegator, validator, req.Enabled)
+}
+
+func (c *DPOS) GetAutoCompoundSettings(
+	ctx contract.StaticContext, req *GetAutoCompoundSettingsRequest,
+) (*GetAutoCompoundSettingsResponse, error) {
+	if req.DelegatorAddress == nil {
+		return nil, logStaticDposError(ctx, errors.New("Delegator address not specified."), req.String())
+	}
+	delegator := loom.UnmarshalAddressPB(req.DelegatorAddress)
+
+	settings := []*AutoCompoundSetting{}
+	var setting AutoCompoundSetting
+	if err := ctx.Get(autoCompoundKey(delegator, loom.Address{}), &setting); err == nil {
+		settings = append(settings, &setting)
+	} else if err != contract.ErrNotFound {
+		return nil, err
+	}
+
+	for _, entry := range ctx.Range(util.PrefixKey(autoCompoundPrefix, delegator.Bytes())) {
+		var validatorSetting AutoCompoundSetting
+		if err := proto.Unmarshal(entry.Value, &validatorSetting); err != nil {
+			return nil, errors.Wrap(err, "unmarshal auto-compound setting")
+		}
+		settings = append(settings, &validatorSetting)
+	}
+	return &GetAutoCompoundSettingsResponse{Settings: settings}, nil
+}
+
+// canAutoCompound checks if rewards can be added to the given delegation, rewards can't be added to
+// delegations that are being unbonded or redelegated since the amount the delegator will end up
+// with is determined by the UpdateAmount of those delegations. Rewards earned by the rewards
+// delegation are always added to it, so it doesn't need to be compounded.
+func canAutoCompound(ctx contract.StaticContext, delegation *Delegation) bool {
+	if delegation.Index == REWARD_DELEGATION_INDEX {
+		return false
+	}
+	if delegation.State != BONDED && delegation.State != BONDING {
+		return false
+	}
+	return isAutoCompoundEnabled(
+		ctx, loom.UnmarshalAddressPB(delegation.Delegator), loom.UnmarshalAddressPB(delegation.Validator),
+	)
+}
+
+// compoundRewards adds the rewards to the delegation that earned them, the rewards are subject to
+// the same locktime as the rest of the delegation.
+func compoundRewards(delegation *Delegation, rewards loom.BigUInt) {
+	updatedAmount := common.BigZero()
+	updatedAmount.Add(&delegation.Amount.Value, &rewards)
+	delegation.Amount = &types.BigUInt{Value: *updatedAmount}
+}
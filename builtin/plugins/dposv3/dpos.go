@@ -337,7 +337,7 @@ func (c *DPOS) Delegate(ctx contract.Context, req *DelegateRequest) error {
 		return err
 	}
 
-	return c.emitDelegatorDelegatesEvent(ctx, delegation)
+	return emitDelegatorDelegatesEvent(ctx, delegation)
 }
 
 func (c *DPOS) Redelegate(ctx contract.Context, req *RedelegateRequest) error {
@@ -1332,6 +1332,23 @@ func Elect(ctx contract.Context) error {
 	}
 	ctx.Logger().Debug("DPOSv3 Elect", "delegationResults", len(delegationResults))
 
+	configurableElection := ctx.FeatureEnabled(features.DPOSVersion3_11, false)
+	var electionParams *ElectionParams
+	if configurableElection {
+		electionParams, err = loadElectionParams(ctx)
+		if err != nil {
+			return err
+		}
+		if common.IsPositive(electionParams.MinSelfStake.Value) {
+			delegationResults, err = filterBySelfStake(
+				ctx, cachedDelegations, delegationResults, electionParams.MinSelfStake.Value,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	validatorCount := int(state.Params.ValidatorCount)
 	if len(delegationResults) < validatorCount {
 		validatorCount = len(delegationResults)
@@ -1340,6 +1357,7 @@ func Elect(ctx contract.Context) error {
 	stripPowerFromJailedValidators := ctx.FeatureEnabled(features.DPOSVersion3_8, false)
 
 	validators := make([]*Validator, 0)
+	operators := make([]string, 0)
 	totalValidatorDelegations := common.BigZero()
 	for _, res := range delegationResults[:validatorCount] {
 		candidate := GetCandidate(ctx, res.ValidatorAddress)
@@ -1389,6 +1407,9 @@ func Elect(ctx contract.Context) error {
 				PubKey: candidate.PubKey,
 				Power:  validatorPower,
 			})
+			if configurableElection {
+				operators = append(operators, getCandidateOperator(ctx, res.ValidatorAddress))
+			}
 
 			if err = SetStatistic(ctx, statistic); err != nil {
 				return err
@@ -1397,9 +1418,18 @@ func Elect(ctx contract.Context) error {
 	}
 
 	// calling `applyPowerCap` ensure that no validator has >28% of the voting
-	// power
+	// power, unless the oracle has changed the cap
 	if common.IsPositive(*totalValidatorDelegations) {
-		state.Validators = applyPowerCap(validators)
+		if configurableElection {
+			powerCap := electionParams.PowerCap
+			if powerCap == 0 {
+				powerCap = defaultPowerCap
+			}
+			validators = applyEntityPowerCap(validators, operators, electionParams.EntityPowerCap)
+			state.Validators = applyPowerCapWithLimit(validators, powerCap)
+		} else {
+			state.Validators = applyPowerCap(validators)
+		}
 		state.LastElectionTime = ctx.Now().Unix()
 		state.TotalValidatorDelegations = &types.BigUInt{Value: *totalValidatorDelegations}
 		if err = saveState(ctx, state); err != nil {
@@ -1420,9 +1450,16 @@ func Elect(ctx contract.Context) error {
 // 2) power total is approx. unchanged as a result of cap
 // 3) ordering of validators by power does not change as a result of cap
 func applyPowerCap(validators []*Validator) []*Validator {
-	// It is impossible to apply a powercap when the number of validators is
-	// less than 4
-	if len(validators) < 4 {
+	return applyPowerCapWithLimit(validators, defaultPowerCap)
+}
+
+// `applyPowerCapWithLimit` is the same as `applyPowerCap` but caps the power
+// of each validator at `powerCap` basis points of the power total
+func applyPowerCapWithLimit(validators []*Validator, powerCap uint64) []*Validator {
+	// It is impossible to apply a powercap when the validators can't hold all
+	// the power without exceeding the cap, e.g. with the default 28% cap the
+	// number of validators must be at least 4
+	if uint64(len(validators))*powerCap < hundredPercentInBasisPoints {
 		return validators
 	}
 
@@ -1435,7 +1472,7 @@ func applyPowerCap(validators []*Validator) []*Validator {
 		}
 	}
 
-	limit := float64(0.28)
+	limit := float64(powerCap) / hundredPercentInBasisPoints
 	maximumIndividualPower := int64(limit * float64(powerSum))
 
 	if max > maximumIndividualPower {
@@ -1670,7 +1707,7 @@ func ShiftDowntimeWindow(ctx contract.Context, currentHeight int64, candidates [
 				}
 
 				if shouldSlash {
-					if err := slash(ctx, statistic, inactivitySlashPercentage); err != nil {
+					if err := slash(ctx, statistic, inactivitySlashPercentage, inactivityFault, currentHeight); err != nil {
 						return err
 					}
 				}
@@ -1779,10 +1816,13 @@ func SlashDoubleSign(ctx contract.Context, statistic *ValidatorStatistic) error
 		return err
 	}
 
-	return slash(ctx, statistic, state.Params.ByzantineSlashingPercentage.Value)
+	return slash(ctx, statistic, state.Params.ByzantineSlashingPercentage.Value, doubleSignFault, ctx.Block().Height)
 }
 
-func slash(ctx contract.Context, statistic *ValidatorStatistic, slashPercentage loom.BigUInt) error {
+func slash(
+	ctx contract.Context, statistic *ValidatorStatistic, slashPercentage loom.BigUInt,
+	faultType string, evidenceHeight int64,
+) error {
 	updatedAmount := common.BigZero()
 	updatedAmount.Add(&statistic.SlashPercentage.Value, &slashPercentage)
 	// this check ensures that the slash percentage never exceeds 100%
@@ -1791,6 +1831,17 @@ func slash(ctx contract.Context, statistic *ValidatorStatistic, slashPercentage
 	}
 	statistic.SlashPercentage = &types.BigUInt{Value: *updatedAmount}
 
+	if ctx.FeatureEnabled(features.DPOSVersion3_13, false) {
+		err := addPendingSlashFault(ctx, loom.UnmarshalAddressPB(statistic.Address), &SlashFault{
+			FaultType:       faultType,
+			SlashPercentage: &types.BigUInt{Value: slashPercentage},
+			EvidenceHeight:  evidenceHeight,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return emitSlashEvent(ctx, statistic.Address, slashPercentage)
 }
 
@@ -2011,6 +2062,11 @@ func slashValidatorDelegations(
 		return err
 	}
 
+	record := &SlashRecord{
+		Validator:       validatorAddress.MarshalPB(),
+		SlashPercentage: statistic.SlashPercentage,
+	}
+
 	for _, d := range delegations {
 		delegation, err := GetDelegation(ctx, d.Index, *d.Validator, *d.Delegator)
 		if err == contract.ErrNotFound {
@@ -2021,6 +2077,12 @@ func slashValidatorDelegations(
 
 		if loom.UnmarshalAddressPB(delegation.Validator).Compare(validatorAddress) == 0 {
 			toSlash := CalculateFraction(statistic.SlashPercentage.Value, delegation.Amount.Value)
+			record.Delegations = append(record.Delegations, &SlashedDelegation{
+				Delegator:   delegation.Delegator,
+				Index:       delegation.Index,
+				Amount:      delegation.Amount,
+				SlashAmount: &types.BigUInt{Value: toSlash},
+			})
 			updatedAmount := common.BigZero()
 			updatedAmount.Sub(&delegation.Amount.Value, &toSlash)
 			delegation.Amount = &types.BigUInt{Value: *updatedAmount}
@@ -2045,6 +2107,8 @@ func slashValidatorDelegations(
 		updatedAmount := common.BigZero()
 		updatedAmount.Sub(&statistic.WhitelistAmount.Value, &toSlash)
 		statistic.WhitelistAmount = &types.BigUInt{Value: *updatedAmount}
+		record.WhitelistAmount = beforeSlashedWhitelistAmount
+		record.WhitelistSlashAmount = &types.BigUInt{Value: toSlash}
 		if err := emitSlashWhitelistAmountEvent(
 			ctx, validatorAddress.MarshalPB(), beforeSlashedWhitelistAmount,
 			&types.BigUInt{Value: toSlash}, statistic.SlashPercentage,
@@ -2053,6 +2117,12 @@ func slashValidatorDelegations(
 		}
 	}
 
+	if ctx.FeatureEnabled(features.DPOSVersion3_13, false) {
+		if err := saveSlashRecord(ctx, record); err != nil {
+			return err
+		}
+	}
+
 	// reset slash total
 	statistic.SlashPercentage = loom.BigZeroPB()
 
@@ -2098,6 +2168,7 @@ func distributeDelegatorRewards(ctx contract.Context, cachedDelegations *CachedD
 		}
 
 		validatorKey := loom.UnmarshalAddressPB(delegation.Validator).String()
+		compounded := false
 
 		// Do not distribute rewards to delegators of the Limbo validator
 		// NOTE: because all delegations are sorted in reverse index order, the
@@ -2115,7 +2186,15 @@ func distributeDelegatorRewards(ctx contract.Context, cachedDelegations *CachedD
 				delegatorDistribution := calculateShare(weightedDelegation, delegationTotal, *rewardsTotal)
 				// increase a delegator's distribution
 				distributedRewards.Add(distributedRewards, &delegatorDistribution)
-				cachedDelegations.IncreaseRewardDelegation(ctx, delegation.Validator, delegation.Delegator, delegatorDistribution)
+				if ctx.FeatureEnabled(features.DPOSVersion3_12, false) && canAutoCompound(ctx, delegation) {
+					// the delegation will be saved further down
+					if common.IsPositive(delegatorDistribution) {
+						compoundRewards(delegation, delegatorDistribution)
+						compounded = true
+					}
+				} else {
+					cachedDelegations.IncreaseRewardDelegation(ctx, delegation.Validator, delegation.Delegator, delegatorDistribution)
+				}
 
 				// If the reward delegation is updated by the
 				// IncreaseRewardDelegation command, we must be sure to use this
@@ -2182,6 +2261,11 @@ func distributeDelegatorRewards(ctx contract.Context, cachedDelegations *CachedD
 			if err := cachedDelegations.SetDelegation(ctx, delegation); err != nil {
 				return nil, err
 			}
+			if compounded {
+				if err := emitDelegatorDelegatesEvent(ctx, delegation); err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		// Calculate delegation totals for all validators except the Limbo
@@ -2396,7 +2480,7 @@ func (c *DPOS) SetElectionCycle(ctx contract.Context, req *SetElectionCycleReque
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2417,7 +2501,7 @@ func (c *DPOS) SetDowntimePeriod(ctx contract.Context, req *SetDowntimePeriodReq
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2436,7 +2520,7 @@ func (c *DPOS) SetMaxYearlyReward(ctx contract.Context, req *SetMaxYearlyRewardR
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2455,7 +2539,7 @@ func (c *DPOS) SetRegistrationRequirement(ctx contract.Context, req *SetRegistra
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2474,7 +2558,7 @@ func (c *DPOS) SetValidatorCount(ctx contract.Context, req *SetValidatorCountReq
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2493,7 +2577,7 @@ func (c *DPOS) SetOracleAddress(ctx contract.Context, req *SetOracleAddressReque
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2518,7 +2602,7 @@ func (c *DPOS) SetSlashingPercentages(ctx contract.Context, req *SetSlashingPerc
 	}
 
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2549,7 +2633,7 @@ func (c *DPOS) SetMaxDowntimePercentage(ctx contract.Context, req *SetMaxDowntim
 		return err
 	}
 
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2573,7 +2657,7 @@ func (c *DPOS) SetMinCandidateFee(ctx contract.Context, req *SetMinCandidateFeeR
 
 	//TODO: this will be replaced with voting system next week
 	// ensure that function is only executed when called by oracle
-	if state.Params.OracleAddress == nil || sender.Compare(loom.UnmarshalAddressPB(state.Params.OracleAddress)) != 0 {
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
 		return logDposError(ctx, errOnlyOracle, req.String())
 	}
 
@@ -2590,6 +2674,19 @@ func (c *DPOS) SetMinCandidateFee(ctx contract.Context, req *SetMinCandidateFeeR
 // STATE-CHANGE LOGGING EVENTS
 // ***************************************
 
+// isOracleOrGovernance checks if the sender is the oracle, or the Governance contract, which calls
+// the param setters when it executes a param change proposal that passed.
+func isOracleOrGovernance(ctx contract.StaticContext, params *Params, sender loom.Address) bool {
+	if params.OracleAddress != nil && sender.Compare(loom.UnmarshalAddressPB(params.OracleAddress)) == 0 {
+		return true
+	}
+	if !ctx.FeatureEnabled(features.GovernanceFeature, false) {
+		return false
+	}
+	governanceAddr, err := ctx.Resolve("governance")
+	return err == nil && sender.Compare(governanceAddr) == 0
+}
+
 func emitElectionEvent(ctx contract.Context) error {
 	marshalled, err := proto.Marshal(&DposElectionEvent{
 		BlockNumber: uint64(ctx.Block().Height),
@@ -2726,7 +2823,7 @@ func (c *DPOS) emitUpdateCandidateInfoEvent(ctx contract.Context, candidate *typ
 	return nil
 }
 
-func (c *DPOS) emitDelegatorDelegatesEvent(ctx contract.Context, delegation *Delegation) error {
+func emitDelegatorDelegatesEvent(ctx contract.Context, delegation *Delegation) error {
 	marshalled, err := proto.Marshal(&DposDelegatorDelegatesEvent{
 		Delegation: delegation,
 	})
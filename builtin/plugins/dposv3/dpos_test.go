@@ -15,6 +15,7 @@ import (
 	"github.com/loomnetwork/go-loom/plugin/contractpb"
 	types "github.com/loomnetwork/go-loom/types"
 	"github.com/loomnetwork/loomchain/builtin/plugins/coin"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/loomnetwork/loomchain/features"
 )
 
@@ -3314,6 +3315,223 @@ func TestApplyPowerCap(t *testing.T) {
 	}
 }
 
+func TestApplyPowerCapWithLimit(t *testing.T) {
+	// a 50% cap can be applied with only 2 validators
+	output := applyPowerCapWithLimit([]*Validator{&Validator{Power: 90}, &Validator{Power: 10}}, 5000)
+	assert.Equal(t, int64(50), output[0].Power)
+	assert.Equal(t, int64(50), output[1].Power)
+
+	// but not with 3 validators if the cap is 30%
+	output = applyPowerCapWithLimit([]*Validator{&Validator{Power: 80}, &Validator{Power: 10}, &Validator{Power: 10}}, 3000)
+	assert.Equal(t, int64(80), output[0].Power)
+
+	// the default cap should produce the same results as applyPowerCap
+	output = applyPowerCapWithLimit([]*Validator{&Validator{Power: 33}, &Validator{Power: 30}, &Validator{Power: 22}, &Validator{Power: 22}}, defaultPowerCap)
+	assert.Equal(t, []int64{29, 29, 24, 24}, []int64{output[0].Power, output[1].Power, output[2].Power, output[3].Power})
+}
+
+func TestApplyEntityPowerCap(t *testing.T) {
+	var tests = []struct {
+		input     []int64
+		operators []string
+		cap       uint64
+		output    []int64
+	}{
+		// cap disabled
+		{[]int64{40, 30, 10, 10, 10}, []string{"a", "a", "", "", ""}, 0, []int64{40, 30, 10, 10, 10}},
+		// operator "a" is capped at 30% of the reduced total
+		{[]int64{40, 30, 10, 10, 10}, []string{"a", "a", "", "", ""}, 3000, []int64{6, 5, 10, 10, 10}},
+		// operator "a" is already under the cap
+		{[]int64{20, 10, 30, 20, 20}, []string{"a", "a", "", "", ""}, 3000, []int64{20, 10, 30, 20, 20}},
+		// validators without an operator are capped individually
+		{[]int64{60, 20, 20, 20}, []string{"", "", "", ""}, 3000, []int64{25, 20, 20, 20}},
+		// too few entities to apply the cap
+		{[]int64{40, 30, 30}, []string{"a", "a", "b"}, 3000, []int64{40, 30, 30}},
+	}
+	for _, test := range tests {
+		validators := make([]*Validator, len(test.input))
+		for i, power := range test.input {
+			validators[i] = &Validator{Power: power}
+		}
+		output := applyEntityPowerCap(validators, test.operators, test.cap)
+		for i, o := range output {
+			assert.Equal(t, test.output[i], o.Power)
+		}
+	}
+}
+
+func TestElectionParams(t *testing.T) {
+	pctx := createCtx()
+	oraclePubKey, _ := hex.DecodeString(validatorPubKeyHex2)
+	oracleAddr := loom.Address{
+		Local: loom.LocalAddressFromPublicKey(oraclePubKey),
+	}
+
+	dpos, err := deployDPOSContract(pctx, &Params{
+		ValidatorCount: 21,
+		OracleAddress:  oracleAddr.MarshalPB(),
+	})
+	require.NoError(t, err)
+	dposCtx := pctx.WithAddress(dpos.Address)
+	oracleCtx := contractpb.WrapPluginContext(dposCtx.WithSender(oracleAddr))
+
+	// fails because the feature isn't enabled yet
+	err = dpos.Contract.SetPowerCap(oracleCtx, &SetPowerCapRequest{PowerCap: 2000})
+	require.Error(t, err)
+
+	pctx.SetFeature(features.DPOSVersion3_11, true)
+
+	resp, err := dpos.Contract.GetElectionParams(oracleCtx, &GetElectionParamsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultPowerCap, resp.Params.PowerCap)
+	assert.True(t, common.IsZero(resp.Params.MinSelfStake.Value))
+	assert.Equal(t, uint64(0), resp.Params.EntityPowerCap)
+
+	// fails because not oracle
+	err = dpos.Contract.SetPowerCap(
+		contractpb.WrapPluginContext(dposCtx.WithSender(addr1)), &SetPowerCapRequest{PowerCap: 2000},
+	)
+	require.Equal(t, errOnlyOracle, err)
+
+	err = dpos.Contract.SetPowerCap(oracleCtx, &SetPowerCapRequest{PowerCap: 10001})
+	require.Equal(t, errPowerCapOutOfRange, err)
+
+	require.NoError(t, dpos.Contract.SetPowerCap(oracleCtx, &SetPowerCapRequest{PowerCap: 2000}))
+	require.NoError(t, dpos.Contract.SetMinSelfStake(oracleCtx, &SetMinSelfStakeRequest{
+		MinSelfStake: &types.BigUInt{Value: *loom.NewBigUIntFromInt(1000)},
+	}))
+	require.NoError(t, dpos.Contract.SetEntityPowerCap(oracleCtx, &SetEntityPowerCapRequest{EntityPowerCap: 3300}))
+
+	resp, err = dpos.Contract.GetElectionParams(oracleCtx, &GetElectionParamsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2000), resp.Params.PowerCap)
+	assert.Equal(t, int64(1000), resp.Params.MinSelfStake.Value.Int64())
+	assert.Equal(t, uint64(3300), resp.Params.EntityPowerCap)
+
+	// only candidates can set an operator
+	err = dpos.Contract.UpdateCandidateOperator(
+		contractpb.WrapPluginContext(dposCtx.WithSender(addr1)), &UpdateCandidateOperatorRequest{Operator: "loom"},
+	)
+	require.Equal(t, errCandidateNotFound, err)
+}
+
+func TestAutoCompound(t *testing.T) {
+	pctx := createCtx()
+	coinAddr := pctx.CreateContract(coin.Contract)
+
+	coinContract := &coin.Coin{}
+	coinCtx := pctx.WithAddress(coinAddr)
+	coinContract.Init(contractpb.WrapPluginContext(coinCtx), &coin.InitRequest{
+		Accounts: []*coin.InitialAccount{
+			makeAccount(delegatorAddress1, 100000000),
+			makeAccount(delegatorAddress2, 100000000),
+			makeAccount(addr1, 100000000),
+		},
+	})
+
+	cycleLengthSeconds := int64(100)
+	dpos, err := deployDPOSContract(pctx, &Params{
+		ValidatorCount:      10,
+		ElectionCycleLength: cycleLengthSeconds,
+		CoinContractAddress: coinAddr.MarshalPB(),
+	})
+	require.Nil(t, err)
+	dposCtx := pctx.WithAddress(dpos.Address)
+
+	// transfer coins to reward fund
+	amount := big.NewInt(10)
+	amount.Exp(amount, big.NewInt(19), nil)
+	coinContract.Transfer(contractpb.WrapPluginContext(coinCtx), &coin.TransferRequest{
+		To: dpos.Address.MarshalPB(),
+		Amount: &types.BigUInt{
+			Value: common.BigUInt{amount},
+		},
+	})
+
+	registrationFee := &types.BigUInt{Value: *scientificNotation(defaultRegistrationRequirement, tokenDecimals)}
+	err = coinContract.Approve(contractpb.WrapPluginContext(coinCtx.WithSender(addr1)), &coin.ApproveRequest{
+		Spender: dpos.Address.MarshalPB(),
+		Amount:  registrationFee,
+	})
+	require.Nil(t, err)
+	require.NoError(t, dpos.RegisterCandidate(pctx.WithSender(addr1), pubKey1, nil, nil, nil, nil, nil, nil))
+	require.NoError(t, elect(pctx, dpos.Address))
+
+	// fails because the feature isn't enabled yet
+	delegator1Ctx := contractpb.WrapPluginContext(dposCtx.WithSender(delegatorAddress1))
+	err = dpos.Contract.SetAutoCompound(delegator1Ctx, &SetAutoCompoundRequest{Enabled: true})
+	require.Error(t, err)
+
+	pctx.SetFeature(features.DPOSVersion3_12, true)
+	require.NoError(t, dpos.Contract.SetAutoCompound(delegator1Ctx, &SetAutoCompoundRequest{Enabled: true}))
+
+	settings, err := dpos.Contract.GetAutoCompoundSettings(delegator1Ctx, &GetAutoCompoundSettingsRequest{
+		DelegatorAddress: delegatorAddress1.MarshalPB(),
+	})
+	require.NoError(t, err)
+	require.Len(t, settings.Settings, 1)
+	assert.True(t, settings.Settings[0].Enabled)
+	assert.Nil(t, settings.Settings[0].Validator)
+
+	// Both delegators lock up their delegations for a year, but only delegator 1 compounds its rewards
+	delegationAmount := loom.BigUInt{big.NewInt(1e18)}
+	tier := uint64(TIER_THREE)
+	for _, delegator := range []loom.Address{delegatorAddress1, delegatorAddress2} {
+		err = coinContract.Approve(contractpb.WrapPluginContext(coinCtx.WithSender(delegator)), &coin.ApproveRequest{
+			Spender: dpos.Address.MarshalPB(),
+			Amount:  &types.BigUInt{Value: delegationAmount},
+		})
+		require.Nil(t, err)
+		require.NoError(t, dpos.Delegate(pctx.WithSender(delegator), &addr1, delegationAmount.Int, &tier, nil))
+	}
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, elect(pctx, dpos.Address))
+		pctx.SetTime(pctx.Now().Add(time.Duration(cycleLengthSeconds) * time.Second))
+	}
+
+	// Delegator 1's rewards should've been added to the delegation that earned them, so they're
+	// weighted at the tier of that delegation, while delegator 2's rewards should've accumulated in
+	// the rewards delegation.
+	compoundedRewards, err := dpos.CheckDelegatorRewards(pctx, &delegatorAddress1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, compoundedRewards.Cmp(common.BigZero().Int))
+	delegations, _, _, err := dpos.CheckDelegation(pctx, &addr1, &delegatorAddress1)
+	require.NoError(t, err)
+	require.Len(t, delegations, 1)
+	assert.Equal(t, TIER_THREE, delegations[0].LocktimeTier)
+	compounded := common.BigZero()
+	compounded.Sub(&delegations[0].Amount.Value, &delegationAmount)
+	assert.True(t, compounded.Cmp(common.BigZero()) > 0)
+
+	rewards, err := dpos.CheckDelegatorRewards(pctx, &delegatorAddress2)
+	require.NoError(t, err)
+	assert.True(t, rewards.Cmp(common.BigZero().Int) > 0)
+	delegations, _, _, err = dpos.CheckDelegation(pctx, &addr1, &delegatorAddress2)
+	require.NoError(t, err)
+	require.Len(t, delegations, 2)
+	for _, d := range delegations {
+		if d.Index == REWARD_DELEGATION_INDEX {
+			assert.Equal(t, TIER_ZERO, d.LocktimeTier)
+		} else {
+			assert.Equal(t, TIER_THREE, d.LocktimeTier)
+			assert.Equal(t, 0, d.Amount.Value.Cmp(&delegationAmount))
+		}
+	}
+
+	// The compounded rewards earn rewards at the tier of the delegation they were added to, so
+	// delegator 1 should end up with more than delegator 2.
+	assert.True(t, compounded.Int.Cmp(rewards) > 0)
+
+	// a validator specific setting takes precedence over the delegator's default setting
+	require.NoError(t, dpos.Contract.SetAutoCompound(delegator1Ctx, &SetAutoCompoundRequest{
+		ValidatorAddress: addr1.MarshalPB(),
+		Enabled:          false,
+	}))
+	assert.False(t, isAutoCompoundEnabled(delegator1Ctx, delegatorAddress1, addr1))
+	assert.True(t, isAutoCompoundEnabled(delegator1Ctx, delegatorAddress1, addr2))
+}
+
 func TestDowntimeFunctions(t *testing.T) {
 	pctx := createCtx()
 
@@ -3532,6 +3750,115 @@ func TestDowntimeSlashing(t *testing.T) {
 	assert.True(t, delegatedAmount.Cmp(expectedSlashedDelegation.Int) == 0)
 }
 
+func TestSlashLedger(t *testing.T) {
+	pctx := createCtx()
+
+	// Deploy the coin contract (DPOS Init() will attempt to resolve it)
+	coinContract := &coin.Coin{}
+	coinAddr := pctx.CreateContract(coin.Contract)
+	coinCtx := pctx.WithAddress(coinAddr)
+	coinContract.Init(contractpb.WrapPluginContext(coinCtx), &coin.InitRequest{
+		Accounts: []*coin.InitialAccount{
+			makeAccount(addr1, 1000000000000000000),
+			makeAccount(delegatorAddress1, 100000000),
+		},
+	})
+
+	registrationFee := &types.BigUInt{Value: *loom.NewBigUIntFromInt(100)}
+	dpos, err := deployDPOSContract(pctx, &Params{
+		ValidatorCount:          1,
+		RegistrationRequirement: registrationFee,
+	})
+	require.Nil(t, err)
+	dposCtx := pctx.WithAddress(dpos.Address)
+	dposCtx.SetFeature(features.DPOSVersion3_13, true)
+
+	err = coinContract.Approve(contractpb.WrapPluginContext(coinCtx.WithSender(addr1)), &coin.ApproveRequest{
+		Spender: dpos.Address.MarshalPB(),
+		Amount:  registrationFee,
+	})
+	require.Nil(t, err)
+	require.NoError(t, dpos.RegisterCandidate(pctx.WithSender(addr1), pubKey1, nil, nil, nil, nil, nil, nil))
+
+	delegationAmount := big.NewInt(100)
+	err = coinContract.Approve(contractpb.WrapPluginContext(coinCtx.WithSender(delegatorAddress1)), &coin.ApproveRequest{
+		Spender: dpos.Address.MarshalPB(),
+		Amount:  &types.BigUInt{Value: *loom.NewBigUInt(delegationAmount)},
+	})
+	require.Nil(t, err)
+	require.NoError(t, dpos.Delegate(pctx.WithSender(delegatorAddress1), &addr1, delegationAmount, nil, nil))
+	require.NoError(t, elect(pctx, dpos.Address))
+
+	for i := 0; i < 2; i++ {
+		ctx := contractpb.WrapPluginContext(dposCtx)
+		statistic, err := GetStatistic(ctx, addr1)
+		require.NoError(t, err)
+		require.NoError(t, slash(ctx, statistic, defaultInactivitySlashPercentage, inactivityFault, 10))
+		doubleSignCtx := contractpb.WrapPluginContext(dposCtx.WithBlock(loom.BlockHeader{
+			ChainID: chainID,
+			Height:  20,
+			Time:    startTime,
+		}))
+		require.NoError(t, SlashDoubleSign(doubleSignCtx, statistic))
+		require.NoError(t, SetStatistic(ctx, statistic))
+		require.NoError(t, elect(pctx, dpos.Address))
+	}
+
+	staticCtx := contractpb.WrapPluginContext(dposCtx)
+	resp, err := dpos.Contract.ListSlashes(staticCtx, &ListSlashesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Slashes, 2)
+	assert.Equal(t, uint64(0), resp.NextCursor)
+	record := resp.Slashes[0]
+	assert.Equal(t, uint64(1), record.Id)
+	assert.Equal(t, 0, loom.UnmarshalAddressPB(record.Validator).Compare(addr1))
+	require.Len(t, record.Faults, 2)
+	assert.Equal(t, inactivityFault, record.Faults[0].FaultType)
+	assert.Equal(t, int64(10), record.Faults[0].EvidenceHeight)
+	assert.Equal(t, doubleSignFault, record.Faults[1].FaultType)
+	assert.Equal(t, int64(20), record.Faults[1].EvidenceHeight)
+	// self-delegation & delegator's delegation
+	require.Len(t, record.Delegations, 2)
+
+	// pagination
+	resp, err = dpos.Contract.ListSlashes(staticCtx, &ListSlashesRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Slashes, 1)
+	assert.Equal(t, uint64(1), resp.NextCursor)
+	resp, err = dpos.Contract.ListSlashes(staticCtx, &ListSlashesRequest{Limit: 1, StartAfter: resp.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, resp.Slashes, 1)
+	assert.Equal(t, uint64(2), resp.Slashes[0].Id)
+	assert.Equal(t, uint64(0), resp.NextCursor)
+
+	resp, err = dpos.Contract.ListSlashes(staticCtx, &ListSlashesRequest{ValidatorAddress: addr2.MarshalPB()})
+	require.NoError(t, err)
+	require.Len(t, resp.Slashes, 0)
+
+	resp, err = dpos.Contract.ListSlashes(staticCtx, &ListSlashesRequest{
+		ValidatorAddress: addr1.MarshalPB(),
+		Limit:            1,
+		StartAfter:       1,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Slashes, 1)
+	assert.Equal(t, uint64(2), resp.Slashes[0].Id)
+	assert.Equal(t, uint64(0), resp.NextCursor)
+
+	delegatorResp, err := dpos.Contract.GetSlashesForDelegator(staticCtx, &GetSlashesForDelegatorRequest{
+		DelegatorAddress: delegatorAddress1.MarshalPB(),
+	})
+	require.NoError(t, err)
+	require.Len(t, delegatorResp.Slashes, 2)
+	for _, record := range delegatorResp.Slashes {
+		require.Len(t, record.Delegations, 1)
+		d := record.Delegations[0]
+		assert.Equal(t, 0, loom.UnmarshalAddressPB(d.Delegator).Compare(delegatorAddress1))
+		expectedSlashAmount := CalculateFraction(record.SlashPercentage.Value, d.Amount.Value)
+		assert.Equal(t, 0, d.SlashAmount.Value.Cmp(&expectedSlashAmount))
+	}
+}
+
 func TestDowntimeSlashingWithZeroSlashingPercentage(t *testing.T) {
 	pctx := createCtx()
 
@@ -4076,3 +4403,109 @@ func createCtx() *plugin.FakeContext {
 		Time:    startTime,
 	})
 }
+
+func TestListPages(t *testing.T) {
+	pctx := createCtx()
+
+	coinContract := &coin.Coin{}
+	coinAddr := pctx.CreateContract(coin.Contract)
+	coinCtx := pctx.WithAddress(coinAddr)
+	coinContract.Init(contractpb.WrapPluginContext(coinCtx), &coin.InitRequest{
+		Accounts: []*coin.InitialAccount{
+			makeAccount(delegatorAddress1, 1000000000000000000),
+			makeAccount(delegatorAddress2, 1000000000000000000),
+		},
+	})
+
+	dpos, err := deployDPOSContract(pctx, &Params{
+		ValidatorCount:          21,
+		RegistrationRequirement: &types.BigUInt{Value: *loom.NewBigUIntFromInt(0)},
+	})
+	require.Nil(t, err)
+
+	candidates := []struct {
+		addr   loom.Address
+		pubKey []byte
+	}{
+		{addr1, pubKey1}, {addr2, pubKey2}, {addr3, pubKey3}, {addr4, pubKey4},
+	}
+	delegationAmount := &types.BigUInt{Value: loom.BigUInt{big.NewInt(2000)}}
+	for _, c := range candidates {
+		err = dpos.RegisterCandidate(pctx.WithSender(c.addr), c.pubKey, nil, nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+
+		for _, delegator := range []loom.Address{delegatorAddress1, delegatorAddress2} {
+			err = coinContract.Approve(contractpb.WrapPluginContext(coinCtx.WithSender(delegator)), &coin.ApproveRequest{
+				Spender: dpos.Address.MarshalPB(),
+				Amount:  delegationAmount,
+			})
+			require.Nil(t, err)
+			err = dpos.Delegate(pctx.WithSender(delegator), &c.addr, delegationAmount.Value.Int, nil, nil)
+			require.Nil(t, err)
+		}
+	}
+	require.NoError(t, elect(pctx, dpos.Address))
+
+	staticCtx := contractpb.WrapPluginContext(pctx.WithAddress(dpos.Address))
+
+	allCandidates, err := dpos.ListCandidates(pctx)
+	require.Nil(t, err)
+	var pagedCandidates []*CandidateStatistic
+	cursor := ""
+	for {
+		resp, err := dpos.Contract.ListCandidatesPage(staticCtx, &pagination.PageRequest{Cursor: cursor, Limit: 3})
+		require.Nil(t, err)
+		require.True(t, len(resp.Candidates) <= 3)
+		pagedCandidates = append(pagedCandidates, resp.Candidates...)
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	require.Len(t, pagedCandidates, len(allCandidates))
+	seenCandidates := map[string]bool{}
+	for i, c := range pagedCandidates {
+		addr := loom.UnmarshalAddressPB(c.Candidate.Address)
+		require.False(t, seenCandidates[addr.String()])
+		seenCandidates[addr.String()] = true
+		if i > 0 {
+			prev := loom.UnmarshalAddressPB(pagedCandidates[i-1].Candidate.Address)
+			require.True(t, prev.Compare(addr) < 0)
+		}
+	}
+
+	allDelegations, err := dpos.ListAllDelegations(pctx)
+	require.Nil(t, err)
+	numDelegations := 0
+	for _, resp := range allDelegations {
+		numDelegations += len(resp.Delegations)
+	}
+	var pagedDelegations []*Delegation
+	cursor = ""
+	for {
+		resp, err := dpos.Contract.ListAllDelegationsPage(staticCtx, &pagination.PageRequest{Cursor: cursor, Limit: 3})
+		require.Nil(t, err)
+		require.True(t, len(resp.Delegations) <= 3)
+		pagedDelegations = append(pagedDelegations, resp.Delegations...)
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	// 4 candidates, 2 delegators each, plus a rewards delegation for each delegator
+	require.Equal(t, 16, numDelegations)
+	require.Len(t, pagedDelegations, numDelegations)
+	seenDelegations := map[string]bool{}
+	for _, d := range pagedDelegations {
+		key := string(delegationPageKey(&DelegationIndex{
+			Validator: d.Validator,
+			Delegator: d.Delegator,
+			Index:     d.Index,
+		}))
+		require.False(t, seenDelegations[key])
+		seenDelegations[key] = true
+	}
+
+	_, err = dpos.Contract.ListCandidatesPage(staticCtx, &pagination.PageRequest{Cursor: "not-a-cursor", Limit: 3})
+	require.Error(t, err)
+}
@@ -0,0 +1,321 @@
+package dposv3
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/common"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/loomchain/features"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Maximum voting power any single validator may have, in basis points, unless the oracle
+	// changes it via SetPowerCap.
+	defaultPowerCap = uint64(2800) // 28%
+	// Maximum length of the operator name candidates can set via UpdateCandidateOperator.
+	maxOperatorNameLength = 64
+)
+
+var errPowerCapOutOfRange = errors.New("Power cap must be from 0 - 10000 basis points.")
+
+// SetPowerCap changes the maximum voting power any single validator can have, the cap is
+// specified in basis points, zero resets it to the default 28%.
+// This method can only be called by the DPOS oracle (admin).
+func (c *DPOS) SetPowerCap(ctx contract.Context, req *SetPowerCapRequest) error {
+	if !ctx.FeatureEnabled(features.DPOSVersion3_11, false) {
+		return errors.New("DPOS v3.11 is not enabled")
+	}
+
+	sender := ctx.Message().Sender
+	ctx.Logger().Info("DPOSv3 SetPowerCap", "sender", sender, "request", req)
+
+	if err := checkOracle(ctx); err != nil {
+		return logDposError(ctx, err, req.String())
+	}
+
+	if req.PowerCap > hundredPercentInBasisPoints {
+		return logDposError(ctx, errPowerCapOutOfRange, req.String())
+	}
+
+	params, err := loadElectionParams(ctx)
+	if err != nil {
+		return err
+	}
+	params.PowerCap = req.PowerCap
+	return saveElectionParams(ctx, params)
+}
+
+// SetMinSelfStake changes the minimum amount a candidate must have staked on itself to be elected
+// as a validator, zero disables the requirement.
+// This method can only be called by the DPOS oracle (admin).
+func (c *DPOS) SetMinSelfStake(ctx contract.Context, req *SetMinSelfStakeRequest) error {
+	if !ctx.FeatureEnabled(features.DPOSVersion3_11, false) {
+		return errors.New("DPOS v3.11 is not enabled")
+	}
+
+	sender := ctx.Message().Sender
+	ctx.Logger().Info("DPOSv3 SetMinSelfStake", "sender", sender, "request", req)
+
+	if err := checkOracle(ctx); err != nil {
+		return logDposError(ctx, err, req.String())
+	}
+
+	if req.MinSelfStake == nil || req.MinSelfStake.Value.Int == nil {
+		return logDposError(ctx, errors.New("Min self-stake not specified."), req.String())
+	}
+
+	params, err := loadElectionParams(ctx)
+	if err != nil {
+		return err
+	}
+	params.MinSelfStake = req.MinSelfStake
+	return saveElectionParams(ctx, params)
+}
+
+// SetEntityPowerCap changes the maximum voting power all the validators run by a single operator
+// can have, the cap is specified in basis points, zero disables the cap.
+// This method can only be called by the DPOS oracle (admin).
+func (c *DPOS) SetEntityPowerCap(ctx contract.Context, req *SetEntityPowerCapRequest) error {
+	if !ctx.FeatureEnabled(features.DPOSVersion3_11, false) {
+		return errors.New("DPOS v3.11 is not enabled")
+	}
+
+	sender := ctx.Message().Sender
+	ctx.Logger().Info("DPOSv3 SetEntityPowerCap", "sender", sender, "request", req)
+
+	if err := checkOracle(ctx); err != nil {
+		return logDposError(ctx, err, req.String())
+	}
+
+	if req.EntityPowerCap > hundredPercentInBasisPoints {
+		return logDposError(ctx, errPowerCapOutOfRange, req.String())
+	}
+
+	params, err := loadElectionParams(ctx)
+	if err != nil {
+		return err
+	}
+	params.EntityPowerCap = req.EntityPowerCap
+	return saveElectionParams(ctx, params)
+}
+
+func (c *DPOS) GetElectionParams(
+	ctx contract.StaticContext, req *GetElectionParamsRequest,
+) (*GetElectionParamsResponse, error) {
+	params, err := loadElectionParams(ctx)
+	if err != nil {
+		return nil, logStaticDposError(ctx, err, req.String())
+	}
+	if params.PowerCap == 0 {
+		params.PowerCap = defaultPowerCap
+	}
+	return &GetElectionParamsResponse{Params: params}, nil
+}
+
+// UpdateCandidateOperator links the calling candidate to the entity that operates it, validators
+// linked to the same operator are subject to the entity power cap as a group. An empty operator
+// name unlinks the candidate.
+func (c *DPOS) UpdateCandidateOperator(ctx contract.Context, req *UpdateCandidateOperatorRequest) error {
+	if !ctx.FeatureEnabled(features.DPOSVersion3_11, false) {
+		return errors.New("DPOS v3.11 is not enabled")
+	}
+
+	ctx.Logger().Info("DPOSv3 UpdateCandidateOperator", "request", req)
+
+	candidateAddress := ctx.Message().Sender
+	if GetCandidate(ctx, candidateAddress) == nil {
+		return errCandidateNotFound
+	}
+
+	operator := strings.TrimSpace(req.Operator)
+	if len(operator) > maxOperatorNameLength {
+		return logDposError(ctx, errors.New("Operator name is too long."), req.String())
+	}
+
+	if err := setCandidateOperator(ctx, candidateAddress, operator); err != nil {
+		return err
+	}
+
+	return c.emitUpdateCandidateInfoEvent(ctx, candidateAddress.MarshalPB())
+}
+
+func (c *DPOS) ListCandidateOperators(
+	ctx contract.StaticContext, req *ListCandidateOperatorsRequest,
+) (*ListCandidateOperatorsResponse, error) {
+	operatorRange := ctx.Range(candidateOperatorPrefix)
+	operators := make([]*CandidateOperator, 0, len(operatorRange))
+	for _, entry := range operatorRange {
+		var operator CandidateOperator
+		if err := proto.Unmarshal(entry.Value, &operator); err != nil {
+			return nil, errors.Wrap(err, "unmarshal candidate operator")
+		}
+		operators = append(operators, &operator)
+	}
+	return &ListCandidateOperatorsResponse{
+		Operators: operators,
+	}, nil
+}
+
+func checkOracle(ctx contract.Context) error {
+	state, err := LoadState(ctx)
+	if err != nil {
+		return err
+	}
+	sender := ctx.Message().Sender
+	if !isOracleOrGovernance(ctx, state.Params, sender) {
+		return errOnlyOracle
+	}
+	return nil
+}
+
+// filterBySelfStake returns the delegation results of the candidates that have at least the given
+// amount staked on themselves, a candidate's self-stake is the total of its delegations to itself
+// plus its whitelist amount.
+func filterBySelfStake(
+	ctx contract.StaticContext, cachedDelegations *CachedDposStorage, results []*DelegationResult,
+	minSelfStake loom.BigUInt,
+) ([]*DelegationResult, error) {
+	delegations, err := cachedDelegations.loadDelegationList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selfStakes := make(map[string]*loom.BigUInt)
+	for _, d := range delegations {
+		validator := loom.UnmarshalAddressPB(d.Validator)
+		if validator.Compare(loom.UnmarshalAddressPB(d.Delegator)) != 0 {
+			continue
+		}
+		delegation, err := GetDelegation(ctx, d.Index, *d.Validator, *d.Delegator)
+		if err == contract.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if delegation.Amount == nil {
+			continue
+		}
+		selfStake, ok := selfStakes[validator.String()]
+		if !ok {
+			selfStake = common.BigZero()
+			selfStakes[validator.String()] = selfStake
+		}
+		selfStake.Add(selfStake, &delegation.Amount.Value)
+	}
+
+	filtered := make([]*DelegationResult, 0, len(results))
+	for _, res := range results {
+		selfStake := common.BigZero()
+		if amount, ok := selfStakes[res.ValidatorAddress.String()]; ok {
+			selfStake.Add(selfStake, amount)
+		}
+		statistic, _ := GetStatistic(ctx, res.ValidatorAddress)
+		if statistic != nil && statistic.WhitelistAmount != nil {
+			selfStake.Add(selfStake, &statistic.WhitelistAmount.Value)
+		}
+		if selfStake.Cmp(&minSelfStake) >= 0 {
+			filtered = append(filtered, res)
+		} else {
+			ctx.Logger().Info(
+				"DPOSv3 Elect excluded candidate with insufficient self-stake",
+				"candidate", res.ValidatorAddress.String(),
+				"selfStake", selfStake.String(),
+			)
+		}
+	}
+	return filtered, nil
+}
+
+// `applyEntityPowerCap` ensures that the validators linked to any one operator don't have more than
+// `entityPowerCap` basis points of the total power. The power of the validators run by an operator
+// that exceeds the cap is scaled down proportionally, the power taken away isn't redistributed so
+// the power total is reduced. Validators that aren't linked to an operator are treated as separate
+// entities. `operators` must contain the operator of each validator, in the same order.
+func applyEntityPowerCap(validators []*Validator, operators []string, entityPowerCap uint64) []*Validator {
+	if entityPowerCap == 0 || entityPowerCap >= hundredPercentInBasisPoints {
+		return validators
+	}
+
+	type entity struct {
+		power      int64
+		validators []*Validator
+	}
+	entities := make([]*entity, 0, len(validators))
+	operatorEntities := make(map[string]*entity)
+	for i, v := range validators {
+		var e *entity
+		if len(operators[i]) > 0 {
+			e = operatorEntities[operators[i]]
+		}
+		if e == nil {
+			e = &entity{}
+			entities = append(entities, e)
+			if len(operators[i]) > 0 {
+				operatorEntities[operators[i]] = e
+			}
+		}
+		e.power += v.Power
+		e.validators = append(e.validators, v)
+	}
+
+	// It is impossible to apply the cap when there are too few entities to hold all the power
+	if uint64(len(entities))*entityPowerCap < hundredPercentInBasisPoints {
+		return validators
+	}
+
+	// Find the entities that must be capped, and the power each of them should be left with so that
+	// each one ends up with exactly `entityPowerCap` of the reduced power total, i.e.
+	// maxPower / (uncappedPower + numCapped * maxPower) = entityPowerCap / 10000
+	capped := make(map[*entity]bool)
+	maxPower := int64(0)
+	for {
+		uncappedPower := int64(0)
+		for _, e := range entities {
+			if !capped[e] {
+				uncappedPower += e.power
+			}
+		}
+		remainder := int64(hundredPercentInBasisPoints) - int64(len(capped))*int64(entityPowerCap)
+		if remainder <= 0 {
+			return validators
+		}
+		maxPower = mulDiv(uncappedPower, int64(entityPowerCap), remainder)
+
+		cappedMore := false
+		for _, e := range entities {
+			if !capped[e] && e.power > maxPower {
+				capped[e] = true
+				cappedMore = true
+			}
+		}
+		if !cappedMore {
+			break
+		}
+	}
+
+	for _, e := range entities {
+		if !capped[e] {
+			continue
+		}
+		for _, v := range e.validators {
+			v.Power = mulDiv(v.Power, maxPower, e.power)
+			// Tendermint errors out if a validator has zero power for two consecutive elections
+			if v.Power < 1 {
+				v.Power = 1
+			}
+		}
+	}
+	return validators
+}
+
+// mulDiv returns a * b / c without overflowing the intermediate product.
+func mulDiv(a, b, c int64) int64 {
+	var result big.Int
+	result.Mul(big.NewInt(a), big.NewInt(b))
+	result.Div(&result, big.NewInt(c))
+	return result.Int64()
+}
@@ -0,0 +1,112 @@
+package dposv3
+
+import (
+	"encoding/binary"
+
+	loom "github.com/loomnetwork/go-loom"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
+)
+
+// The candidate & delegation lists are each stored as a single value, so every page has to load
+// the whole list, but only the items that make up the page are sorted & loaded from the contract
+// storage.
+
+// ListCandidatesPage returns a page of the registered candidates, ordered by address, along with
+// the cursor of the next page.
+func (c *DPOS) ListCandidatesPage(
+	ctx contract.StaticContext, req *pagination.PageRequest,
+) (*ListCandidatesPageResponse, error) {
+	candidates, err := LoadCandidateList(ctx)
+	if err != nil {
+		return nil, logStaticDposError(ctx, err, req.String())
+	}
+
+	keys := make([][]byte, len(candidates))
+	for i, candidate := range candidates {
+		keys[i] = loom.UnmarshalAddressPB(candidate.Address).Bytes()
+	}
+	indices, nextCursor, err := pagination.Select(keys, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, logStaticDposError(ctx, err, req.String())
+	}
+
+	candidateStatistics := make([]*CandidateStatistic, 0, len(indices))
+	for _, i := range indices {
+		candidate := candidates[i]
+		statistic, err := GetStatistic(ctx, loom.UnmarshalAddressPB(candidate.Address))
+		if err != nil && err != contract.ErrNotFound {
+			return nil, err
+		}
+		candidateStatistics = append(candidateStatistics, &CandidateStatistic{
+			Candidate: candidate,
+			Statistic: statistic,
+		})
+	}
+	return &ListCandidatesPageResponse{
+		Candidates: candidateStatistics,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// ListAllDelegationsPage returns a page of the delegations to all the registered candidates, ordered
+// by candidate, delegator & index, along with the cursor of the next page.
+func (c *DPOS) ListAllDelegationsPage(
+	ctx contract.StaticContext, req *pagination.PageRequest,
+) (*ListAllDelegationsPageResponse, error) {
+	ctx.Logger().Debug("DPOSv3 ListAllDelegationsPage", "request", req)
+
+	candidates, err := LoadCandidateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	delegations, err := loadDelegationList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidateSet := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		candidateSet[loom.UnmarshalAddressPB(candidate.Address).String()] = true
+	}
+
+	indices := make([]*DelegationIndex, 0, len(delegations))
+	keys := make([][]byte, 0, len(delegations))
+	for _, d := range delegations {
+		if !candidateSet[loom.UnmarshalAddressPB(d.Validator).String()] {
+			continue
+		}
+		indices = append(indices, d)
+		keys = append(keys, delegationPageKey(d))
+	}
+	selected, nextCursor, err := pagination.Select(keys, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, logStaticDposError(ctx, err, req.String())
+	}
+
+	result := make([]*Delegation, 0, len(selected))
+	for _, i := range selected {
+		d := indices[i]
+		delegation, err := GetDelegation(ctx, d.Index, *d.Validator, *d.Delegator)
+		if err == contract.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		result = append(result, delegation)
+	}
+	return &ListAllDelegationsPageResponse{
+		Delegations: result,
+		NextCursor:  nextCursor,
+	}, nil
+}
+
+// delegationPageKey returns the key that's used to order delegations in pages, and to build cursors.
+func delegationPageKey(d *DelegationIndex) []byte {
+	key := make([]byte, 0, len(d.Validator.Local)+len(d.Delegator.Local)+8)
+	key = append(key, d.Validator.Local...)
+	key = append(key, d.Delegator.Local...)
+	index := make([]byte, 8)
+	binary.BigEndian.PutUint64(index, d.Index)
+	return append(key, index...)
+}
@@ -0,0 +1,189 @@
+package dposv3
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
+	"github.com/pkg/errors"
+)
+
+const (
+	inactivityFault = "inactivity"
+	doubleSignFault = "double-sign"
+)
+
+var (
+	slashLedgerStateKey  = []byte("slashledger")
+	slashRecordPrefix    = []byte("slashrecord")
+	pendingSlashPrefix   = []byte("pendingslash")
+	delegatorSlashPrefix = []byte("delegatorslash")
+)
+
+func slashRecordKey(id uint64) []byte {
+	return util.PrefixKey(slashRecordPrefix, slashIDBytes(id))
+}
+
+func pendingSlashKey(validator loom.Address) []byte {
+	return util.PrefixKey(pendingSlashPrefix, validator.Bytes())
+}
+
+func delegatorSlashKey(delegator loom.Address, id uint64) []byte {
+	return util.PrefixKey(delegatorSlashPrefix, delegator.Bytes(), slashIDBytes(id))
+}
+
+func slashIDBytes(id uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, id)
+	return buf
+}
+
+// ListSlashes returns the slash ledger entries in the order in which they were recorded, optionally
+// filtered by validator.
+func (c *DPOS) ListSlashes(ctx contract.StaticContext, req *ListSlashesRequest) (*ListSlashesResponse, error) {
+	var filter func(*SlashRecord) bool
+	if req.ValidatorAddress != nil {
+		validator := loom.UnmarshalAddressPB(req.ValidatorAddress)
+		filter = func(record *SlashRecord) bool {
+			return loom.UnmarshalAddressPB(record.Validator).Compare(validator) == 0
+		}
+	}
+	slashes, nextCursor, err := listSlashRecords(ctx, slashRecordPrefix, req.StartAfter, req.Limit, filter)
+	if err != nil {
+		return nil, logStaticDposError(ctx, err, req.String())
+	}
+	return &ListSlashesResponse{
+		Slashes:    slashes,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetSlashesForDelegator returns the slash ledger entries that affected the given delegator, each
+// entry only lists the delegator's own delegations.
+func (c *DPOS) GetSlashesForDelegator(
+	ctx contract.StaticContext, req *GetSlashesForDelegatorRequest,
+) (*GetSlashesForDelegatorResponse, error) {
+	if req.DelegatorAddress == nil {
+		return nil, logStaticDposError(ctx, errors.New("Delegator address not specified."), req.String())
+	}
+	delegator := loom.UnmarshalAddressPB(req.DelegatorAddress)
+	prefix := util.PrefixKey(delegatorSlashPrefix, delegator.Bytes())
+	slashes, nextCursor, err := listSlashRecords(ctx, prefix, req.StartAfter, req.Limit, nil)
+	if err != nil {
+		return nil, logStaticDposError(ctx, err, req.String())
+	}
+	return &GetSlashesForDelegatorResponse{
+		Slashes:    slashes,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// listSlashRecords returns up to limit records stored under the given prefix that have an ID
+// greater than startAfter, and the cursor that should be used to get the next page. Records are
+// read a page at a time starting from the cursor, so only the part of the ledger that's needed to
+// fill the page is loaded. When a filter is specified the last page may turn out to be empty.
+func listSlashRecords(
+	ctx contract.StaticContext, prefix []byte, startAfter, limit uint64, filter func(*SlashRecord) bool,
+) ([]*SlashRecord, uint64, error) {
+	limit = pagination.Limit(limit)
+	cursor := ""
+	if startAfter > 0 {
+		cursor = hex.EncodeToString(slashIDBytes(startAfter))
+	}
+
+	records := []*SlashRecord{}
+	for {
+		entries, nextCursor, err := pagination.Range(ctx, prefix, cursor, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, entry := range entries {
+			var record SlashRecord
+			if err := proto.Unmarshal(entry.Value, &record); err != nil {
+				return nil, 0, errors.Wrap(err, "unmarshal slash record")
+			}
+			if filter != nil && !filter(&record) {
+				continue
+			}
+			records = append(records, &record)
+			if uint64(len(records)) == limit {
+				if nextCursor == "" && i == len(entries)-1 {
+					return records, 0, nil
+				}
+				return records, record.Id, nil
+			}
+		}
+		if nextCursor == "" {
+			return records, 0, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// addPendingSlashFault records a fault committed by a validator, the fault will be added to the
+// slash ledger when the validator's delegations are slashed.
+func addPendingSlashFault(ctx contract.Context, validator loom.Address, fault *SlashFault) error {
+	var pending PendingSlash
+	if err := ctx.Get(pendingSlashKey(validator), &pending); err != nil && err != contract.ErrNotFound {
+		return err
+	}
+	pending.Validator = validator.MarshalPB()
+	pending.Faults = append(pending.Faults, fault)
+	return ctx.Set(pendingSlashKey(validator), &pending)
+}
+
+// saveSlashRecord adds the record to the slash ledger along with the faults the validator has been
+// slashed for, and indexes the record by the delegators it affected.
+func saveSlashRecord(ctx contract.Context, record *SlashRecord) error {
+	validator := loom.UnmarshalAddressPB(record.Validator)
+	var pending PendingSlash
+	if err := ctx.Get(pendingSlashKey(validator), &pending); err != nil && err != contract.ErrNotFound {
+		return err
+	}
+	ctx.Delete(pendingSlashKey(validator))
+
+	var state SlashLedgerState
+	if err := ctx.Get(slashLedgerStateKey, &state); err != nil && err != contract.ErrNotFound {
+		return err
+	}
+	state.LastSlashId++
+
+	record.Id = state.LastSlashId
+	record.BlockHeight = ctx.Block().Height
+	record.Faults = pending.Faults
+	if err := ctx.Set(slashRecordKey(record.Id), record); err != nil {
+		return err
+	}
+	if err := ctx.Set(slashLedgerStateKey, &state); err != nil {
+		return err
+	}
+
+	delegatorRecords := make(map[string]*SlashRecord)
+	delegators := []loom.Address{}
+	for _, d := range record.Delegations {
+		delegator := loom.UnmarshalAddressPB(d.Delegator)
+		delegatorRecord, ok := delegatorRecords[delegator.String()]
+		if !ok {
+			delegatorRecord = &SlashRecord{
+				Id:              record.Id,
+				BlockHeight:     record.BlockHeight,
+				Validator:       record.Validator,
+				Faults:          record.Faults,
+				SlashPercentage: record.SlashPercentage,
+			}
+			delegatorRecords[delegator.String()] = delegatorRecord
+			delegators = append(delegators, delegator)
+		}
+		delegatorRecord.Delegations = append(delegatorRecord.Delegations, d)
+	}
+	for _, delegator := range delegators {
+		if err := ctx.Set(delegatorSlashKey(delegator, record.Id), delegatorRecords[delegator.String()]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
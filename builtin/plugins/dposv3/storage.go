@@ -30,12 +30,29 @@ var (
 	requestBatchTallyKey   = []byte("request_batch_tally")
 	deprecatedReferrersKey = []byte("referrers")
 	referrerPrefix         = []byte("rf")
+
+	electionParamsKey       = []byte("election_params")
+	candidateOperatorPrefix = []byte("operator")
+	autoCompoundPrefix      = []byte("autocompound")
 )
 
 func referrerKey(referrerName string) []byte {
 	return util.PrefixKey([]byte(referrerPrefix), []byte(referrerName))
 }
 
+func candidateOperatorKey(candidate loom.Address) []byte {
+	return util.PrefixKey(candidateOperatorPrefix, candidate.Bytes())
+}
+
+// autoCompoundKey returns the key of the delegator's auto-compound setting for the given validator,
+// or of the delegator's default setting if the validator address is empty.
+func autoCompoundKey(delegator, validator loom.Address) []byte {
+	if validator.IsEmpty() {
+		return util.PrefixKey(autoCompoundPrefix, delegator.Bytes())
+	}
+	return util.PrefixKey(autoCompoundPrefix, delegator.Bytes(), validator.Bytes())
+}
+
 func sortValidators(validators []*Validator) []*Validator {
 	sort.Sort(byPubkey(validators))
 	return validators
@@ -408,6 +425,9 @@ func updateCandidateList(ctx contract.Context) error {
 	// Remove unregistering candidates from candidates array
 	for _, candidateAddress := range deleteList {
 		candidates.Delete(candidateAddress)
+		if ctx.FeatureEnabled(features.DPOSVersion3_11, false) {
+			ctx.Delete(candidateOperatorKey(candidateAddress))
+		}
 	}
 
 	// Only save CandidateList when it gets updated
@@ -526,6 +546,76 @@ func LoadState(ctx contract.StaticContext) (*State, error) {
 	return &state, nil
 }
 
+// loadElectionParams returns the election params set by the oracle, or the defaults if the oracle
+// hasn't set any yet.
+func loadElectionParams(ctx contract.StaticContext) (*ElectionParams, error) {
+	var params ElectionParams
+	err := ctx.Get(electionParamsKey, &params)
+	if err != nil && err != contract.ErrNotFound {
+		return nil, err
+	}
+	if params.MinSelfStake == nil {
+		params.MinSelfStake = loom.BigZeroPB()
+	}
+	return &params, nil
+}
+
+func saveElectionParams(ctx contract.Context, params *ElectionParams) error {
+	return ctx.Set(electionParamsKey, params)
+}
+
+// getCandidateOperator returns the operator the candidate was linked to, or an empty string if the
+// candidate hasn't specified an operator.
+func getCandidateOperator(ctx contract.StaticContext, candidate loom.Address) string {
+	var operator CandidateOperator
+	if err := ctx.Get(candidateOperatorKey(candidate), &operator); err != nil {
+		return ""
+	}
+	return operator.Operator
+}
+
+func setCandidateOperator(ctx contract.Context, candidate loom.Address, operator string) error {
+	if len(operator) == 0 {
+		ctx.Delete(candidateOperatorKey(candidate))
+		return nil
+	}
+	return ctx.Set(candidateOperatorKey(candidate), &CandidateOperator{
+		Candidate: candidate.MarshalPB(),
+		Operator:  operator,
+	})
+}
+
+// isAutoCompoundEnabled checks if the rewards earned by the delegator's delegations to the given
+// validator should be compounded, a setting for the validator takes precedence over the
+// delegator's default setting.
+func isAutoCompoundEnabled(ctx contract.StaticContext, delegator, validator loom.Address) bool {
+	var setting AutoCompoundSetting
+	if err := ctx.Get(autoCompoundKey(delegator, validator), &setting); err == nil {
+		return setting.Enabled
+	}
+	if err := ctx.Get(autoCompoundKey(delegator, loom.Address{}), &setting); err == nil {
+		return setting.Enabled
+	}
+	return false
+}
+
+func setAutoCompound(ctx contract.Context, delegator, validator loom.Address, enabled bool) error {
+	// Auto-compounding is disabled by default so there's no need to store a default setting that
+	// disables it.
+	if validator.IsEmpty() && !enabled {
+		ctx.Delete(autoCompoundKey(delegator, validator))
+		return nil
+	}
+	setting := &AutoCompoundSetting{
+		Delegator: delegator.MarshalPB(),
+		Enabled:   enabled,
+	}
+	if !validator.IsEmpty() {
+		setting.Validator = validator.MarshalPB()
+	}
+	return ctx.Set(autoCompoundKey(delegator, validator), setting)
+}
+
 type DelegationResult struct {
 	ValidatorAddress loom.Address
 	DelegationTotal  loom.BigUInt
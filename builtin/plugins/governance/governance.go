@@ -0,0 +1,606 @@
+package governance
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/common"
+	"github.com/loomnetwork/go-loom/plugin"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/features"
+)
+
+const (
+	proposalPrefix = "proposal"
+	votePrefix     = "vote"
+
+	hundredPercentInBasisPoints = 10000
+
+	defaultVotingPeriod  = int64(60 * 60 * 24 * 7) // 1 week
+	defaultQuorum        = uint64(3340)            // 33.4%
+	defaultPassThreshold = uint64(5000)            // 50%
+	maxDescriptionLength = 1024
+
+	// Minimum amount (in LOOM) an account must have delegated to submit a proposal, unless the
+	// params specify otherwise.
+	defaultMinProposerStake = 100000
+	tokenDecimals           = 18
+
+	// Limits on the number of proposals that can be open for voting at any one time, these keep the
+	// amount of work done by HarvestProposals at the end of each block bounded.
+	maxPendingProposals            = 50
+	maxPendingProposalsPerProposer = 3
+)
+
+var (
+	stateKey  = []byte("state")
+	paramsKey = []byte("params")
+)
+
+var (
+	// ErrNotAuthorized indicates that a contract method failed because the caller didn't have
+	// the permission to execute that method.
+	ErrNotAuthorized = errors.New("[Governance] not authorized")
+	// ErrInvalidRequest is a generic error that's returned when something is wrong with the
+	// request message, e.g. missing or invalid fields.
+	ErrInvalidRequest = errors.New("[Governance] invalid request")
+	// ErrInvalidParams is returned if the contract params are invalid.
+	ErrInvalidParams = errors.New("[Governance] invalid params")
+	// ErrFeatureNotEnabled is returned if the governance feature hasn't been enabled yet.
+	ErrFeatureNotEnabled = errors.New("[Governance] feature not enabled")
+	// ErrProposalNotFound is returned if a proposal with the given ID doesn't exist.
+	ErrProposalNotFound = errors.New("[Governance] proposal not found")
+	// ErrVotingClosed is returned if an account tries to vote on a proposal after voting has ended.
+	ErrVotingClosed = errors.New("[Governance] voting closed")
+	// ErrInsufficientStake is returned if an account doesn't have enough stake delegated to submit
+	// a proposal or vote on one.
+	ErrInsufficientStake = errors.New("[Governance] insufficient stake")
+	// ErrTooManyProposals is returned if a proposal can't be submitted because the proposer, or all
+	// proposers combined, already have the maximum number of proposals open for voting.
+	ErrTooManyProposals = errors.New("[Governance] too many pending proposals")
+)
+
+// Governance contract lets accounts with stake delegated in the DPOSv3 contract propose changes to
+// contract params, feature flags, and contract code, and vote on these proposals. Votes are
+// weighted by the amount each account has delegated when the voting period ends. Proposals that
+// pass are executed at the end of the block in which voting ended.
+type Governance struct {
+}
+
+func (c *Governance) Meta() (plugin.Meta, error) {
+	return plugin.Meta{
+		Name:    "governance",
+		Version: "1.0.0",
+	}, nil
+}
+
+func (c *Governance) Init(ctx contract.Context, req *InitRequest) error {
+	params := req.Params
+	if params == nil {
+		params = &Params{}
+	}
+	if params.VotingPeriod == 0 {
+		params.VotingPeriod = defaultVotingPeriod
+	}
+	if params.Quorum == 0 {
+		params.Quorum = defaultQuorum
+	}
+	if params.PassThreshold == 0 {
+		params.PassThreshold = defaultPassThreshold
+	}
+	if params.MinProposerStake == nil {
+		params.MinProposerStake = defaultMinProposerStakePB()
+	}
+	if err := validateParams(params); err != nil {
+		return err
+	}
+	if err := ctx.Set(paramsKey, params); err != nil {
+		return err
+	}
+	return ctx.Set(stateKey, &State{})
+}
+
+// SubmitProposal creates a new proposal that will be open for voting until the end of the voting
+// period. Only accounts that have delegated at least the minimum proposer stake can submit
+// proposals, and the number of proposals that can be open for voting at the same time is capped
+// both per proposer and in total.
+func (c *Governance) SubmitProposal(
+	ctx contract.Context, req *SubmitProposalRequest,
+) (*SubmitProposalResponse, error) {
+	if !ctx.FeatureEnabled(features.GovernanceFeature, false) {
+		return nil, ErrFeatureNotEnabled
+	}
+
+	if err := validateProposal(req); err != nil {
+		return nil, err
+	}
+
+	params, err := loadParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := ctx.Message().Sender
+	stake, err := delegatedStake(ctx, sender)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsPositive(*stake) || stake.Cmp(&params.MinProposerStake.Value) < 0 {
+		return nil, ErrInsufficientStake
+	}
+
+	state, err := loadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.PendingProposalIds) >= maxPendingProposals {
+		return nil, ErrTooManyProposals
+	}
+	numPending := 0
+	for _, id := range state.PendingProposalIds {
+		proposal, err := LoadProposal(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if loom.UnmarshalAddressPB(proposal.Proposer).Compare(sender) == 0 {
+			numPending++
+		}
+	}
+	if numPending >= maxPendingProposalsPerProposer {
+		return nil, ErrTooManyProposals
+	}
+
+	state.LastProposalId++
+	state.PendingProposalIds = append(state.PendingProposalIds, state.LastProposalId)
+
+	proposal := &Proposal{
+		Id:                state.LastProposalId,
+		Proposer:          sender.MarshalPB(),
+		Description:       req.Description,
+		ParamChange:       req.ParamChange,
+		FeatureActivation: req.FeatureActivation,
+		ContractUpgrade:   req.ContractUpgrade,
+		VotingEndTime:     ctx.Now().Unix() + params.VotingPeriod,
+	}
+	if err := saveProposal(ctx, proposal); err != nil {
+		return nil, err
+	}
+	if err := ctx.Set(stateKey, state); err != nil {
+		return nil, err
+	}
+
+	ctx.Logger().Info("[Governance] proposal submitted", "id", proposal.Id, "proposer", sender.String())
+	return &SubmitProposalResponse{ProposalId: proposal.Id}, nil
+}
+
+// Vote records the caller's vote on a proposal that's still open for voting, an account that has
+// already voted on the proposal can change its vote. Only accounts with stake delegated in the
+// DPOSv3 contract can vote.
+func (c *Governance) Vote(ctx contract.Context, req *VoteRequest) error {
+	if !ctx.FeatureEnabled(features.GovernanceFeature, false) {
+		return ErrFeatureNotEnabled
+	}
+
+	proposal, err := LoadProposal(ctx, req.ProposalId)
+	if err != nil {
+		return err
+	}
+	if proposal.Closed || ctx.Now().Unix() >= proposal.VotingEndTime {
+		return ErrVotingClosed
+	}
+
+	sender := ctx.Message().Sender
+	stake, err := delegatedStake(ctx, sender)
+	if err != nil {
+		return err
+	}
+	if !common.IsPositive(*stake) {
+		return ErrInsufficientStake
+	}
+
+	return ctx.Set(voteKey(proposal.Id, sender), &ProposalVote{
+		Voter:   sender.MarshalPB(),
+		Approve: req.Approve,
+	})
+}
+
+func (c *Governance) GetProposal(
+	ctx contract.StaticContext, req *GetProposalRequest,
+) (*GetProposalResponse, error) {
+	proposal, err := LoadProposal(ctx, req.ProposalId)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := listVotes(ctx, proposal.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &GetProposalResponse{
+		Proposal: proposal,
+		Votes:    votes,
+	}, nil
+}
+
+func (c *Governance) ListProposals(
+	ctx contract.StaticContext, req *ListProposalsRequest,
+) (*ListProposalsResponse, error) {
+	proposals := make([]*Proposal, 0)
+	if req.PendingOnly {
+		state, err := loadState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range state.PendingProposalIds {
+			proposal, err := LoadProposal(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			proposals = append(proposals, proposal)
+		}
+		return &ListProposalsResponse{Proposals: proposals}, nil
+	}
+
+	for _, m := range ctx.Range([]byte(proposalPrefix)) {
+		var proposal Proposal
+		if err := proto.Unmarshal(m.Value, &proposal); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal Proposal %x", m.Key)
+		}
+		proposals = append(proposals, &proposal)
+	}
+	return &ListProposalsResponse{Proposals: proposals}, nil
+}
+
+func (c *Governance) GetParams(ctx contract.StaticContext, req *GetParamsRequest) (*GetParamsResponse, error) {
+	params, err := loadParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GetParamsResponse{Params: params}, nil
+}
+
+// SetParams changes the contract params, this method can only be called by the contract itself,
+// i.e. the params can only be changed by a proposal.
+func (c *Governance) SetParams(ctx contract.Context, req *SetParamsRequest) error {
+	if ctx.Message().Sender.Compare(ctx.ContractAddress()) != 0 {
+		return ErrNotAuthorized
+	}
+	if req.Params == nil {
+		return ErrInvalidRequest
+	}
+	if req.Params.MinProposerStake == nil {
+		req.Params.MinProposerStake = defaultMinProposerStakePB()
+	}
+	if err := validateParams(req.Params); err != nil {
+		return err
+	}
+	return ctx.Set(paramsKey, req.Params)
+}
+
+// HarvestProposals tallies the votes on all the proposals whose voting period has ended, and
+// returns the ones that passed so they can be executed. Each voter's vote is weighted by the amount
+// the voter has delegated to the registered candidates at the time the votes are tallied, a
+// proposal passes if the stake that voted on it reaches the quorum of the total amount delegated to
+// the registered candidates, and the percentage of that stake which voted in favor of it exceeds
+// the pass threshold.
+func HarvestProposals(ctx contract.Context) ([]*Proposal, error) {
+	state, err := loadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.PendingProposalIds) == 0 {
+		return nil, nil
+	}
+
+	now := ctx.Now().Unix()
+	pending := make([]uint64, 0, len(state.PendingProposalIds))
+	var closed []*Proposal
+	for _, id := range state.PendingProposalIds {
+		proposal, err := LoadProposal(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if proposal.VotingEndTime > now {
+			pending = append(pending, id)
+			continue
+		}
+		closed = append(closed, proposal)
+	}
+	if len(closed) == 0 {
+		return nil, nil
+	}
+
+	params, err := loadParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stakes, err := loadCandidateStakes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var passed []*Proposal
+	for _, proposal := range closed {
+		if err := tallyVotes(ctx, proposal, params, stakes); err != nil {
+			return nil, err
+		}
+		if err := saveProposal(ctx, proposal); err != nil {
+			return nil, err
+		}
+		ctx.Logger().Info(
+			"[Governance] voting ended",
+			"id", proposal.Id,
+			"passed", proposal.Passed,
+			"yes", proposal.YesVotes.Value.String(),
+			"no", proposal.NoVotes.Value.String(),
+		)
+		if proposal.Passed {
+			passed = append(passed, proposal)
+		}
+	}
+
+	state.PendingProposalIds = pending
+	if err := ctx.Set(stateKey, state); err != nil {
+		return nil, err
+	}
+	return passed, nil
+}
+
+// SetProposalExecuted records the outcome of the execution of a proposal that passed.
+func SetProposalExecuted(ctx contract.Context, id uint64, execErr error) error {
+	proposal, err := LoadProposal(ctx, id)
+	if err != nil {
+		return err
+	}
+	proposal.Executed = execErr == nil
+	if execErr != nil {
+		proposal.ExecutionError = execErr.Error()
+	}
+	return saveProposal(ctx, proposal)
+}
+
+func tallyVotes(ctx contract.Context, proposal *Proposal, params *Params, stakes *candidateStakes) error {
+	votes, err := listVotes(ctx, proposal.Id)
+	if err != nil {
+		return err
+	}
+
+	yes := common.BigZero()
+	no := common.BigZero()
+	for _, vote := range votes {
+		stake, err := stakes.delegatedBy(ctx, loom.UnmarshalAddressPB(vote.Voter))
+		if err != nil {
+			return err
+		}
+		if vote.Approve {
+			yes.Add(yes, stake)
+		} else {
+			no.Add(no, stake)
+		}
+	}
+
+	voted := common.BigZero()
+	voted.Add(yes, no)
+	proposal.Closed = true
+	proposal.YesVotes = &types.BigUInt{Value: *yes}
+	proposal.NoVotes = &types.BigUInt{Value: *no}
+	proposal.TotalStake = &types.BigUInt{Value: *stakes.total}
+	proposal.Passed = common.IsPositive(*yes) &&
+		exceedsBasisPoints(voted, stakes.total, params.Quorum, true) &&
+		exceedsBasisPoints(yes, voted, params.PassThreshold, false)
+	return nil
+}
+
+// exceedsBasisPoints returns true if amount > total * bps / 10000, or if orEqual is true and
+// amount >= total * bps / 10000.
+func exceedsBasisPoints(amount, total *loom.BigUInt, bps uint64, orEqual bool) bool {
+	lhs := common.BigZero()
+	lhs.Mul(amount, loom.NewBigUIntFromInt(hundredPercentInBasisPoints))
+	rhs := common.BigZero()
+	rhs.Mul(total, loom.NewBigUIntFromInt(int64(bps)))
+	if orEqual {
+		return lhs.Cmp(rhs) >= 0
+	}
+	return lhs.Cmp(rhs) > 0
+}
+
+// delegatedStake returns the total amount the given account has delegated in the DPOSv3 contract.
+func delegatedStake(ctx contract.StaticContext, addr loom.Address) (*loom.BigUInt, error) {
+	resp, err := checkAllDelegations(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Amount == nil {
+		return common.BigZero(), nil
+	}
+	return &resp.Amount.Value, nil
+}
+
+func checkAllDelegations(
+	ctx contract.StaticContext, addr loom.Address,
+) (*dposv3.CheckAllDelegationsResponse, error) {
+	dposAddr, err := ctx.Resolve("dposV3")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve address of DPOSv3 contract")
+	}
+	req := &dposv3.CheckAllDelegationsRequest{DelegatorAddress: addr.MarshalPB()}
+	var resp dposv3.CheckAllDelegationsResponse
+	if err := contract.StaticCallMethod(ctx, dposAddr, "CheckAllDelegations", req, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to call CheckAllDelegations")
+	}
+	return &resp, nil
+}
+
+// candidateStakes measures the stake voting on proposals against the total stake the quorum is
+// computed from. Both only count the face value of the delegations to the registered candidates,
+// so the stake that voted on a proposal can never exceed the total.
+type candidateStakes struct {
+	candidates map[string]bool
+	total      *loom.BigUInt
+}
+
+func loadCandidateStakes(ctx contract.StaticContext) (*candidateStakes, error) {
+	dposAddr, err := ctx.Resolve("dposV3")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve address of DPOSv3 contract")
+	}
+	var resp dposv3.ListCandidatesResponse
+	if err := contract.StaticCallMethod(
+		ctx, dposAddr, "ListCandidates", &dposv3.ListCandidatesRequest{}, &resp,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to call ListCandidates")
+	}
+
+	stakes := &candidateStakes{
+		candidates: make(map[string]bool, len(resp.Candidates)),
+		total:      common.BigZero(),
+	}
+	for _, c := range resp.Candidates {
+		if c.Candidate == nil || c.Candidate.Address == nil {
+			continue
+		}
+		var delegations dposv3.ListDelegationsResponse
+		req := &dposv3.ListDelegationsRequest{Candidate: c.Candidate.Address}
+		if err := contract.StaticCallMethod(ctx, dposAddr, "ListDelegations", req, &delegations); err != nil {
+			return nil, errors.Wrap(err, "failed to call ListDelegations")
+		}
+		stakes.candidates[loom.UnmarshalAddressPB(c.Candidate.Address).String()] = true
+		if delegations.DelegationTotal != nil {
+			stakes.total.Add(stakes.total, &delegations.DelegationTotal.Value)
+		}
+	}
+	return stakes, nil
+}
+
+// delegatedBy returns the total amount the given account has delegated to the registered candidates.
+func (s *candidateStakes) delegatedBy(ctx contract.StaticContext, addr loom.Address) (*loom.BigUInt, error) {
+	resp, err := checkAllDelegations(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	stake := common.BigZero()
+	for _, d := range resp.Delegations {
+		if d.Validator == nil || d.Amount == nil {
+			continue
+		}
+		if s.candidates[loom.UnmarshalAddressPB(d.Validator).String()] {
+			stake.Add(stake, &d.Amount.Value)
+		}
+	}
+	return stake, nil
+}
+
+func defaultMinProposerStakePB() *types.BigUInt {
+	amount := loom.NewBigUIntFromInt(10)
+	amount.Exp(amount, loom.NewBigUIntFromInt(tokenDecimals), nil)
+	amount.Mul(amount, loom.NewBigUIntFromInt(defaultMinProposerStake))
+	return &types.BigUInt{Value: *amount}
+}
+
+func validateParams(params *Params) error {
+	if params.VotingPeriod <= 0 ||
+		params.Quorum > hundredPercentInBasisPoints ||
+		params.PassThreshold > hundredPercentInBasisPoints {
+		return ErrInvalidParams
+	}
+	return nil
+}
+
+func validateProposal(req *SubmitProposalRequest) error {
+	if len(req.Description) > maxDescriptionLength {
+		return errors.Wrap(ErrInvalidRequest, "description too long")
+	}
+
+	numActions := 0
+	if req.ParamChange != nil {
+		numActions++
+		if req.ParamChange.ContractName == "" || req.ParamChange.Method == "" {
+			return errors.Wrap(ErrInvalidRequest, "contract name & method must be specified")
+		}
+	}
+	if req.FeatureActivation != nil {
+		numActions++
+		if strings.TrimSpace(req.FeatureActivation.FeatureName) == "" {
+			return errors.Wrap(ErrInvalidRequest, "feature name must be specified")
+		}
+	}
+	if req.ContractUpgrade != nil {
+		numActions++
+		if req.ContractUpgrade.ContractName == "" || req.ContractUpgrade.PluginName == "" {
+			return errors.Wrap(ErrInvalidRequest, "contract & plugin name must be specified")
+		}
+	}
+	if numActions != 1 {
+		return errors.Wrap(ErrInvalidRequest, "proposal must specify exactly one action")
+	}
+	return nil
+}
+
+func proposalKey(id uint64) []byte {
+	return util.PrefixKey([]byte(proposalPrefix), idBytes(id))
+}
+
+func voteKey(id uint64, voter loom.Address) []byte {
+	return util.PrefixKey([]byte(votePrefix), idBytes(id), voter.Bytes())
+}
+
+func idBytes(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return b
+}
+
+func loadState(ctx contract.StaticContext) (*State, error) {
+	var state State
+	if err := ctx.Get(stateKey, &state); err != nil && err != contract.ErrNotFound {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func loadParams(ctx contract.StaticContext) (*Params, error) {
+	var params Params
+	if err := ctx.Get(paramsKey, &params); err != nil {
+		return nil, errors.Wrap(err, "failed to load params")
+	}
+	if params.MinProposerStake == nil {
+		params.MinProposerStake = defaultMinProposerStakePB()
+	}
+	return &params, nil
+}
+
+// LoadProposal loads the proposal with the given ID, returns ErrProposalNotFound if it doesn't exist.
+func LoadProposal(ctx contract.StaticContext, id uint64) (*Proposal, error) {
+	var proposal Proposal
+	if err := ctx.Get(proposalKey(id), &proposal); err != nil {
+		if err == contract.ErrNotFound {
+			return nil, ErrProposalNotFound
+		}
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func saveProposal(ctx contract.Context, proposal *Proposal) error {
+	return ctx.Set(proposalKey(proposal.Id), proposal)
+}
+
+func listVotes(ctx contract.StaticContext, id uint64) ([]*ProposalVote, error) {
+	votes := make([]*ProposalVote, 0)
+	for _, m := range ctx.Range(util.PrefixKey([]byte(votePrefix), idBytes(id))) {
+		var vote ProposalVote
+		if err := proto.Unmarshal(m.Value, &vote); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal ProposalVote %x", m.Key)
+		}
+		votes = append(votes, &vote)
+	}
+	return votes, nil
+}
+
+var Contract plugin.Contract = contract.MakePluginContract(&Governance{})
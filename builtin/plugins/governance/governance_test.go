@@ -0,0 +1,333 @@
+package governance
+
+import (
+	"encoding/hex"
+	"testing"
+
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/plugin"
+	"github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loomnetwork/loomchain/builtin/plugins/coin"
+	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/features"
+)
+
+var (
+	addr1        = loom.MustParseAddress("chain:0xb16a379ec18d4093666f8f38b11a3071c920207d")
+	contractAddr = loom.MustParseAddress("chain:0x5cecd1f7261e1f4c684e297be3edf03b825e01c4")
+
+	oracleAddr = loom.MustParseAddress("chain:0xfa4c7920accfd66b86f5fd0e69682a79f762d49e")
+	voter1     = loom.MustParseAddress("chain:0x0000000000000000000000000000000000000001")
+	voter2     = loom.MustParseAddress("chain:0x0000000000000000000000000000000000000002")
+	nonVoter   = loom.MustParseAddress("chain:0x0000000000000000000000000000000000000003")
+
+	candidatePubKey, _ = hex.DecodeString("3866f776276246e4f9998aa90632931d89b0d3a5930e804e02299533f55b39e1")
+	candidateAddr      = loom.Address{ChainID: "chain", Local: loom.LocalAddressFromPublicKey(candidatePubKey)}
+)
+
+const startTime = int64(100000)
+
+func TestInitDefaultParams(t *testing.T) {
+	ctx := contractpb.WrapPluginContext(plugin.CreateFakeContext(addr1, contractAddr))
+	c := &Governance{}
+	require.NoError(t, c.Init(ctx, &InitRequest{}))
+
+	resp, err := c.GetParams(ctx, &GetParamsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, defaultVotingPeriod, resp.Params.VotingPeriod)
+	require.Equal(t, defaultQuorum, resp.Params.Quorum)
+	require.Equal(t, defaultPassThreshold, resp.Params.PassThreshold)
+	require.Equal(t, defaultMinProposerStakePB().Value.String(), resp.Params.MinProposerStake.Value.String())
+
+	require.Error(t, c.Init(ctx, &InitRequest{Params: &Params{Quorum: 10001}}))
+}
+
+func TestSetParamsOnlyByContract(t *testing.T) {
+	pctx := plugin.CreateFakeContext(addr1, contractAddr)
+	c := &Governance{}
+	require.NoError(t, c.Init(contractpb.WrapPluginContext(pctx), &InitRequest{}))
+
+	params := &Params{
+		VotingPeriod:  60,
+		Quorum:        5000,
+		PassThreshold: 6667,
+	}
+	err := c.SetParams(contractpb.WrapPluginContext(pctx), &SetParamsRequest{Params: params})
+	require.Equal(t, ErrNotAuthorized, err)
+
+	ctx := contractpb.WrapPluginContext(pctx.WithSender(contractAddr))
+	require.NoError(t, c.SetParams(ctx, &SetParamsRequest{Params: params}))
+	resp, err := c.GetParams(ctx, &GetParamsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(60), resp.Params.VotingPeriod)
+	require.Equal(t, uint64(6667), resp.Params.PassThreshold)
+}
+
+func TestValidateProposal(t *testing.T) {
+	require.Error(t, validateProposal(&SubmitProposalRequest{}))
+	require.Error(t, validateProposal(&SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "  "},
+	}))
+	require.Error(t, validateProposal(&SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "dpos:v3.11"},
+		ParamChange:       &ParamChange{ContractName: "dposV3", Method: "SetPowerCap"},
+	}))
+	require.NoError(t, validateProposal(&SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "dpos:v3.11"},
+	}))
+	require.NoError(t, validateProposal(&SubmitProposalRequest{
+		ContractUpgrade: &ContractUpgrade{ContractName: "dposV3", PluginName: "dposV3:3.1.0"},
+	}))
+}
+
+func TestExceedsBasisPoints(t *testing.T) {
+	total := loom.NewBigUIntFromInt(1000)
+	require.True(t, exceedsBasisPoints(loom.NewBigUIntFromInt(501), total, 5000, false))
+	require.False(t, exceedsBasisPoints(loom.NewBigUIntFromInt(500), total, 5000, false))
+	require.True(t, exceedsBasisPoints(loom.NewBigUIntFromInt(500), total, 5000, true))
+	require.False(t, exceedsBasisPoints(loom.NewBigUIntFromInt(333), total, 3340, true))
+	require.True(t, exceedsBasisPoints(loom.NewBigUIntFromInt(334), total, 3340, true))
+	// nothing can exceed a share of zero
+	require.False(t, exceedsBasisPoints(loom.NewBigUIntFromInt(0), loom.NewBigUIntFromInt(0), 0, false))
+}
+
+// governanceTestEnv contains a Governance contract deployed alongside the DPOSv3 & Coin contracts,
+// with a single validator, to which voter1 has delegated 300 tokens & voter2 600 tokens. Accounts
+// that have delegated at least 100 tokens can submit proposals.
+type governanceTestEnv struct {
+	pctx     *plugin.FakeContext
+	gov      *Governance
+	govAddr  loom.Address
+	dposAddr loom.Address
+}
+
+func newGovernanceTestEnv(t *testing.T) *governanceTestEnv {
+	pctx := plugin.CreateFakeContext(addr1, loom.Address{}).WithBlock(loom.BlockHeader{
+		ChainID: "chain",
+		Time:    startTime,
+	})
+	pctx.SetFeature(features.GovernanceFeature, true)
+
+	coinAddr := pctx.CreateContract(coin.Contract)
+	coinCtx := pctx.WithAddress(coinAddr)
+	require.NoError(t, (&coin.Coin{}).Init(contractpb.WrapPluginContext(coinCtx), &coin.InitRequest{
+		Accounts: []*coin.InitialAccount{
+			{Owner: voter1.MarshalPB(), Balance: 1},
+			{Owner: voter2.MarshalPB(), Balance: 1},
+		},
+	}))
+
+	dpos := &dposv3.DPOS{}
+	dposAddr := pctx.CreateContract(contractpb.MakePluginContract(dpos))
+	dposCtx := pctx.WithAddress(dposAddr)
+	require.NoError(t, dpos.Init(contractpb.WrapPluginContext(dposCtx), &dposv3.InitRequest{
+		Params: &dposv3.Params{
+			ValidatorCount:      21,
+			CoinContractAddress: coinAddr.MarshalPB(),
+			OracleAddress:       oracleAddr.MarshalPB(),
+		},
+	}))
+	require.NoError(t, dpos.WhitelistCandidate(
+		contractpb.WrapPluginContext(dposCtx.WithSender(oracleAddr)),
+		&dposv3.WhitelistCandidateRequest{
+			CandidateAddress: candidateAddr.MarshalPB(),
+			Amount:           &types.BigUInt{Value: *loom.NewBigUIntFromInt(100)},
+		},
+	))
+	require.NoError(t, dpos.RegisterCandidate(
+		contractpb.WrapPluginContext(dposCtx.WithSender(candidateAddr)),
+		&dposv3.RegisterCandidateRequest{PubKey: candidatePubKey},
+	))
+	delegations := []struct {
+		voter  loom.Address
+		amount int64
+	}{
+		{voter1, 300},
+		{voter2, 600},
+	}
+	for _, d := range delegations {
+		amount := &types.BigUInt{Value: *loom.NewBigUIntFromInt(d.amount)}
+		require.NoError(t, (&coin.Coin{}).Approve(
+			contractpb.WrapPluginContext(coinCtx.WithSender(d.voter)),
+			&coin.ApproveRequest{Spender: dposAddr.MarshalPB(), Amount: amount},
+		))
+		require.NoError(t, dpos.Delegate(
+			contractpb.WrapPluginContext(dposCtx.WithSender(d.voter)),
+			&dposv3.DelegateRequest{ValidatorAddress: candidateAddr.MarshalPB(), Amount: amount},
+		))
+	}
+	require.NoError(t, dposv3.Elect(contractpb.WrapPluginContext(dposCtx)))
+
+	gov := &Governance{}
+	govAddr := pctx.CreateContract(contractpb.MakePluginContract(gov))
+	require.NoError(t, gov.Init(contractpb.WrapPluginContext(pctx.WithAddress(govAddr)), &InitRequest{
+		Params: &Params{
+			VotingPeriod:     100,
+			MinProposerStake: &types.BigUInt{Value: *loom.NewBigUIntFromInt(100)},
+		},
+	}))
+
+	return &governanceTestEnv{
+		pctx:     pctx,
+		gov:      gov,
+		govAddr:  govAddr,
+		dposAddr: dposAddr,
+	}
+}
+
+func (e *governanceTestEnv) ctx(sender loom.Address) contractpb.Context {
+	return contractpb.WrapPluginContext(e.pctx.WithAddress(e.govAddr).WithSender(sender))
+}
+
+func (e *governanceTestEnv) submitProposal(t *testing.T, proposer loom.Address) uint64 {
+	resp, err := e.gov.SubmitProposal(e.ctx(proposer), &SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "test:v1"},
+	})
+	require.NoError(t, err)
+	return resp.ProposalId
+}
+
+// endVoting moves the block time past the end of the voting period of the proposals submitted so far.
+func (e *governanceTestEnv) endVoting() {
+	e.pctx = e.pctx.WithBlock(loom.BlockHeader{
+		ChainID: "chain",
+		Time:    e.pctx.Now().Unix() + 100,
+	})
+}
+
+func TestVote(t *testing.T) {
+	env := newGovernanceTestEnv(t)
+
+	_, err := env.gov.SubmitProposal(env.ctx(nonVoter), &SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "test:v1"},
+	})
+	require.Equal(t, ErrInsufficientStake, err)
+
+	id := env.submitProposal(t, voter1)
+	require.Equal(t, ErrProposalNotFound, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: id + 1}))
+	require.Equal(t, ErrInsufficientStake, env.gov.Vote(env.ctx(nonVoter), &VoteRequest{ProposalId: id}))
+
+	// a voter can change its vote while voting is open
+	require.NoError(t, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: id, Approve: true}))
+	require.NoError(t, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: id, Approve: false}))
+	require.NoError(t, env.gov.Vote(env.ctx(voter2), &VoteRequest{ProposalId: id, Approve: true}))
+
+	resp, err := env.gov.GetProposal(env.ctx(voter1), &GetProposalRequest{ProposalId: id})
+	require.NoError(t, err)
+	require.Len(t, resp.Votes, 2)
+	for _, vote := range resp.Votes {
+		require.Equal(t, loom.UnmarshalAddressPB(vote.Voter).Compare(voter2) == 0, vote.Approve)
+	}
+
+	env.endVoting()
+	require.Equal(t, ErrVotingClosed, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: id, Approve: true}))
+
+	env.pctx.SetFeature(features.GovernanceFeature, false)
+	require.Equal(t, ErrFeatureNotEnabled, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: id}))
+}
+
+func TestHarvestProposals(t *testing.T) {
+	env := newGovernanceTestEnv(t)
+
+	// 600 of the 900 votes approve it
+	passed := env.submitProposal(t, voter1)
+	require.NoError(t, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: passed, Approve: false}))
+	require.NoError(t, env.gov.Vote(env.ctx(voter2), &VoteRequest{ProposalId: passed, Approve: true}))
+	// only 300 of the 900 votes approve it
+	rejected := env.submitProposal(t, voter1)
+	require.NoError(t, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: rejected, Approve: true}))
+	require.NoError(t, env.gov.Vote(env.ctx(voter2), &VoteRequest{ProposalId: rejected, Approve: false}))
+	// all the votes approve it, but only 300 of the 900 staked voted, which is below the quorum
+	noQuorum := env.submitProposal(t, voter2)
+	require.NoError(t, env.gov.Vote(env.ctx(voter1), &VoteRequest{ProposalId: noQuorum, Approve: true}))
+
+	// nothing is harvested while voting is still open
+	proposals, err := HarvestProposals(env.ctx(addr1))
+	require.NoError(t, err)
+	require.Len(t, proposals, 0)
+
+	env.endVoting()
+	// submitted after the others so voting on it is still open
+	pending := env.submitProposal(t, voter2)
+
+	proposals, err = HarvestProposals(env.ctx(addr1))
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	require.Equal(t, passed, proposals[0].Id)
+
+	for _, id := range []uint64{passed, rejected, noQuorum} {
+		proposal, err := LoadProposal(env.ctx(addr1), id)
+		require.NoError(t, err)
+		require.True(t, proposal.Closed)
+		require.Equal(t, id == passed, proposal.Passed)
+		// the whitelisted amount isn't delegated by anyone, so it doesn't count towards the quorum
+		require.Equal(t, int64(900), proposal.TotalStake.Value.Int64())
+	}
+	proposal, err := LoadProposal(env.ctx(addr1), passed)
+	require.NoError(t, err)
+	require.Equal(t, int64(600), proposal.YesVotes.Value.Int64())
+	require.Equal(t, int64(300), proposal.NoVotes.Value.Int64())
+
+	resp, err := env.gov.ListProposals(env.ctx(addr1), &ListProposalsRequest{PendingOnly: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Proposals, 1)
+	require.Equal(t, pending, resp.Proposals[0].Id)
+
+	// proposals are only harvested once
+	proposals, err = HarvestProposals(env.ctx(addr1))
+	require.NoError(t, err)
+	require.Len(t, proposals, 0)
+
+	require.NoError(t, SetProposalExecuted(env.ctx(addr1), passed, nil))
+	proposal, err = LoadProposal(env.ctx(addr1), passed)
+	require.NoError(t, err)
+	require.True(t, proposal.Executed)
+}
+
+func TestSubmitProposalLimits(t *testing.T) {
+	env := newGovernanceTestEnv(t)
+
+	// voter1 has delegated less than the min proposer stake
+	require.NoError(t, env.gov.SetParams(env.ctx(env.govAddr), &SetParamsRequest{
+		Params: &Params{
+			VotingPeriod:     100,
+			Quorum:           defaultQuorum,
+			PassThreshold:    defaultPassThreshold,
+			MinProposerStake: &types.BigUInt{Value: *loom.NewBigUIntFromInt(500)},
+		},
+	}))
+	_, err := env.gov.SubmitProposal(env.ctx(voter1), &SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "test:v1"},
+	})
+	require.Equal(t, ErrInsufficientStake, err)
+
+	for i := 0; i < maxPendingProposalsPerProposer; i++ {
+		env.submitProposal(t, voter2)
+	}
+	_, err = env.gov.SubmitProposal(env.ctx(voter2), &SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "test:v1"},
+	})
+	require.Equal(t, ErrTooManyProposals, err)
+
+	// once voting on the pending proposals ends the proposer can submit new ones
+	env.endVoting()
+	_, err = HarvestProposals(env.ctx(addr1))
+	require.NoError(t, err)
+	env.submitProposal(t, voter2)
+
+	// the total number of pending proposals is capped regardless of who submitted them
+	ctx := env.ctx(voter2)
+	state, err := loadState(ctx)
+	require.NoError(t, err)
+	for len(state.PendingProposalIds) < maxPendingProposals {
+		state.PendingProposalIds = append(state.PendingProposalIds, state.LastProposalId+1000)
+	}
+	require.NoError(t, ctx.Set(stateKey, state))
+	_, err = env.gov.SubmitProposal(ctx, &SubmitProposalRequest{
+		FeatureActivation: &FeatureActivation{FeatureName: "test:v1"},
+	})
+	require.Equal(t, ErrTooManyProposals, err)
+}
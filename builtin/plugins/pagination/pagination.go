@@ -0,0 +1,187 @@
+// Package pagination implements the cursor based pagination used by the list methods of the
+// built-in contracts.
+package pagination
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/hex"
+	"sort"
+
+	"github.com/loomnetwork/go-loom/plugin"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultLimit is the number of items returned in a page when the caller doesn't specify a limit.
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of items that can be returned in a single page.
+	MaxLimit = 1000
+)
+
+// Page returns the half-open range [start, end) of the keys that make up the page that follows the
+// cursor, and the cursor that should be used to get the next page, which will be empty if there are
+// no more keys. The keys must be sorted in ascending order.
+//
+// A cursor is the hex encoded key of the last item of a page, so pages remain consistent when
+// items are added or removed between calls.
+func Page(keys [][]byte, cursor string, limit uint64) (int, int, string, error) {
+	lastKey, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	limit = Limit(limit)
+
+	start := 0
+	if len(lastKey) > 0 {
+		start = sort.Search(len(keys), func(i int) bool {
+			return bytes.Compare(keys[i], lastKey) > 0
+		})
+	}
+	end := start + int(limit)
+	if end >= len(keys) {
+		return start, len(keys), "", nil
+	}
+	return start, end, hex.EncodeToString(keys[end-1]), nil
+}
+
+// Select returns the indices of the keys that make up the page that follows the cursor, in key
+// order, and the cursor that should be used to get the next page, which will be empty if there are
+// no more keys. Unlike Page the keys don't have to be sorted, only the keys in the page are, so
+// it's suited to lists that are stored as a single value and have to be loaded in full anyway.
+func Select(keys [][]byte, cursor string, limit uint64) ([]int, string, error) {
+	lastKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = Limit(limit)
+
+	// Keep track of the limit+1 smallest keys after the cursor, the extra key is only used to find
+	// out if there's another page.
+	selected := &keyHeap{keys: keys}
+	for i, key := range keys {
+		if len(lastKey) > 0 && bytes.Compare(key, lastKey) <= 0 {
+			continue
+		}
+		if uint64(selected.Len()) <= limit {
+			heap.Push(selected, i)
+		} else if bytes.Compare(key, keys[selected.indices[0]]) < 0 {
+			selected.indices[0] = i
+			heap.Fix(selected, 0)
+		}
+	}
+
+	indices := selected.indices
+	sort.Slice(indices, func(i, j int) bool {
+		return bytes.Compare(keys[indices[i]], keys[indices[j]]) < 0
+	})
+	if uint64(len(indices)) <= limit {
+		return indices, "", nil
+	}
+	indices = indices[:limit]
+	return indices, hex.EncodeToString(keys[indices[limit-1]]), nil
+}
+
+// keyHeap is a max-heap of indices into a list of keys, ordered by key.
+type keyHeap struct {
+	keys    [][]byte
+	indices []int
+}
+
+func (h *keyHeap) Len() int {
+	return len(h.indices)
+}
+
+func (h *keyHeap) Less(i, j int) bool {
+	return bytes.Compare(h.keys[h.indices[i]], h.keys[h.indices[j]]) > 0
+}
+
+func (h *keyHeap) Swap(i, j int) {
+	h.indices[i], h.indices[j] = h.indices[j], h.indices[i]
+}
+
+func (h *keyHeap) Push(x interface{}) {
+	h.indices = append(h.indices, x.(int))
+}
+
+func (h *keyHeap) Pop() interface{} {
+	last := h.indices[len(h.indices)-1]
+	h.indices = h.indices[:len(h.indices)-1]
+	return last
+}
+
+// SortRange sorts the entries returned by a range query by key.
+func SortRange(entries plugin.RangeData) {
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Key, entries[j].Key) < 0
+	})
+}
+
+// RangeKeys returns the keys of the entries returned by a range query.
+func RangeKeys(entries plugin.RangeData) [][]byte {
+	keys := make([][]byte, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// RangeFrom is implemented by contract contexts that can read a limited number of the keys that
+// follow a cursor without loading all the keys with the same prefix.
+type RangeFrom interface {
+	RangeFrom(prefix, start []byte, limit int) plugin.RangeData
+}
+
+// Range returns the page of entries with the given prefix that follows the cursor, ordered by key,
+// and the cursor that should be used to get the next page, which will be empty if there are no
+// more entries. Only the entries that make up the page are read from the contract storage if the
+// contract context implements RangeFrom, otherwise all the entries with the prefix are read and
+// sorted.
+func Range(
+	ctx contract.StaticContext, prefix []byte, cursor string, limit uint64,
+) (plugin.RangeData, string, error) {
+	lastKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = Limit(limit)
+
+	reader, ok := ctx.(RangeFrom)
+	if !ok {
+		entries := ctx.Range(prefix)
+		SortRange(entries)
+		start, end, nextCursor, err := Page(RangeKeys(entries), cursor, limit)
+		if err != nil {
+			return nil, "", err
+		}
+		return entries[start:end], nextCursor, nil
+	}
+
+	// Read one extra entry to find out if there's another page.
+	entries := reader.RangeFrom(prefix, lastKey, int(limit)+1)
+	if len(entries) <= int(limit) {
+		return entries, "", nil
+	}
+	entries = entries[:limit]
+	return entries, hex.EncodeToString(entries[len(entries)-1].Key), nil
+}
+
+func decodeCursor(cursor string) ([]byte, error) {
+	lastKey, err := hex.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	return lastKey, nil
+}
+
+// Limit returns the number of items that should be returned in a page given the limit requested by
+// the caller.
+func Limit(limit uint64) uint64 {
+	if limit == 0 {
+		return DefaultLimit
+	} else if limit > MaxLimit {
+		return MaxLimit
+	}
+	return limit
+}
@@ -0,0 +1,145 @@
+package pagination
+
+import (
+	"encoding/hex"
+	"testing"
+
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/plugin"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loomnetwork/loomchain/store"
+)
+
+func TestPage(t *testing.T) {
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e")}
+
+	start, end, cursor, err := Page(keys, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, start)
+	require.Equal(t, 2, end)
+	require.Equal(t, hex.EncodeToString([]byte("b")), cursor)
+
+	start, end, cursor, err = Page(keys, cursor, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, start)
+	require.Equal(t, 4, end)
+	require.Equal(t, hex.EncodeToString([]byte("d")), cursor)
+
+	start, end, cursor, err = Page(keys, cursor, 2)
+	require.NoError(t, err)
+	require.Equal(t, 4, start)
+	require.Equal(t, 5, end)
+	require.Equal(t, "", cursor)
+
+	// the cursor doesn't have to match an existing key
+	start, end, cursor, err = Page(keys, hex.EncodeToString([]byte("bb")), 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, start)
+	require.Equal(t, 5, end)
+	require.Equal(t, "", cursor)
+
+	start, end, _, err = Page(keys, hex.EncodeToString([]byte("z")), 2)
+	require.NoError(t, err)
+	require.Equal(t, start, end)
+
+	_, _, _, err = Page(keys, "not hex", 2)
+	require.Error(t, err)
+}
+
+func TestSortRange(t *testing.T) {
+	entries := plugin.RangeData{
+		&plugin.RangeEntry{Key: []byte("c")},
+		&plugin.RangeEntry{Key: []byte("a")},
+		&plugin.RangeEntry{Key: []byte("b")},
+	}
+	SortRange(entries)
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, RangeKeys(entries))
+}
+
+func TestSelect(t *testing.T) {
+	keys := [][]byte{[]byte("d"), []byte("b"), []byte("e"), []byte("a"), []byte("c")}
+
+	indices, cursor, err := Select(keys, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 1}, indices)
+	require.Equal(t, hex.EncodeToString([]byte("b")), cursor)
+
+	indices, cursor, err = Select(keys, cursor, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 0}, indices)
+	require.Equal(t, hex.EncodeToString([]byte("d")), cursor)
+
+	indices, cursor, err = Select(keys, cursor, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, indices)
+	require.Equal(t, "", cursor)
+
+	// the cursor doesn't have to match an existing key
+	indices, cursor, err = Select(keys, hex.EncodeToString([]byte("bb")), 0)
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 0, 2}, indices)
+	require.Equal(t, "", cursor)
+
+	_, _, err = Select(keys, "not hex", 2)
+	require.Error(t, err)
+}
+
+// rangeFromContext implements RangeFrom on top of a contract context.
+type rangeFromContext struct {
+	contract.StaticContext
+	reader store.KVReader
+	calls  int
+}
+
+func (c *rangeFromContext) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	c.calls++
+	return store.RangeFrom(c.reader, prefix, start, limit)
+}
+
+// wrappedContext hides the RangeFrom implementation of the context it wraps, so Range has to fall
+// back to reading all the entries with the prefix.
+type wrappedContext struct {
+	contract.StaticContext
+}
+
+func TestRange(t *testing.T) {
+	addr := loom.MustParseAddress("chain:0xb16a379ec18d4093666f8f38b11a3071c920207d")
+	pctx := plugin.CreateFakeContext(addr, addr)
+	for _, key := range []string{"e", "c", "a", "d", "b"} {
+		pctx.Set(util.PrefixKey([]byte("item"), []byte(key)), []byte(key))
+	}
+	pctx.Set(util.PrefixKey([]byte("other"), []byte("f")), []byte("f"))
+
+	readerCtx := &rangeFromContext{StaticContext: contract.WrapPluginStaticContext(pctx), reader: pctx}
+	ctxs := []contract.StaticContext{
+		contract.WrapPluginStaticContext(pctx),
+		readerCtx,
+		&wrappedContext{readerCtx},
+	}
+	for _, ctx := range ctxs {
+		var pages [][][]byte
+		cursor := ""
+		for {
+			entries, nextCursor, err := Range(ctx, []byte("item"), cursor, 2)
+			require.NoError(t, err)
+			pages = append(pages, RangeKeys(entries))
+			if nextCursor == "" {
+				break
+			}
+			cursor = nextCursor
+		}
+		require.Equal(t, [][][]byte{
+			{[]byte("a"), []byte("b")},
+			{[]byte("c"), []byte("d")},
+			{[]byte("e")},
+		}, pages)
+
+		_, _, err := Range(ctx, []byte("item"), "not hex", 2)
+		require.Error(t, err)
+	}
+	// only the pages read via readerCtx itself should've been read with RangeFrom
+	require.Equal(t, 3, readerCtx.calls)
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/loomnetwork/go-loom/cli"
 	"github.com/loomnetwork/go-loom/common/evmcompat"
 	lcrypto "github.com/loomnetwork/go-loom/crypto"
+	"github.com/loomnetwork/go-loom/types"
 	"github.com/loomnetwork/loomchain/builtin/plugins/address_mapper"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -38,56 +40,152 @@ func AddIdentityMappingCmd() *cobra.Command {
 			}
 			mapping.From = user.MarshalPB()
 
-			var privkey *ecdsa.PrivateKey
-			var foreignLocalAddr loom.LocalAddress
-			var sigType = evmcompat.SignatureType_EIP712
+			privkey, foreignLocalAddr, sigType, err := loadForeignKey(chainId, args[1])
+			if err != nil {
+				return err
+			}
+
+			foreignAddr := loom.Address{ChainID: chainId, Local: foreignLocalAddr}
+			mapping.To = foreignAddr.MarshalPB()
+			mapping.Signature, err = address_mapper.SignIdentityMapping(user, foreignAddr, privkey, sigType)
+			if err != nil {
+				return errors.Wrapf(err, "sigining mapping with %s key", chainId)
+			}
+
+			err = cli.CallContractWithFlags(&callFlags, AddressMapperName, "AddIdentityMapping", &mapping, nil)
+			if err != nil {
+				return errors.Wrap(err, "call contract")
+			} else {
+				fmt.Println("mapping successful")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&callFlags.URI, "uri", "u", "http://localhost:46658", "DAppChain base URI")
+	cmd.Flags().StringVar(&callFlags.ContractAddr, "contract", "", "contract address")
+	cmd.Flags().StringVarP(&callFlags.ChainID, "chain", "", "default", "chain ID")
+	cmd.Flags().StringVarP(&callFlags.PrivFile, "key", "k", "", "private key file")
+	cmd.Flags().StringVar(&callFlags.HsmConfigFile, "hsm", "", "hsm config file")
+	cmd.Flags().StringVar(&callFlags.Algo, "algo", "ed25519", "Signing algo: ed25519, secp256k1, tron")
+	cmd.Flags().StringVarP(&chainId, "mapped-chain-id", "c", "eth", "ethereum chain id")
+	return cmd
+}
+
+// loadForeignKey loads the private key of a foreign account from the given file, and returns the
+// key, the corresponding address, and the type of signature that should be generated by the key.
+func loadForeignKey(
+	chainId string, keyFile string,
+) (*ecdsa.PrivateKey, loom.LocalAddress, evmcompat.SignatureType, error) {
+	var privkey *ecdsa.PrivateKey
+	var foreignLocalAddr loom.LocalAddress
+	var sigType = evmcompat.SignatureType_EIP712
+	var err error
+
+	switch strings.TrimSpace(chainId) {
+	case "eth":
+		privkey, err = crypto.LoadECDSA(keyFile)
+		if err != nil {
+			return nil, nil, sigType, errors.Wrapf(err, "read ethereum private key from file %v", keyFile)
+		}
+		foreignLocalAddr, err = loom.LocalAddressFromHexString(crypto.PubkeyToAddress(privkey.PublicKey).Hex())
+		if err != nil {
+			return nil, nil, sigType, errors.Wrapf(err, "bad ethereum private key from file %v", keyFile)
+		}
+	case "tron":
+		privkey, err = lcrypto.LoadBtecSecp256k1PrivKey(keyFile)
+		if err != nil {
+			return nil, nil, sigType, errors.Wrapf(err, "read tron private key from file %v", keyFile)
+		}
+		foreignLocalAddr, err = loom.LocalAddressFromHexString(crypto.PubkeyToAddress(privkey.PublicKey).Hex())
+		if err != nil {
+			return nil, nil, sigType, errors.Wrapf(err, "bad tron private key from file% v", keyFile)
+		}
+		sigType = evmcompat.SignatureType_TRON
+	case "binance":
+		privkey, err = crypto.LoadECDSA(keyFile)
+		if err != nil {
+			return nil, nil, sigType, errors.Wrapf(err, "read binance private key from file %v", keyFile)
+		}
+		signer := auth.NewBinanceSigner(crypto.FromECDSA(privkey))
+		foreignLocalAddr, err = loom.LocalAddressFromHexString(evmcompat.BitcoinAddress(signer.PublicKey()).Hex())
+		if err != nil {
+			return nil, nil, sigType, errors.Wrapf(err, "bad binance private key from file %v", keyFile)
+		}
+		sigType = evmcompat.SignatureType_BINANCE
+	default:
+		return nil, nil, sigType, fmt.Errorf("unsupported mapped chain ID %s", chainId)
+	}
+	return privkey, foreignLocalAddr, sigType, nil
+}
+
+func RemoveMappingCmd() *cobra.Command {
+	var chainId string
+	var foreignKeyFile string
+	var callFlags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:   "remove-mapping <addr>",
+		Short: "Removes the mapping between a DAppChain account and a Mainnet account.",
+		Long: `Removes the mapping between a DAppChain account and a Mainnet account.
+The tx must be signed by one of the mapped accounts, or the private key of the mapped Mainnet account
+must be specified via --foreign-key so the removal can be authorized by a signature from that key.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := cli.ParseAddress(args[0], callFlags.ChainID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse address %v", args[0])
+			}
+			req := &address_mapper.RemoveMappingRequest{
+				From: addr.MarshalPB(),
+			}
 
-			switch strings.TrimSpace(chainId) {
-			case "eth":
-				privkey, err = crypto.LoadECDSA(args[1])
+			if foreignKeyFile != "" {
+				privkey, foreignLocalAddr, sigType, err := loadForeignKey(chainId, foreignKeyFile)
 				if err != nil {
-					return errors.Wrapf(err, "read ethereum private key from file %v", args[1])
+					return err
 				}
-				foreignLocalAddr, err = loom.LocalAddressFromHexString(crypto.PubkeyToAddress(privkey.PublicKey).Hex())
+				foreignAddr := loom.Address{ChainID: chainId, Local: foreignLocalAddr}
+
+				var mapping amtypes.AddressMapperGetMappingResponse
+				err = cli.StaticCallContractWithFlags(&callFlags, AddressMapperName, "GetMapping",
+					&amtypes.AddressMapperGetMappingRequest{
+						From: foreignAddr.MarshalPB(),
+					}, &mapping)
 				if err != nil {
-					return errors.Wrapf(err, "bad ethereum private key from file %v", args[1])
+					return errors.Wrap(err, "failed to load mapping")
 				}
-			case "tron":
-				privkey, err = lcrypto.LoadBtecSecp256k1PrivKey(args[1])
+
+				var nonce types.BigUInt
+				err = cli.StaticCallContractWithFlags(&callFlags, AddressMapperName, "GetRemovalNonce",
+					&amtypes.AddressMapperGetMappingRequest{
+						From: foreignAddr.MarshalPB(),
+					}, &nonce)
 				if err != nil {
-					return errors.Wrapf(err, "read tron private key from file %v", args[1])
+					return errors.Wrap(err, "failed to load removal nonce")
 				}
-				foreignLocalAddr, err = loom.LocalAddressFromHexString(crypto.PubkeyToAddress(privkey.PublicKey).Hex())
-				if err != nil {
-					return errors.Wrapf(err, "bad tron private key from file% v", args[1])
+
+				contractName := callFlags.ContractAddr
+				if contractName == "" {
+					contractName = AddressMapperName
 				}
-				sigType = evmcompat.SignatureType_TRON
-			case "binance":
-				privkey, err = crypto.LoadECDSA(args[1])
+				contractAddr, err := cli.ResolveAddress(contractName, callFlags.ChainID, callFlags.URI)
 				if err != nil {
-					return errors.Wrapf(err, "read binance private key from file %v", args[1])
+					return errors.Wrap(err, "failed to resolve contract address")
 				}
-				signer := auth.NewBinanceSigner(crypto.FromECDSA(privkey))
-				foreignLocalAddr, err = loom.LocalAddressFromHexString(evmcompat.BitcoinAddress(signer.PublicKey()).Hex())
+
+				req.Signature, err = address_mapper.SignRemoveMapping(
+					contractAddr, loom.UnmarshalAddressPB(mapping.To), foreignAddr, nonce.Value.Uint64(),
+					privkey, sigType,
+				)
 				if err != nil {
-					return errors.Wrapf(err, "bad binance private key from file %v", args[1])
+					return errors.Wrapf(err, "sigining mapping removal with %s key", chainId)
 				}
-				sigType = evmcompat.SignatureType_BINANCE
-			}
-
-			foreignAddr := loom.Address{ChainID: chainId, Local: foreignLocalAddr}
-			mapping.To = foreignAddr.MarshalPB()
-			mapping.Signature, err = address_mapper.SignIdentityMapping(user, foreignAddr, privkey, sigType)
-			if err != nil {
-				return errors.Wrapf(err, "sigining mapping with %s key", chainId)
 			}
 
-			err = cli.CallContractWithFlags(&callFlags, AddressMapperName, "AddIdentityMapping", &mapping, nil)
+			err = cli.CallContractWithFlags(&callFlags, AddressMapperName, "RemoveMapping", req, nil)
 			if err != nil {
 				return errors.Wrap(err, "call contract")
-			} else {
-				fmt.Println("mapping successful")
 			}
+			fmt.Println("mapping removed")
 			return nil
 		},
 	}
@@ -97,6 +195,7 @@ func AddIdentityMappingCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&callFlags.PrivFile, "key", "k", "", "private key file")
 	cmd.Flags().StringVar(&callFlags.HsmConfigFile, "hsm", "", "hsm config file")
 	cmd.Flags().StringVar(&callFlags.Algo, "algo", "ed25519", "Signing algo: ed25519, secp256k1, tron")
+	cmd.Flags().StringVar(&foreignKeyFile, "foreign-key", "", "private key file of the mapped Mainnet account")
 	cmd.Flags().StringVarP(&chainId, "mapped-chain-id", "c", "eth", "ethereum chain id")
 	return cmd
 }
@@ -133,16 +232,31 @@ func GetMapping() *cobra.Command {
 
 func ListMappingCmd() *cobra.Command {
 	var flags cli.ContractCallFlags
+	var cursor string
+	var limit uint64
 	cmd := &cobra.Command{
 		Use:   "list-mappings",
 		Short: "list user account mappings",
 		Args:  cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var resp address_mapper.ListMappingResponse
-			err := cli.StaticCallContractWithFlags(&flags, AddressMapperName, "ListMapping",
-				&address_mapper.ListMappingRequest{}, &resp)
-			if err != nil {
-				return errors.Wrap(err, "static call contract")
+			var mappings []*address_mapper.AddressMapping
+			var nextCursor string
+			if limit > 0 || cursor != "" {
+				var resp address_mapper.ListMappingPageResponse
+				err := cli.StaticCallContractWithFlags(&flags, AddressMapperName, "ListMappingPage",
+					&pagination.PageRequest{Cursor: cursor, Limit: limit}, &resp)
+				if err != nil {
+					return errors.Wrap(err, "static call contract")
+				}
+				mappings, nextCursor = resp.Mappings, resp.NextCursor
+			} else {
+				var resp address_mapper.ListMappingResponse
+				err := cli.StaticCallContractWithFlags(&flags, AddressMapperName, "ListMapping",
+					&address_mapper.ListMappingRequest{}, &resp)
+				if err != nil {
+					return errors.Wrap(err, "static call contract")
+				}
+				mappings = resp.Mappings
 			}
 			type maxLength struct {
 				From int
@@ -151,15 +265,20 @@ func ListMappingCmd() *cobra.Command {
 			ml := maxLength{From: 50, To: 50}
 
 			fmt.Printf("%-*s | %-*s \n", ml.From, "From", ml.To, "To")
-			for _, value := range resp.Mappings {
+			for _, value := range mappings {
 				fmt.Printf("%-*s | %-*s\n",
 					ml.From, loom.UnmarshalAddressPB(value.From).String(),
 					ml.To, loom.UnmarshalAddressPB(value.To).String())
 			}
+			if nextCursor != "" {
+				fmt.Printf("\nNext cursor: %s\n", nextCursor)
+			}
 			return nil
 		},
 	}
 	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Only list mappings after this cursor (the next cursor of the previous page)")
+	cmd.Flags().Uint64Var(&limit, "limit", 0, "Maximum number of mappings to list")
 	return cmd
 }
 
@@ -170,6 +289,7 @@ func NewAddressMapperCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		AddIdentityMappingCmd(),
+		RemoveMappingCmd(),
 		GetMapping(),
 		ListMappingCmd(),
 	)
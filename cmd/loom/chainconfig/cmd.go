@@ -3,6 +3,7 @@ package chainconfig
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,7 +17,10 @@ import (
 	"github.com/loomnetwork/go-loom/client"
 	"github.com/loomnetwork/go-loom/config"
 	plugintypes "github.com/loomnetwork/go-loom/plugin/types"
+	"github.com/loomnetwork/go-loom/types"
+	ccplugin "github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/spf13/cobra"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -38,6 +42,8 @@ func NewChainCfgCommand() *cobra.Command {
 		GetFeatureCmd(),
 		SetParamsCmd(),
 		GetParamsCmd(),
+		SetMinGasPriceCmd(),
+		GetMinGasPriceCmd(),
 		ListFeaturesCmd(),
 		FeatureEnabledCmd(),
 		RemoveFeatureCmd(),
@@ -47,6 +53,9 @@ func NewChainCfgCommand() *cobra.Command {
 		SetValidatorInfoCmd(),
 		GetValidatorInfoCmd(),
 		ListValidatorsInfoCmd(),
+		ProposeContractUpgradeCmd(),
+		ApproveContractUpgradeCmd(),
+		ListContractUpgradeProposalsCmd(),
 	)
 	return cmd
 }
@@ -185,6 +194,61 @@ func GetParamsCmd() *cobra.Command {
 	return cmd
 }
 
+const setMinGasPriceCmdExample = `
+loom chain-cfg set-min-gas-price 1000000000
+`
+
+func SetMinGasPriceCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "set-min-gas-price <price in wei>",
+		Short:   "Set the minimum gas price of Ethereum txs, zero disables the minimum",
+		Example: setMinGasPriceCmdExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			price, ok := new(big.Int).SetString(args[0], 10)
+			if !ok || price.Sign() < 0 {
+				return fmt.Errorf("invalid gas price: %s", args[0])
+			}
+			req := &ccplugin.SetMinGasPriceRequest{
+				MinGasPrice: &types.BigUInt{Value: *loom.NewBigUInt(price)},
+			}
+			return cli.CallContractWithFlags(&flags, chainConfigContractName, "SetMinGasPrice", req, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const getMinGasPriceCmdExample = `
+loom chain-cfg get-min-gas-price
+`
+
+func GetMinGasPriceCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "get-min-gas-price",
+		Short:   "Get the minimum gas price of Ethereum txs",
+		Example: getMinGasPriceCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resp ccplugin.GetMinGasPriceResponse
+			err := cli.StaticCallContractWithFlags(&flags, chainConfigContractName, "GetMinGasPrice",
+				&ccplugin.GetMinGasPriceRequest{}, &resp)
+			if err != nil {
+				return err
+			}
+			if resp.MinGasPrice == nil {
+				fmt.Println("0")
+				return nil
+			}
+			fmt.Println(resp.MinGasPrice.Value.String())
+			return nil
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
 const getFeatureCmdExample = `
 loom chain-cfg get-feature hardfork
 `
@@ -217,20 +281,36 @@ func GetFeatureCmd() *cobra.Command {
 
 const listFeaturesCmdExample = `
 loom chainconfig list-features
+loom chainconfig list-features --limit 20 --cursor <next cursor of the previous page>
 `
 
 func ListFeaturesCmd() *cobra.Command {
 	var flags cli.ContractCallFlags
+	var cursor string
+	var limit uint64
 	cmd := &cobra.Command{
 		Use:     "list-features",
 		Short:   "Display all features",
 		Example: listFeaturesCmdExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var resp cctype.ListFeaturesResponse
-			err := cli.StaticCallContractWithFlags(&flags, chainConfigContractName, "ListFeatures",
-				&cctype.ListFeaturesRequest{}, &resp)
-			if err != nil {
-				return err
+			var features []*cctype.Feature
+			var nextCursor string
+			if limit > 0 || cursor != "" {
+				var resp ccplugin.ListFeaturesPageResponse
+				err := cli.StaticCallContractWithFlags(&flags, chainConfigContractName, "ListFeaturesPage",
+					&pagination.PageRequest{Cursor: cursor, Limit: limit}, &resp)
+				if err != nil {
+					return err
+				}
+				features, nextCursor = resp.Features, resp.NextCursor
+			} else {
+				var resp cctype.ListFeaturesResponse
+				err := cli.StaticCallContractWithFlags(&flags, chainConfigContractName, "ListFeatures",
+					&cctype.ListFeaturesRequest{}, &resp)
+				if err != nil {
+					return err
+				}
+				features = resp.Features
 			}
 
 			type maxLength struct {
@@ -243,7 +323,7 @@ func ListFeaturesCmd() *cobra.Command {
 			}
 
 			ml := maxLength{Name: 4, Status: 7, Validators: 10, Height: 6, Percentage: 6, BuildNumber: 5}
-			for _, value := range resp.Features {
+			for _, value := range features {
 				if len(value.Name) > ml.Name {
 					ml.Name = len(value.Name)
 				}
@@ -258,17 +338,22 @@ func ListFeaturesCmd() *cobra.Command {
 			fmt.Printf(
 				strings.Repeat("-", ml.Name+ml.Status+ml.Validators+
 					ml.Height+ml.Percentage+ml.BuildNumber+15) + "\n")
-			for _, value := range resp.Features {
+			for _, value := range features {
 				fmt.Printf("%-*s | %-*s | %-*d | %-*d | %-*d | %-*d\n",
 					ml.Name, value.Name, ml.Status, value.Status,
 					ml.Validators, len(value.Validators), ml.Height,
 					value.BlockHeight, ml.Percentage, value.Percentage,
 					ml.BuildNumber, value.BuildNumber)
 			}
+			if nextCursor != "" {
+				fmt.Printf("\nNext cursor: %s\n", nextCursor)
+			}
 			return nil
 		},
 	}
 	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Only list features after this cursor (the next cursor of the previous page)")
+	cmd.Flags().Uint64Var(&limit, "limit", 0, "Maximum number of features to list")
 	return cmd
 }
 
@@ -633,6 +718,94 @@ func ListValidatorsInfoCmd() *cobra.Command {
 	return cmd
 }
 
+const proposeContractUpgradeCmdExample = `
+loom chain-cfg propose-contract-upgrade dposV3 dposV3:3.1.0 --height 1500000 -k private_key
+`
+
+func ProposeContractUpgradeCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var blockHeight int64
+	cmd := &cobra.Command{
+		Use:     "propose-contract-upgrade <contract name or address> <new plugin name:version>",
+		Short:   "Propose switching a Go contract to a different version of its code at a future block height",
+		Example: proposeContractUpgradeCmdExample,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddr, err := cli.ResolveAddress(args[0], flags.ChainID, flags.URI)
+			if err != nil {
+				return err
+			}
+			req := &ccplugin.ProposeContractUpgradeRequest{
+				ContractAddress: contractAddr.MarshalPB(),
+				PluginName:      args[1],
+				BlockHeight:     blockHeight,
+			}
+			return cli.CallContractWithFlags(&flags, chainConfigContractName, "ProposeContractUpgrade", req, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().Int64Var(&blockHeight, "height", 0, "Block height from which the new code should be loaded")
+	cmd.MarkFlagRequired("height")
+	return cmd
+}
+
+const approveContractUpgradeCmdExample = `
+loom chain-cfg approve-contract-upgrade dposV3 -k private_key
+`
+
+func ApproveContractUpgradeCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "approve-contract-upgrade <contract name or address>",
+		Short:   "Approve the pending upgrade proposal of a Go contract (validators only)",
+		Example: approveContractUpgradeCmdExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddr, err := cli.ResolveAddress(args[0], flags.ChainID, flags.URI)
+			if err != nil {
+				return err
+			}
+			req := &ccplugin.ApproveContractUpgradeRequest{
+				ContractAddress: contractAddr.MarshalPB(),
+			}
+			return cli.CallContractWithFlags(&flags, chainConfigContractName, "ApproveContractUpgrade", req, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const listContractUpgradeProposalsCmdExample = `
+loom chain-cfg list-contract-upgrade-proposals
+`
+
+func ListContractUpgradeProposalsCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "list-contract-upgrade-proposals",
+		Short:   "Show all the contract upgrade proposals that haven't been scheduled yet",
+		Example: listContractUpgradeProposalsCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resp ccplugin.ListContractUpgradeProposalsResponse
+			err := cli.StaticCallContractWithFlags(
+				&flags, chainConfigContractName,
+				"ListContractUpgradeProposals", &ccplugin.ListContractUpgradeProposalsRequest{}, &resp,
+			)
+			if err != nil {
+				return err
+			}
+			out, err := formatJSON(&resp)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
 // Utils
 
 func formatJSON(pb proto.Message) (string, error) {
@@ -8,6 +8,7 @@ import (
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv2"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
 	"github.com/loomnetwork/loomchain/builtin/plugins/ethcoin"
+	"github.com/loomnetwork/loomchain/builtin/plugins/governance"
 	"github.com/loomnetwork/loomchain/builtin/plugins/karma"
 	"github.com/loomnetwork/loomchain/builtin/plugins/plasma_cash"
 	"github.com/loomnetwork/loomchain/builtin/plugins/sample_go_contract"
@@ -50,6 +51,9 @@ func NewDefaultContractsLoader(cfg *config.Config) plugin.Loader {
 	if cfg.UserDeployerWhitelist.ContractEnabled {
 		contracts = append(contracts, user_deployer_whitelist.Contract)
 	}
+	if cfg.Governance.ContractEnabled {
+		contracts = append(contracts, governance.Contract)
+	}
 
 	if cfg.AddressMapperContractEnabled() {
 		contracts = append(contracts, address_mapper.Contract)
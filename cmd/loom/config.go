@@ -16,6 +16,7 @@ import (
 	"github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv2"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/builtin/plugins/governance"
 	"github.com/loomnetwork/loomchain/builtin/plugins/karma"
 	"github.com/loomnetwork/loomchain/config"
 	"github.com/loomnetwork/loomchain/features"
@@ -288,6 +289,21 @@ func defaultGenesis(cfg *config.Config, validator *loom.Validator) (*config.Gene
 		})
 	}
 
+	if cfg.Governance.ContractEnabled {
+		governanceInit, err := marshalInit(&governance.InitRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		contracts = append(contracts, config.ContractConfig{
+			VMTypeName: "plugin",
+			Format:     "plugin",
+			Name:       "governance",
+			Location:   "governance:1.0.0",
+			Init:       governanceInit,
+		})
+	}
+
 	if cfg.Karma.Enabled {
 		karmaInitRequest := ktypes.KarmaInitRequest{
 			Sources: []*ktypes.KarmaSourceReward{
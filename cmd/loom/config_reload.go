@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/cmd/loom/common"
+	"github.com/loomnetwork/loomchain/config"
+	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/rpc"
+	"github.com/loomnetwork/loomchain/store"
+	"github.com/loomnetwork/loomchain/throttle"
+)
+
+// configReloader re-reads loom.yml when the node receives a SIGHUP, or when a reload is requested
+// via the unsafe RPC, and applies any changes to the settings that don't affect consensus to the
+// running node. Changes to any other settings are reported, but only take effect after a restart.
+type configReloader struct {
+	mutex sync.Mutex
+	// Config as it was when the node started.
+	startupCfg *config.Config
+	// Config as it was when it was last applied.
+	appliedCfg *config.Config
+
+	txLimiter            *loomchain.SwappableTxMiddleware
+	contractTxLimiter    *loomchain.SwappableTxMiddleware
+	contractTxLimiterCtx contextFactory
+
+	queryServer *rpc.QueryServer
+	blockStore  *store.SwappableBlockStore
+	rateLimiter *rpc.RateLimiter
+}
+
+var _ rpc.ConfigReloader = &configReloader{}
+
+// newConfigReloader creates a reloader that will compare any changes made to the config file to
+// the given config. The config shouldn't include any values overridden via command line flags.
+func newConfigReloader(cfg *config.Config) *configReloader {
+	return &configReloader{
+		startupCfg: cfg,
+		appliedCfg: cfg,
+	}
+}
+
+// ListenForSignals reloads the config whenever the process receives a SIGHUP.
+func (r *configReloader) ListenForSignals() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			if _, err := r.ReloadConfig(); err != nil {
+				log.Error("Failed to reload config", "err", err)
+			}
+		}
+	}()
+}
+
+// ReloadConfig re-reads & validates the config file, then applies any changes that can be applied
+// to the running node. Nothing is applied if the new config is invalid.
+func (r *configReloader) ReloadConfig() (*config.ConfigChanges, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cfg, err := common.ParseConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse config")
+	}
+	changes := config.CompareConfigs(r.appliedCfg, cfg)
+	// Settings that can't be applied may have been changed by an earlier reload, so they must be
+	// compared to the config the node was started with.
+	changes.RestartRequired = config.CompareConfigs(r.startupCfg, cfg).RestartRequired
+
+	var apply []func()
+
+	if changes.Changed("LoomLogLevel") || changes.Changed("Log.ModuleLevels") {
+		levels, err := log.ParseModuleLevels("*:" + cfg.LoomLogLevel + "," + cfg.Log.ModuleLevels)
+		if err != nil {
+			return nil, err
+		}
+		apply = append(apply, func() {
+			if err := log.SetModuleLevels(levels); err != nil {
+				log.Error("Failed to set log levels", "err", err)
+			}
+		})
+	}
+
+	if changes.Changed("TxLimiter") && r.txLimiter != nil {
+		var txLimiter loomchain.TxMiddleware
+		if cfg.TxLimiter.Enabled {
+			txLimiter = throttle.NewTxLimiterMiddleware(cfg.TxLimiter)
+		}
+		apply = append(apply, func() { r.txLimiter.Swap(txLimiter) })
+	}
+
+	if changes.Changed("ContractTxLimiter") && r.contractTxLimiter != nil {
+		var contractTxLimiter loomchain.TxMiddleware
+		if cfg.ContractTxLimiter.Enabled {
+			contractTxLimiter = throttle.NewContractTxLimiterMiddleware(
+				cfg.ContractTxLimiter, r.contractTxLimiterCtx,
+			)
+		}
+		apply = append(apply, func() { r.contractTxLimiter.Swap(contractTxLimiter) })
+	}
+
+	if changes.Changed("BlockStore") && r.blockStore != nil {
+		blockStore, err := store.NewBlockStore(cfg.BlockStore)
+		if err != nil {
+			return nil, err
+		}
+		apply = append(apply, func() { r.blockStore.Swap(blockStore) })
+	}
+
+	if changes.Changed("Web3") && r.queryServer != nil {
+		gasPriceProvider, err := rpc.NewGasPriceProvider(
+			cfg.Web3.GasPrice, r.queryServer.BlockStore, r.queryServer.StateProvider,
+			r.queryServer.CreateChainConfigCtx,
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Only some of the Web3 settings can be reloaded, the rest must remain unchanged.
+		web3Cfg := *r.startupCfg.Web3
+		web3Cfg.GetLogsMaxBlockRange = cfg.Web3.GetLogsMaxBlockRange
+		web3Cfg.GasPrice = cfg.Web3.GasPrice
+		apply = append(apply, func() { r.queryServer.SetWeb3Config(&web3Cfg, gasPriceProvider) })
+	}
+
+	if changes.Changed("RPCRateLimit") && r.rateLimiter != nil {
+		apply = append(apply, func() {
+			r.rateLimiter.Reload(cfg.RPCRateLimit)
+		})
+	}
+
+	for _, fn := range apply {
+		fn()
+	}
+	r.appliedCfg = cfg
+
+	log.Info(
+		"Reloaded config",
+		"applied", changes.Reloadable,
+		"restartRequired", changes.RestartRequired,
+	)
+	return changes, nil
+}
@@ -3,6 +3,7 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -24,6 +25,7 @@ import (
 	"github.com/loomnetwork/loomchain/receipts"
 	registry "github.com/loomnetwork/loomchain/registry/factory"
 	"github.com/loomnetwork/loomchain/store"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	abci "github.com/tendermint/tendermint/abci/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -376,3 +378,40 @@ func newGetEvmHeightCommand() *cobra.Command {
 	}
 	return cmd
 }
+
+// exportEvmState writes all the EVM state trie nodes, storage trie nodes, and contract code
+// reachable from the given EVM state root to the snapshot.
+func exportEvmState(evmDB cdb.DBWrapper, version int64, root []byte, w *store.StateSnapshotWriter) error {
+	evmStore := store.NewEvmStore(evmDB, 100)
+	if err := evmStore.LoadVersion(version); err != nil {
+		return err
+	}
+	if bytes.Equal(root, []byte{1}) {
+		// default root indicates the EVM state is empty
+		return nil
+	}
+
+	storeTx := store.WrapAtomic(evmStore).BeginTx()
+	state := loomchain.NewStoreState(context.Background(), storeTx, abci.Header{Height: version}, nil, nil)
+	stateDB, err := gstate.New(gcommon.BytesToHash(root), gstate.NewDatabase(evm.NewLoomEthdb(state, nil)))
+	if err != nil {
+		return err
+	}
+
+	it := gstate.NewNodeIterator(stateDB)
+	for it.Next() {
+		// nodes that are embedded in their parents don't have a hash
+		if it.Hash == (gcommon.Hash{}) {
+			continue
+		}
+		key := store.EvmStoreKey(it.Hash.Bytes())
+		value := evmDB.Get(key)
+		if value == nil {
+			return errors.Errorf("EVM state node %s not found", it.Hash.Hex())
+		}
+		if err := w.Write(store.SnapshotEvmDB, key, value); err != nil {
+			return err
+		}
+	}
+	return it.Error
+}
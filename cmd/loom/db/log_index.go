@@ -0,0 +1,85 @@
+// +build evm
+
+package db
+
+import (
+	"fmt"
+
+	"github.com/loomnetwork/go-loom/plugin/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/loomnetwork/loomchain/receipts/leveldb"
+	evmaux "github.com/loomnetwork/loomchain/store/evm_aux"
+)
+
+func newIndexEvmLogsCommand() *cobra.Command {
+	var fromHeight uint64
+	cmd := &cobra.Command{
+		Use:   "index-evm-logs",
+		Short: "Builds the EVM log index for blocks that were committed before the index was introduced",
+		Long: `Builds the EVM log index for all the blocks from the given height up to the start of the
+existing index. Only txs whose receipts are still in receipts_db can be indexed, older logs
+can't be returned by eth_getLogs anyway. Must be run from the node directory while the node
+is stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromHeight == 0 {
+				return errors.New("from height must be greater than zero")
+			}
+
+			evmAuxStore, err := evmaux.LoadStore()
+			if err != nil {
+				return errors.Wrap(err, "failed to load EVM aux store")
+			}
+			defer evmAuxStore.Close()
+
+			indexStart, err := evmAuxStore.GetLogIndexStart()
+			if err != nil {
+				return err
+			}
+			if indexStart == 0 {
+				return errors.New("the node hasn't indexed any blocks yet, start the node first")
+			}
+			if fromHeight >= indexStart {
+				fmt.Printf("Log index already starts at height %d\n", indexStart)
+				return nil
+			}
+
+			receipts := leveldb.NewLevelDbReceipts(evmAuxStore, 0)
+			numIndexed := 0
+			for height := fromHeight; height < indexStart; height++ {
+				txHashes, err := evmAuxStore.GetTxHashList(height)
+				if err != nil {
+					return errors.Wrapf(err, "failed to load tx hashes at height %d", height)
+				}
+				blockReceipts := make([]*types.EvmTxReceipt, 0, len(txHashes))
+				for _, txHash := range txHashes {
+					receipt, err := receipts.GetReceipt(txHash)
+					if err != nil {
+						// receipt has been pruned from the DB
+						continue
+					}
+					blockReceipts = append(blockReceipts, &receipt)
+				}
+				if err := evmAuxStore.IndexBlockLogs(height, blockReceipts); err != nil {
+					return errors.Wrapf(err, "failed to index logs at height %d", height)
+				}
+				numIndexed += len(blockReceipts)
+				if (height-fromHeight+1)%10000 == 0 {
+					fmt.Printf("Indexed blocks up to height %d\n", height)
+				}
+			}
+
+			if err := evmAuxStore.SetLogIndexStart(fromHeight); err != nil {
+				return err
+			}
+			fmt.Printf(
+				"Indexed logs from %d txs in blocks %d-%d, log index now starts at height %d\n",
+				numIndexed, fromHeight, indexStart-1, fromHeight,
+			)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64Var(&fromHeight, "from", 1, "Height of the first block to index")
+	return cmd
+}
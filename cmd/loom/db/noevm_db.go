@@ -3,7 +3,11 @@
 package db
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+
+	cdb "github.com/loomnetwork/loomchain/db"
+	"github.com/loomnetwork/loomchain/store"
 )
 
 func NewDBCommand() *cobra.Command {
@@ -14,6 +18,11 @@ func NewDBCommand() *cobra.Command {
 	cmd.AddCommand(
 		newPruneDBCommand(),
 		newCompactDBCommand(),
+		newSnapshotCommand(),
 	)
 	return cmd
 }
+
+func exportEvmState(_ cdb.DBWrapper, _ int64, _ []byte, _ *store.StateSnapshotWriter) error {
+	return errors.New("EVM state can't be exported in a non-EVM build")
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/loomnetwork/loomchain/cmd/loom/common"
+	"github.com/loomnetwork/loomchain/config"
+	cdb "github.com/loomnetwork/loomchain/db"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+func newSnapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export & import snapshots of the app state",
+	}
+	cmd.AddCommand(
+		newExportSnapshotCommand(),
+		newImportSnapshotCommand(),
+	)
+	return cmd
+}
+
+func newExportSnapshotCommand() *cobra.Command {
+	var height int64
+	var outDir string
+	var chunkSize int
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Exports the app state at the given height to a directory of chunked, hashed files",
+		Example: "loom db snapshot export --height 12345 --out ./snapshot-12345",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := common.ParseConfig()
+			if err != nil {
+				return err
+			}
+			if height <= 0 {
+				return errors.New("height must be specified")
+			}
+			if outDir == "" {
+				outDir = fmt.Sprintf("snapshot-%d", height)
+			}
+
+			appDB, err := loadSnapshotAppDB(cfg)
+			if err != nil {
+				return err
+			}
+			defer appDB.Close()
+
+			w, err := store.NewStateSnapshotWriter(outDir, chunkSize)
+			if err != nil {
+				return err
+			}
+			appHash, err := store.ExportIAVLStoreSnapshot(appDB, height, w)
+			if err != nil {
+				return err
+			}
+			tmSnapshot, err := exportTendermintSnapshot(cfg, height, appHash)
+			if err != nil {
+				return err
+			}
+			tmState, err := tmCdc.MarshalBinaryBare(tmSnapshot)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal Tendermint state")
+			}
+			if err := w.WriteTendermintState(tmState); err != nil {
+				return err
+			}
+
+			var evmRoot []byte
+			if cfg.AppStore.Version == 3 {
+				evmDB, err := loadSnapshotEvmDB(cfg)
+				if err != nil {
+					return err
+				}
+				defer evmDB.Close()
+
+				evmRoot, err = store.ExportEvmStoreRoot(evmDB, height)
+				if err != nil {
+					return err
+				}
+				if err := exportEvmState(evmDB, height, evmRoot, w); err != nil {
+					return errors.Wrap(err, "failed to export EVM state")
+				}
+			}
+
+			manifest, err := w.Finish(height, appHash, evmRoot)
+			if err != nil {
+				return err
+			}
+			fmt.Printf(
+				"Exported app state at height %d to %s in %d chunks, app hash: %X\n",
+				height, outDir, len(manifest.Chunks), appHash,
+			)
+			return nil
+		},
+	}
+	cmdFlags := cmd.Flags()
+	cmdFlags.Int64Var(&height, "height", 0, "Height of the app state to export")
+	cmdFlags.StringVar(&outDir, "out", "", "Directory to write the snapshot to (defaults to ./snapshot-<height>)")
+	cmdFlags.IntVar(&chunkSize, "chunk-size", store.DefaultStateSnapshotChunkSize, "Max size of each chunk (in bytes)")
+	cmd.MarkFlagRequired("height")
+	return cmd
+}
+
+func newImportSnapshotCommand() *cobra.Command {
+	var appHashHex string
+	cmd := &cobra.Command{
+		Use:   "import <path/to/snapshot>",
+		Short: "Rebuilds app.db (and evm.db) & the Tendermint state from a snapshot",
+		Long: "Rebuilds app.db (and evm.db if the snapshot contains EVM state) from a snapshot, and verifies\n" +
+			"the rebuilt state matches the trusted app hash, which must be obtained from a source other\n" +
+			"than the snapshot (e.g. the header of the block following the snapshot height on a node you\n" +
+			"trust). The snapshot must also contain the header of that block signed by the validators in\n" +
+			"the snapshot. The Tendermint block store & state are then initialized at the snapshot height,\n" +
+			"so the node can be started from it. None of the databases may exist yet.",
+		Example: "loom db snapshot import ./snapshot-12345 --app-hash 6F0A...",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := common.ParseConfig()
+			if err != nil {
+				return err
+			}
+
+			// The validator signatures in the snapshot are checked against the validator set in the
+			// snapshot itself, so without an independently obtained app hash anyone could produce
+			// a snapshot that passes verification.
+			if appHashHex == "" {
+				return errors.New("trusted app hash must be specified")
+			}
+			appHash, err := hex.DecodeString(strings.TrimPrefix(appHashHex, "0x"))
+			if err != nil {
+				return errors.Wrap(err, "invalid app hash")
+			}
+			if len(appHash) == 0 {
+				return errors.New("trusted app hash must be specified")
+			}
+
+			manifest, err := store.ReadStateSnapshotManifest(args[0])
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(appHash, manifest.AppHash) {
+				return errors.Errorf(
+					"snapshot app hash %X doesn't match expected app hash %X", manifest.AppHash, appHash,
+				)
+			}
+			tmState, err := store.ReadStateSnapshotTendermintState(args[0], manifest)
+			if err != nil {
+				return err
+			}
+			var tmSnapshot tendermintSnapshot
+			if err := tmCdc.UnmarshalBinaryBare(tmState, &tmSnapshot); err != nil {
+				return errors.Wrap(err, "failed to unmarshal Tendermint state")
+			}
+			if err := tmSnapshot.verify(manifest.Height, manifest.AppHash); err != nil {
+				return errors.Wrap(err, "failed to verify snapshot")
+			}
+			blockStoreDB, stateDB := openTendermintDBs(cfg)
+			err = checkTendermintDBsEmpty(blockStoreDB, stateDB)
+			blockStoreDB.Close()
+			stateDB.Close()
+			if err != nil {
+				return err
+			}
+
+			appDB, err := loadSnapshotAppDB(cfg)
+			if err != nil {
+				return err
+			}
+			defer appDB.Close()
+
+			var evmDB cdb.DBWrapper
+			if manifest.EvmRoot != nil {
+				evmDB, err = loadSnapshotEvmDB(cfg)
+				if err != nil {
+					return err
+				}
+				defer evmDB.Close()
+			}
+
+			if _, err := store.ImportStateSnapshot(args[0], appDB, evmDB, manifest.AppHash); err != nil {
+				return err
+			}
+			if err := importTendermintSnapshot(cfg, &tmSnapshot); err != nil {
+				return errors.Wrap(err, "failed to import Tendermint state")
+			}
+			fmt.Printf("Imported app state at height %d, app hash: %X\n", manifest.Height, manifest.AppHash)
+			return nil
+		},
+	}
+	cmdFlags := cmd.Flags()
+	cmdFlags.StringVar(&appHashHex, "app-hash", "", "Trusted app hash (hex) the imported state must match")
+	cmd.MarkFlagRequired("app-hash")
+	return cmd
+}
+
+func loadSnapshotAppDB(cfg *config.Config) (cdb.DBWrapper, error) {
+	return cdb.LoadDB(
+		cfg.DBBackend, cfg.DBName, cfg.RootPath(),
+		cfg.DBBackendConfig.CacheSizeMegs, cfg.DBBackendConfig.WriteBufferMegs, false,
+	)
+}
+
+func loadSnapshotEvmDB(cfg *config.Config) (cdb.DBWrapper, error) {
+	return cdb.LoadDB(
+		cfg.EvmStore.DBBackend, cfg.EvmStore.DBName, cfg.RootPath(),
+		cfg.EvmStore.CacheSizeMegs, cfg.EvmStore.WriteBufferMegs, false,
+	)
+}
@@ -0,0 +1,244 @@
+package db
+
+import (
+	"bytes"
+	"path"
+
+	"github.com/pkg/errors"
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/blockchain"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	sm "github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+
+	"github.com/loomnetwork/loomchain/config"
+)
+
+var tmCdc = amino.NewCodec()
+
+func init() {
+	tmtypes.RegisterBlockAmino(tmCdc)
+}
+
+// tendermintSnapshot contains the Tendermint block & state a node needs to start from a snapshot
+// of the app state at the height of Block, and the header & commit of the following block, which
+// attest to the app hash of the snapshot.
+type tendermintSnapshot struct {
+	Block       *tmtypes.Block
+	BlockCommit *tmtypes.Commit
+	NextHeader  tmtypes.Header
+	NextCommit  *tmtypes.Commit
+	State       sm.State
+}
+
+func openTendermintDBs(cfg *config.Config) (blockStoreDB dbm.DB, stateDB dbm.DB) {
+	dataDir := path.Join(cfg.RootPath(), "chaindata", "data")
+	return dbm.NewDB("blockstore", "leveldb", dataDir), dbm.NewDB("state", "leveldb", dataDir)
+}
+
+// exportTendermintSnapshot loads the Tendermint block & state at the given height, and checks the
+// given app hash matches the app hash in the header of the block following the given height.
+func exportTendermintSnapshot(cfg *config.Config, height int64, appHash []byte) (*tendermintSnapshot, error) {
+	blockStoreDB, stateDB := openTendermintDBs(cfg)
+	defer blockStoreDB.Close()
+	defer stateDB.Close()
+
+	blockStore := blockchain.NewBlockStore(blockStoreDB)
+	// The app hash of the state at the given height is in the header of the following block, so
+	// the snapshot can't be verified until that block has been committed.
+	if blockStore.Height() <= height {
+		return nil, errors.Errorf(
+			"block %d not found in block store, the latest block is %d", height+1, blockStore.Height(),
+		)
+	}
+	block := blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, errors.Errorf("block %d not found in block store", height)
+	}
+	blockCommit := blockStore.LoadBlockCommit(height)
+	if blockCommit == nil {
+		return nil, errors.Errorf("commit for block %d not found in block store", height)
+	}
+	nextMeta := blockStore.LoadBlockMeta(height + 1)
+	if nextMeta == nil {
+		return nil, errors.Errorf("block %d not found in block store", height+1)
+	}
+	nextCommit := blockStore.LoadBlockCommit(height + 1)
+	if nextCommit == nil {
+		nextCommit = blockStore.LoadSeenCommit(height + 1)
+	}
+	if nextCommit == nil {
+		return nil, errors.Errorf("commit for block %d not found in block store", height+1)
+	}
+	if !bytes.Equal(nextMeta.Header.AppHash, appHash) {
+		return nil, errors.Errorf(
+			"app hash %X doesn't match app hash %X in block %d", appHash, nextMeta.Header.AppHash, height+1,
+		)
+	}
+
+	lastValidators, err := sm.LoadValidators(stateDB, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load validators at height %d", height)
+	}
+	validators, err := sm.LoadValidators(stateDB, height+1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load validators at height %d", height+1)
+	}
+	nextValidators, err := sm.LoadValidators(stateDB, height+2)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load validators at height %d", height+2)
+	}
+	consensusParams, err := sm.LoadConsensusParams(stateDB, height+1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load consensus params at height %d", height+1)
+	}
+
+	snap := &tendermintSnapshot{
+		Block:       block,
+		BlockCommit: blockCommit,
+		NextHeader:  nextMeta.Header,
+		NextCommit:  nextCommit,
+		// This is the state Tendermint had after committing the block at the given height.
+		State: sm.State{
+			Version: sm.Version{
+				Consensus: nextMeta.Header.Version,
+				Software:  version.TMCoreSemVer,
+			},
+			ChainID:          block.ChainID,
+			LastBlockHeight:  height,
+			LastBlockTotalTx: block.TotalTxs,
+			LastBlockID:      nextMeta.Header.LastBlockID,
+			LastBlockTime:    block.Time,
+			NextValidators:   nextValidators,
+			Validators:       validators,
+			LastValidators:   lastValidators,
+			// The validator sets & consensus params at earlier heights aren't in the snapshot, so
+			// the imported state must not refer to them.
+			LastHeightValidatorsChanged:      height + 2,
+			ConsensusParams:                  consensusParams,
+			LastHeightConsensusParamsChanged: height + 1,
+			LastResultsHash:                  nextMeta.Header.LastResultsHash,
+			AppHash:                          nextMeta.Header.AppHash,
+		},
+	}
+	if err := snap.verify(height, appHash); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// verify checks that the header of the block following the snapshot height has been signed by
+// the validators in the snapshot, and that the app hash in that header matches the given app
+// hash, and that the rest of the snapshot matches that header.
+func (s *tendermintSnapshot) verify(height int64, appHash []byte) error {
+	state := s.State
+	if s.Block == nil || s.Block.Height != height || state.LastBlockHeight != height {
+		return errors.Errorf("Tendermint state isn't at height %d", height)
+	}
+	if state.LastValidators == nil || state.Validators == nil || state.NextValidators == nil {
+		return errors.New("Tendermint state is missing validators")
+	}
+	if s.NextHeader.Height != height+1 {
+		return errors.Errorf("expected header of block %d, got %d", height+1, s.NextHeader.Height)
+	}
+	if s.NextHeader.ChainID != state.ChainID || s.Block.ChainID != state.ChainID {
+		return errors.New("chain ID mismatch")
+	}
+	if !bytes.Equal(s.NextHeader.AppHash, appHash) || !bytes.Equal(state.AppHash, appHash) {
+		return errors.Errorf(
+			"app hash %X doesn't match app hash %X in block %d", appHash, s.NextHeader.AppHash, height+1,
+		)
+	}
+
+	// the header of the next block must've been committed by the validators in the snapshot...
+	if s.NextCommit == nil || !bytes.Equal(s.NextCommit.BlockID.Hash, s.NextHeader.Hash()) {
+		return errors.Errorf("commit doesn't match block %d", height+1)
+	}
+	if !bytes.Equal(state.Validators.Hash(), s.NextHeader.ValidatorsHash) {
+		return errors.Errorf("validators don't match block %d", height+1)
+	}
+	if err := state.Validators.VerifyCommit(
+		state.ChainID, s.NextCommit.BlockID, height+1, s.NextCommit,
+	); err != nil {
+		return errors.Wrapf(err, "invalid commit for block %d", height+1)
+	}
+
+	// ...and the rest of the snapshot must match that header
+	if !bytes.Equal(state.NextValidators.Hash(), s.NextHeader.NextValidatorsHash) {
+		return errors.Errorf("next validators don't match block %d", height+1)
+	}
+	if !bytes.Equal(state.ConsensusParams.Hash(), s.NextHeader.ConsensusHash) {
+		return errors.Errorf("consensus params don't match block %d", height+1)
+	}
+	if !bytes.Equal(state.LastResultsHash, s.NextHeader.LastResultsHash) {
+		return errors.Errorf("last results hash doesn't match block %d", height+1)
+	}
+	if !state.LastBlockID.Equals(s.NextHeader.LastBlockID) ||
+		!bytes.Equal(s.Block.Hash(), state.LastBlockID.Hash) {
+		return errors.Errorf("block %d doesn't match block %d", height, height+1)
+	}
+	parts := s.Block.MakePartSet(tmtypes.BlockPartSizeBytes)
+	if !parts.Header().Equals(state.LastBlockID.PartsHeader) {
+		return errors.Errorf("block %d parts don't match block %d", height, height+1)
+	}
+	if !bytes.Equal(state.LastValidators.Hash(), s.Block.ValidatorsHash) {
+		return errors.Errorf("validators don't match block %d", height)
+	}
+	if s.BlockCommit == nil {
+		return errors.Errorf("commit for block %d is missing", height)
+	}
+	if err := state.LastValidators.VerifyCommit(
+		state.ChainID, state.LastBlockID, height, s.BlockCommit,
+	); err != nil {
+		return errors.Wrapf(err, "invalid commit for block %d", height)
+	}
+	return nil
+}
+
+// importTendermintSnapshot writes the block & state from the snapshot to the Tendermint block store
+// & state DBs, so that the node can be started from the snapshot height. Neither DB may contain
+// any blocks or state yet.
+func importTendermintSnapshot(cfg *config.Config, snap *tendermintSnapshot) error {
+	blockStoreDB, stateDB := openTendermintDBs(cfg)
+	defer blockStoreDB.Close()
+	defer stateDB.Close()
+
+	if err := checkTendermintDBsEmpty(blockStoreDB, stateDB); err != nil {
+		return err
+	}
+
+	height := snap.State.LastBlockHeight
+	// The block store only accepts contiguous blocks, so it has to be told it already contains
+	// the blocks that precede the snapshot.
+	blockchain.BlockStoreStateJSON{Height: height - 1}.Save(blockStoreDB)
+	blockStore := blockchain.NewBlockStore(blockStoreDB)
+	blockStore.SaveBlock(snap.Block, snap.Block.MakePartSet(tmtypes.BlockPartSizeBytes), snap.BlockCommit)
+
+	// SaveState only stores the validator set for the height after the next one (and the consensus
+	// params for the next height), so earlier states are saved first to store the full validator sets
+	// for the snapshot height & the one after, so they can be loaded without any earlier sets.
+	for _, h := range []int64{height, height + 1} {
+		prevState := snap.State.Copy()
+		prevState.LastBlockHeight = h - 2
+		prevState.NextValidators = snap.State.LastValidators
+		if h == height+1 {
+			prevState.NextValidators = snap.State.Validators
+		}
+		prevState.LastHeightValidatorsChanged = h
+		prevState.LastHeightConsensusParamsChanged = h - 1
+		sm.SaveState(stateDB, prevState)
+	}
+	sm.SaveState(stateDB, snap.State)
+	return nil
+}
+
+func checkTendermintDBsEmpty(blockStoreDB, stateDB dbm.DB) error {
+	if blockchain.LoadBlockStoreStateJSON(blockStoreDB).Height != 0 {
+		return errors.New("Tendermint block store isn't empty")
+	}
+	if !sm.LoadState(stateDB).IsEmpty() {
+		return errors.New("Tendermint state isn't empty")
+	}
+	return nil
+}
@@ -260,7 +260,7 @@ func newDeleteAppHeightCommand() *cobra.Command {
 }
 
 // NewDebugCommand creates a new instance of the top-level debug command
-func NewDebugCommand() *cobra.Command {
+func NewDebugCommand(loadApp AppLoader) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "debug <command>",
 		Short: "Node Debugging Tools",
@@ -272,6 +272,7 @@ func NewDebugCommand() *cobra.Command {
 		newSetAppHeightCommand(),
 		newGetAppHeightCommand(),
 		newDeleteAppHeightCommand(),
+		newReplayBlockCommand(loadApp),
 	)
 	return cmd
 }
@@ -0,0 +1,362 @@
+package dbg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/blockchain"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	sm "github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/cmd/loom/common"
+	"github.com/loomnetwork/loomchain/config"
+	cdb "github.com/loomnetwork/loomchain/db"
+	"github.com/loomnetwork/loomchain/events"
+	"github.com/loomnetwork/loomchain/registry"
+	registryFac "github.com/loomnetwork/loomchain/registry/factory"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+// AppLoader loads the app from the node data directory specified in the config, the app store
+// is loaded at the given height. The node's databases must be opened in read-only mode, so the
+// returned app can't be used to run a node.
+type AppLoader func(cfg *config.Config, appHeight int64) (*loomchain.Application, error)
+
+var evmKeyPrefix = []byte("vm\x00")
+
+func newReplayBlockCommand(loadApp AppLoader) *cobra.Command {
+	var rootDir, outFile, compareFile string
+	var showValues bool
+	cmd := &cobra.Command{
+		Use:   "replay-block <height>",
+		Short: "Re-executes the txs in a block and displays the state changes made by each tx",
+		Long: "Loads the app state at the block preceding the given block, re-executes all the txs in the\n" +
+			"block, and displays all the keys written by each tx. Nothing is written to the node's\n" +
+			"databases. The output doesn't depend on the node it was generated on, so the output from\n" +
+			"two nodes can be compared to find the first tx that produced a different result.",
+		Example: "loom debug replay-block 12345 --out node1.txt\n" +
+			"loom debug replay-block 12345 --root /path/to/node2 --compare node1.txt",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid block height")
+			}
+			if height < 2 {
+				return errors.New("block height must be greater than 1")
+			}
+			cfg, err := common.ParseConfig()
+			if err != nil {
+				return err
+			}
+			if rootDir != "" {
+				cfg.RootDir = rootDir
+			}
+
+			var out bytes.Buffer
+			if err := replayBlock(cfg, loadApp, height, showValues, &out); err != nil {
+				return err
+			}
+
+			if outFile != "" {
+				if err := ioutil.WriteFile(outFile, out.Bytes(), 0644); err != nil {
+					return errors.Wrap(err, "failed to write output")
+				}
+			} else {
+				if _, err := io.Copy(cmd.OutOrStdout(), &out); err != nil {
+					return err
+				}
+			}
+
+			if compareFile != "" {
+				other, err := ioutil.ReadFile(compareFile)
+				if err != nil {
+					return errors.Wrap(err, "failed to read output to compare with")
+				}
+				return compareReplayOutput(cmd.OutOrStdout(), out.Bytes(), other)
+			}
+			return nil
+		},
+	}
+	cmdFlags := cmd.Flags()
+	cmdFlags.StringVar(&rootDir, "root", "", "Root directory of the node to load the data from")
+	cmdFlags.StringVar(&outFile, "out", "", "File to write the output to, instead of stdout")
+	cmdFlags.StringVar(&compareFile, "compare", "", "Output of a previous replay of the same block to compare with")
+	cmdFlags.BoolVar(&showValues, "values", false, "Display the values written, instead of their hashes")
+	return cmd
+}
+
+func replayBlock(cfg *config.Config, loadApp AppLoader, height int64, showValues bool, out io.Writer) error {
+	dataDir := path.Join(cfg.RootPath(), "chaindata", "data")
+	blockStoreDB, err := cdb.LoadReadOnlyGoLevelDB("blockstore", dataDir, 0)
+	if err != nil {
+		return errors.Wrap(err, "failed to open block store")
+	}
+	defer blockStoreDB.Close()
+	stateDB, err := cdb.LoadReadOnlyGoLevelDB("state", dataDir, 0)
+	if err != nil {
+		return errors.Wrap(err, "failed to open state DB")
+	}
+	defer stateDB.Close()
+
+	block := blockchain.NewBlockStore(blockStoreDB).LoadBlock(height)
+	if block == nil {
+		return fmt.Errorf("block %d not found in block store", height)
+	}
+	req, err := newBeginBlockRequest(stateDB, block)
+	if err != nil {
+		return err
+	}
+
+	// Events & receipts generated while replaying the block must not be persisted.
+	cfg.EventDispatcher.Dispatcher = events.DispatcherLog
+	cfg.LogStateDB = false
+	app, err := loadApp(cfg, height-1)
+	if err != nil {
+		return errors.Wrap(err, "failed to load app")
+	}
+	if app.Store.Version() != height-1 {
+		return fmt.Errorf("failed to load app state at height %d", height-1)
+	}
+	app.ReceiptHandlerProvider = &replayReceiptHandlerProvider{app.ReceiptHandlerProvider}
+	recordingStore := store.NewRecordingStore(app.Store)
+	app.Store = recordingStore
+
+	type section struct {
+		title  string
+		writes []store.KVWrite
+	}
+	var sections []section
+
+	app.BeginBlock(req)
+	sections = append(sections, section{title: "begin-block", writes: recordingStore.TakeWrites()})
+	for i, tx := range block.Txs {
+		r := app.DeliverTx(tx)
+		title := fmt.Sprintf("tx %d %X code=%d", i, tx.Hash(), r.Code)
+		if r.Log != "" {
+			title += " log=" + strconv.Quote(r.Log)
+		}
+		sections = append(sections, section{title: title, writes: recordingStore.TakeWrites()})
+	}
+	app.EndBlock(abci.RequestEndBlock{Height: height})
+	sections = append(sections, section{title: "end-block", writes: recordingStore.TakeWrites()})
+
+	createRegistry, err := newRegistryFactory(cfg)
+	if err != nil {
+		return err
+	}
+	state := loomchain.NewStoreState(context.Background(), app.Store, req.Header, nil, nil)
+	decoder := &keyDecoder{
+		chainID:   block.ChainID,
+		registry:  createRegistry(state),
+		contracts: map[string]string{},
+	}
+
+	fmt.Fprintf(out, "block %d txs=%d\n", height, len(block.Txs))
+	for _, s := range sections {
+		fmt.Fprintf(out, "%s writes=%d digest=%X\n", s.title, len(s.writes), writesDigest(s.writes))
+		for _, w := range s.writes {
+			key := decoder.decode(w.Key)
+			if w.Deleted {
+				fmt.Fprintf(out, "  del %s\n", key)
+			} else if showValues {
+				fmt.Fprintf(out, "  set %s = 0x%X\n", key, w.Value)
+			} else {
+				valueHash := sha256.Sum256(w.Value)
+				fmt.Fprintf(out, "  set %s (%d bytes, sha256 %X)\n", key, len(w.Value), valueHash[:8])
+			}
+		}
+	}
+	return nil
+}
+
+// newBeginBlockRequest reconstructs the BeginBlock request Tendermint sent to the app when the
+// block was originally executed.
+func newBeginBlockRequest(stateDB dbm.DB, block *tmtypes.Block) (abci.RequestBeginBlock, error) {
+	lastValSet, err := sm.LoadValidators(stateDB, block.Height-1)
+	if err != nil {
+		return abci.RequestBeginBlock{}, errors.Wrapf(err, "failed to load validators at height %d", block.Height-1)
+	}
+	voteInfos := make([]abci.VoteInfo, lastValSet.Size())
+	for i, val := range lastValSet.Validators {
+		var vote *tmtypes.Vote
+		if i < len(block.LastCommit.Precommits) {
+			vote = block.LastCommit.Precommits[i]
+		}
+		voteInfos[i] = abci.VoteInfo{
+			Validator:       tmtypes.TM2PB.Validator(val),
+			SignedLastBlock: vote != nil,
+		}
+	}
+
+	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		valSet, err := sm.LoadValidators(stateDB, ev.Height())
+		if err != nil {
+			return abci.RequestBeginBlock{}, errors.Wrapf(err, "failed to load validators at height %d", ev.Height())
+		}
+		byzVals[i] = tmtypes.TM2PB.Evidence(ev, valSet, block.Time)
+	}
+
+	return abci.RequestBeginBlock{
+		Hash:   block.Hash(),
+		Header: tmtypes.TM2PB.Header(&block.Header),
+		LastCommitInfo: abci.LastCommitInfo{
+			Round: int32(block.LastCommit.Round()),
+			Votes: voteInfos,
+		},
+		ByzantineValidators: byzVals,
+	}, nil
+}
+
+func newRegistryFactory(cfg *config.Config) (registryFac.RegistryFactoryFunc, error) {
+	regVer, err := registryFac.RegistryVersionFromInt(cfg.RegistryVersion)
+	if err != nil {
+		return nil, err
+	}
+	return registryFac.NewRegistryFactory(regVer)
+}
+
+func writesDigest(writes []store.KVWrite) []byte {
+	h := sha256.New()
+	for _, w := range writes {
+		if w.Deleted {
+			h.Write([]byte{0})
+		} else {
+			h.Write([]byte{1})
+		}
+		fmt.Fprintf(h, "%d:%X:%d:%X", len(w.Key), w.Key, len(w.Value), w.Value)
+	}
+	return h.Sum(nil)[:8]
+}
+
+// keyDecoder converts app store keys to a human readable form, keys in the storage space of Go
+// contracts are prefixed with the contract name, and EVM state keys are prefixed with "evm".
+type keyDecoder struct {
+	chainID   string
+	registry  registry.Registry
+	contracts map[string]string // local address -> contract name
+}
+
+func (d *keyDecoder) decode(key []byte) string {
+	if bytes.HasPrefix(key, evmKeyPrefix) {
+		return "evm:" + formatKeyParts(key[len(evmKeyPrefix):])
+	}
+
+	dataPrefix := loom.DataPrefix(loom.Address{ChainID: d.chainID, Local: make(loom.LocalAddress, 20)})
+	dataPrefix = dataPrefix[:len(dataPrefix)-20]
+	if bytes.HasPrefix(key, dataPrefix) && len(key) >= len(dataPrefix)+20 {
+		local := loom.LocalAddress(key[len(dataPrefix) : len(dataPrefix)+20])
+		rest := bytes.TrimPrefix(key[len(dataPrefix)+20:], []byte{0})
+		return "contract:" + d.contractName(local) + ":" + formatKeyParts(rest)
+	}
+	return formatKeyParts(key)
+}
+
+func (d *keyDecoder) contractName(local loom.LocalAddress) string {
+	if name, ok := d.contracts[string(local)]; ok {
+		return name
+	}
+	addr := loom.Address{ChainID: d.chainID, Local: local}
+	name := addr.String()
+	if record, err := d.registry.GetRecord(addr); err == nil && record.Name != "" {
+		name = record.Name
+	}
+	d.contracts[string(local)] = name
+	return name
+}
+
+// formatKeyParts splits a key into the parts joined by util.PrefixKey, printable parts are
+// displayed as is, everything else is hex encoded.
+func formatKeyParts(key []byte) string {
+	parts := bytes.Split(key, []byte{0})
+	strs := make([]string, len(parts))
+	for i, part := range parts {
+		if isPrintable(part) {
+			strs[i] = string(part)
+		} else {
+			strs[i] = fmt.Sprintf("0x%X", part)
+		}
+	}
+	return strings.Join(strs, "/")
+}
+
+func isPrintable(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e || c == '/' {
+			return false
+		}
+	}
+	return true
+}
+
+// Max length of a line in the replay output, values written by a tx (e.g. contract code) are
+// printed on a single line, so lines can be much longer than the default bufio.Scanner limit.
+const maxReplayOutputLineSize = 64 * 1024 * 1024
+
+// compareReplayOutput reports the first tx that wrote different keys or values in the two outputs.
+func compareReplayOutput(w io.Writer, a, b []byte) error {
+	linesA := bufio.NewScanner(bytes.NewReader(a))
+	linesA.Buffer(nil, maxReplayOutputLineSize)
+	linesB := bufio.NewScanner(bytes.NewReader(b))
+	linesB.Buffer(nil, maxReplayOutputLineSize)
+	var section string
+	for lineNum := 1; ; lineNum++ {
+		okA, okB := linesA.Scan(), linesB.Scan()
+		if err := linesA.Err(); err != nil {
+			return errors.Wrapf(err, "failed to read line %d of the output from this node", lineNum)
+		}
+		if err := linesB.Err(); err != nil {
+			return errors.Wrapf(err, "failed to read line %d of the output from the other node", lineNum)
+		}
+		if !okA && !okB {
+			fmt.Fprintln(w, "No differences found")
+			return nil
+		}
+		lineA, lineB := linesA.Text(), linesB.Text()
+		if lineA != lineB {
+			return fmt.Errorf(
+				"outputs differ at line %d in section %q\n  this node:  %s\n  other node: %s",
+				lineNum, section, lineA, lineB,
+			)
+		}
+		if !strings.HasPrefix(lineA, "  ") {
+			section = lineA
+		}
+	}
+}
+
+// replayReceiptHandlerProvider prevents the receipts generated while replaying a block from
+// overwriting the receipts that were stored when the block was originally executed.
+type replayReceiptHandlerProvider struct {
+	loomchain.ReceiptHandlerProvider
+}
+
+func (p *replayReceiptHandlerProvider) Store() loomchain.ReceiptHandlerStore {
+	return &replayReceiptHandlerStore{p.ReceiptHandlerProvider.Store()}
+}
+
+type replayReceiptHandlerStore struct {
+	loomchain.ReceiptHandlerStore
+}
+
+func (s *replayReceiptHandlerStore) CommitBlock(height int64) error {
+	return nil
+}
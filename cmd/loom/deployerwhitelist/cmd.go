@@ -8,6 +8,7 @@ import (
 	dwtypes "github.com/loomnetwork/go-loom/builtin/types/deployer_whitelist"
 	"github.com/loomnetwork/go-loom/cli"
 	dw "github.com/loomnetwork/loomchain/builtin/plugins/deployer_whitelist"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/spf13/cobra"
 )
 
@@ -155,10 +156,13 @@ func getDeployerCmd() *cobra.Command {
 
 const listDeployersCmdExample = `
 loom deployer list
+loom deployer list --limit 100 --cursor <next cursor of the previous page>
 `
 
 func listDeployersCmd() *cobra.Command {
 	var flags cli.ContractCallFlags
+	var cursor string
+	var limit uint64
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "Display all deployers in whitelist",
@@ -166,30 +170,56 @@ func listDeployersCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
+			if limit > 0 || cursor != "" {
+				req := &pagination.PageRequest{Cursor: cursor, Limit: limit}
+				var resp dw.ListDeployersPageResponse
+				err := cli.StaticCallContractWithFlags(&flags, dwContractName, "ListDeployersPage", req, &resp)
+				if err != nil {
+					return err
+				}
+				return printJSON(&deployersPage{
+					Deployers:  getDeployersInfo(resp.Deployers),
+					NextCursor: resp.NextCursor,
+				})
+			}
+
 			req := &dwtypes.ListDeployersRequest{}
 			var resp dwtypes.ListDeployersResponse
 			if err := cli.StaticCallContractWithFlags(&flags, dwContractName, "ListDeployers", req, &resp); err != nil {
 				return err
 			}
-
-			deployersInfo := []*deployerInfo{}
-			for _, deployer := range resp.Deployers {
-				deployerInfo := getDeployerInfo(deployer)
-				deployersInfo = append(deployersInfo, &deployerInfo)
-			}
-
-			output, err := json.MarshalIndent(deployersInfo, "", "  ")
-			if err != nil {
-				return err
-			}
-			fmt.Println(string(output))
-			return nil
+			return printJSON(getDeployersInfo(resp.Deployers))
 		},
 	}
 	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Only list deployers after this cursor (the next cursor of the previous page)")
+	cmd.Flags().Uint64Var(&limit, "limit", 0, "Maximum number of deployers to list")
 	return cmd
 }
 
+type deployersPage struct {
+	Deployers  []*deployerInfo
+	NextCursor string
+}
+
+func getDeployersInfo(deployers []*dwtypes.Deployer) []*deployerInfo {
+	deployersInfo := []*deployerInfo{}
+	for _, deployer := range deployers {
+		deployerInfo := getDeployerInfo(deployer)
+		deployersInfo = append(deployersInfo, &deployerInfo)
+	}
+	return deployersInfo
+}
+
+func printJSON(v interface{}) error {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
 func getDeployerInfo(deployer *dwtypes.Deployer) deployerInfo {
 	flagsInt := dw.UnpackFlags(deployer.Flags)
 	flags := []string{}
@@ -10,10 +10,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	loom "github.com/loomnetwork/go-loom"
 	"github.com/loomnetwork/go-loom/builtin/types/dposv3"
 	"github.com/loomnetwork/go-loom/cli"
 	"github.com/loomnetwork/go-loom/types"
+	dposv3plugin "github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/builtin/plugins/pagination"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -151,23 +154,36 @@ func ListValidatorsCmdV3() *cobra.Command {
 
 const listCandidateCmdExample = `
 loom dpos3 list-candidates
+loom dpos3 list-candidates --limit 50 --cursor <next cursor of the previous page>
 `
 
 func ListCandidatesCmdV3() *cobra.Command {
 	var flags cli.ContractCallFlags
+	var cursor string
+	var limit uint64
 	cmd := &cobra.Command{
 		Use:     "list-candidates",
 		Short:   "List the registered candidates",
 		Example: listCandidateCmdExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var resp dposv3.ListCandidatesResponse
-			err := cli.StaticCallContractWithFlags(
-				&flags, DPOSV3ContractName, "ListCandidates", &dposv3.ListCandidatesRequest{}, &resp,
-			)
+			var resp proto.Message
+			var err error
+			if limit > 0 || cursor != "" {
+				resp = &dposv3plugin.ListCandidatesPageResponse{}
+				err = cli.StaticCallContractWithFlags(
+					&flags, DPOSV3ContractName, "ListCandidatesPage",
+					&pagination.PageRequest{Cursor: cursor, Limit: limit}, resp,
+				)
+			} else {
+				resp = &dposv3.ListCandidatesResponse{}
+				err = cli.StaticCallContractWithFlags(
+					&flags, DPOSV3ContractName, "ListCandidates", &dposv3.ListCandidatesRequest{}, resp,
+				)
+			}
 			if err != nil {
 				return err
 			}
-			out, err := formatJSON(&resp)
+			out, err := formatJSON(resp)
 			if err != nil {
 				return err
 			}
@@ -176,6 +192,8 @@ func ListCandidatesCmdV3() *cobra.Command {
 		},
 	}
 	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Only list candidates after this cursor (the next cursor of the previous page)")
+	cmd.Flags().Uint64Var(&limit, "limit", 0, "Maximum number of candidates to list")
 	return cmd
 }
 
@@ -323,10 +341,12 @@ func RegisterCandidateCmdV3() *cobra.Command {
 
 const updateCandidateCmdExample = `
 loom dpos3 update-candidate-info candidate_name candidate_description candidate.com 1000 --key path/to/private_key
+loom dpos3 update-candidate-info candidate_name candidate_description candidate.com 1000 --operator operator_name --key path/to/private_key
 `
 
 func UpdateCandidateInfoCmdV3() *cobra.Command {
 	var flags cli.ContractCallFlags
+	var operator string
 	cmd := &cobra.Command{
 		Use:     "update-candidate-info [name] [description] [website] [maximum referral percentage]",
 		Short:   "Update candidate information for a validator",
@@ -349,7 +369,7 @@ func UpdateCandidateInfoCmdV3() *cobra.Command {
 				maxReferralPercentage = percentage
 			}
 
-			return cli.CallContractWithFlags(
+			err := cli.CallContractWithFlags(
 				&flags, DPOSV3ContractName, "UpdateCandidateInfo", &dposv3.UpdateCandidateInfoRequest{
 					Name:                  candidateName,
 					Description:           candidateDescription,
@@ -357,9 +377,21 @@ func UpdateCandidateInfoCmdV3() *cobra.Command {
 					MaxReferralPercentage: maxReferralPercentage,
 				}, nil,
 			)
+			if err != nil || !cmd.Flags().Changed("operator") {
+				return err
+			}
+			return cli.CallContractWithFlags(
+				&flags, DPOSV3ContractName, "UpdateCandidateOperator", &dposv3plugin.UpdateCandidateOperatorRequest{
+					Operator: operator,
+				}, nil,
+			)
 		},
 	}
 	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(
+		&operator, "operator", "",
+		"Name of the entity operating the candidate, validators with the same operator are subject to the entity power cap",
+	)
 	return cmd
 }
 
@@ -1183,25 +1215,38 @@ func ListDelegationsCmdV3() *cobra.Command {
 
 const listAllDelegationsCmdExample = `
 loom dpos3 list-all-delegations -u http://localhost:12345
+loom dpos3 list-all-delegations --limit 500 --cursor <next cursor of the previous page>
 `
 
 func ListAllDelegationsCmdV3() *cobra.Command {
 	var flags cli.ContractCallFlags
+	var cursor string
+	var limit uint64
 	cmd := &cobra.Command{
 		Use:     "list-all-delegations",
 		Short:   "display the results of calling list_delegations for all candidates",
 		Example: listAllDelegationsCmdExample,
 		Args:    cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var resp dposv3.ListAllDelegationsResponse
-			err := cli.StaticCallContractWithFlags(
-				&flags, DPOSV3ContractName, "ListAllDelegations",
-				&dposv3.ListAllDelegationsRequest{}, &resp,
-			)
+			var resp proto.Message
+			var err error
+			if limit > 0 || cursor != "" {
+				resp = &dposv3plugin.ListAllDelegationsPageResponse{}
+				err = cli.StaticCallContractWithFlags(
+					&flags, DPOSV3ContractName, "ListAllDelegationsPage",
+					&pagination.PageRequest{Cursor: cursor, Limit: limit}, resp,
+				)
+			} else {
+				resp = &dposv3.ListAllDelegationsResponse{}
+				err = cli.StaticCallContractWithFlags(
+					&flags, DPOSV3ContractName, "ListAllDelegations",
+					&dposv3.ListAllDelegationsRequest{}, resp,
+				)
+			}
 			if err != nil {
 				return err
 			}
-			out, err := formatJSON(&resp)
+			out, err := formatJSON(resp)
 			if err != nil {
 				return err
 			}
@@ -1210,6 +1255,8 @@ func ListAllDelegationsCmdV3() *cobra.Command {
 		},
 	}
 	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Only list delegations after this cursor (the next cursor of the previous page)")
+	cmd.Flags().Uint64Var(&limit, "limit", 0, "Maximum number of delegations to list")
 	return cmd
 }
 
@@ -1596,6 +1643,326 @@ func IgnoreUnbondLocktimeCmd() *cobra.Command {
 	return cmd
 }
 
+const setPowerCapCmdExample = `
+loom dpos3 set-power-cap 2800 --key path/to/private_key
+`
+
+func SetPowerCapCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "set-power-cap [power cap]",
+		Short:   "Set maximum voting power of a single validator expressed in basis points",
+		Example: setPowerCapCmdExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			powerCap, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			if powerCap > 10000 {
+				// nolint:lll
+				return errors.New("powerCap is expressed in basis points (hundredths of a percent) and must be between 10000 (100%) and 0 (default).")
+			}
+
+			return cli.CallContractWithFlags(
+				&flags, DPOSV3ContractName, "SetPowerCap", &dposv3plugin.SetPowerCapRequest{
+					PowerCap: powerCap,
+				}, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const setMinSelfStakeCmdExample = `
+loom dpos3 set-min-self-stake 1250000 --key path/to/private_key
+`
+
+func SetMinSelfStakeCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "set-min-self-stake [min self-stake]",
+		Short:   "Set minimum amount a candidate must stake on itself to be elected",
+		Example: setMinSelfStakeCmdExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minSelfStake, err := cli.ParseAmount(args[0])
+			if err != nil {
+				return err
+			}
+
+			return cli.CallContractWithFlags(
+				&flags, DPOSV3ContractName, "SetMinSelfStake", &dposv3plugin.SetMinSelfStakeRequest{
+					MinSelfStake: &types.BigUInt{
+						Value: *minSelfStake,
+					},
+				}, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const setEntityPowerCapCmdExample = `
+loom dpos3 set-entity-power-cap 3300 --key path/to/private_key
+`
+
+func SetEntityPowerCapCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "set-entity-power-cap [entity power cap]",
+		Short:   "Set maximum voting power of all the validators run by one operator expressed in basis points",
+		Example: setEntityPowerCapCmdExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entityPowerCap, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			if entityPowerCap > 10000 {
+				// nolint:lll
+				return errors.New("entityPowerCap is expressed in basis points (hundredths of a percent) and must be between 10000 (100%) and 0 (disabled).")
+			}
+
+			return cli.CallContractWithFlags(
+				&flags, DPOSV3ContractName, "SetEntityPowerCap", &dposv3plugin.SetEntityPowerCapRequest{
+					EntityPowerCap: entityPowerCap,
+				}, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const getElectionParamsCmdExample = `
+loom dpos3 get-election-params
+`
+
+func GetElectionParamsCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "get-election-params",
+		Short:   "Gets the power cap, min self-stake, and entity power cap used in elections",
+		Example: getElectionParamsCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resp dposv3plugin.GetElectionParamsResponse
+			err := cli.StaticCallContractWithFlags(
+				&flags, DPOSV3ContractName, "GetElectionParams", &dposv3plugin.GetElectionParamsRequest{}, &resp,
+			)
+			if err != nil {
+				return err
+			}
+			out, err := formatJSON(&resp)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const listCandidateOperatorsCmdExample = `
+loom dpos3 list-candidate-operators
+`
+
+func ListCandidateOperatorsCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "list-candidate-operators",
+		Short:   "List the operators candidates have linked themselves to",
+		Example: listCandidateOperatorsCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resp dposv3plugin.ListCandidateOperatorsResponse
+			err := cli.StaticCallContractWithFlags(
+				&flags, DPOSV3ContractName, "ListCandidateOperators", &dposv3plugin.ListCandidateOperatorsRequest{}, &resp,
+			)
+			if err != nil {
+				return err
+			}
+			out, err := formatJSON(&resp)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const setAutoCompoundCmdExample = `
+loom dpos3 set-auto-compound true --key path/to/private_key
+loom dpos3 set-auto-compound false --validator 0x7262d4c97c7B93937E4810D289b7320e9dA82857 --key path/to/private_key
+`
+
+func SetAutoCompoundCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var validator string
+	cmd := &cobra.Command{
+		Use:     "set-auto-compound [true|false]",
+		Short:   "Enable or disable compounding of the rewards earned by the caller's delegations",
+		Example: setAutoCompoundCmdExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			enabled, err := strconv.ParseBool(args[0])
+			if err != nil {
+				return err
+			}
+
+			req := &dposv3plugin.SetAutoCompoundRequest{
+				Enabled: enabled,
+			}
+			if validator != "" {
+				addr, err := cli.ParseAddress(validator, flags.ChainID)
+				if err != nil {
+					return err
+				}
+				req.ValidatorAddress = addr.MarshalPB()
+			}
+			return cli.CallContractWithFlags(&flags, DPOSV3ContractName, "SetAutoCompound", req, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(
+		&validator, "validator", "", "Only apply the setting to delegations to this validator",
+	)
+	return cmd
+}
+
+const getAutoCompoundSettingsCmdExample = `
+loom dpos3 get-auto-compound-settings 0x62666100f8988238d81831dc543D098572F283A1
+`
+
+func GetAutoCompoundSettingsCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "get-auto-compound-settings [delegator]",
+		Short:   "Display a delegator's auto-compound settings",
+		Example: getAutoCompoundSettingsCmdExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := cli.ResolveAccountAddress(args[0], &flags)
+			if err != nil {
+				return err
+			}
+
+			var resp dposv3plugin.GetAutoCompoundSettingsResponse
+			err = cli.StaticCallContractWithFlags(
+				&flags, DPOSV3ContractName, "GetAutoCompoundSettings",
+				&dposv3plugin.GetAutoCompoundSettingsRequest{DelegatorAddress: addr.MarshalPB()}, &resp,
+			)
+			if err != nil {
+				return err
+			}
+			out, err := formatJSON(&resp)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const listSlashesCmdExample = `
+loom dpos3 list-slashes --validator 0x7262d4c97c7B93937E4810D289b7320e9dA82857 --limit 10
+`
+
+func ListSlashesCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var validator string
+	var startAfter, limit uint64
+	cmd := &cobra.Command{
+		Use:     "list-slashes",
+		Short:   "List the slashes recorded in the slash ledger",
+		Example: listSlashesCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &dposv3plugin.ListSlashesRequest{
+				StartAfter: startAfter,
+				Limit:      limit,
+			}
+			if validator != "" {
+				addr, err := cli.ParseAddress(validator, flags.ChainID)
+				if err != nil {
+					return err
+				}
+				req.ValidatorAddress = addr.MarshalPB()
+			}
+
+			var resp dposv3plugin.ListSlashesResponse
+			err := cli.StaticCallContractWithFlags(&flags, DPOSV3ContractName, "ListSlashes", req, &resp)
+			if err != nil {
+				return err
+			}
+			out, err := formatJSON(&resp)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmdFlags := cmd.Flags()
+	cmdFlags.StringVar(&validator, "validator", "", "Only list the slashes of this validator")
+	cmdFlags.Uint64Var(&startAfter, "start-after", 0, "Only list slashes with a greater ID (the next cursor of the previous page)")
+	cmdFlags.Uint64Var(&limit, "limit", 0, "Maximum number of slashes to list")
+	return cmd
+}
+
+const getSlashesForDelegatorCmdExample = `
+loom dpos3 get-slashes-for-delegator 0x62666100f8988238d81831dc543D098572F283A1
+`
+
+func GetSlashesForDelegatorCmdV3() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var startAfter, limit uint64
+	cmd := &cobra.Command{
+		Use:     "get-slashes-for-delegator [delegator]",
+		Short:   "List the slashes that affected a delegator's delegations",
+		Example: getSlashesForDelegatorCmdExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := cli.ResolveAccountAddress(args[0], &flags)
+			if err != nil {
+				return err
+			}
+
+			var resp dposv3plugin.GetSlashesForDelegatorResponse
+			err = cli.StaticCallContractWithFlags(
+				&flags, DPOSV3ContractName, "GetSlashesForDelegator",
+				&dposv3plugin.GetSlashesForDelegatorRequest{
+					DelegatorAddress: addr.MarshalPB(),
+					StartAfter:       startAfter,
+					Limit:            limit,
+				}, &resp,
+			)
+			if err != nil {
+				return err
+			}
+			out, err := formatJSON(&resp)
+			if err != nil {
+				return err
+			}
+			fmt.Println(out)
+			return nil
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmdFlags := cmd.Flags()
+	cmdFlags.Uint64Var(&startAfter, "start-after", 0, "Only list slashes with a greater ID (the next cursor of the previous page)")
+	cmdFlags.Uint64Var(&limit, "limit", 0, "Maximum number of slashes to list")
+	return cmd
+}
+
 func NewDPOSV3Command() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "dpos3 <command>",
@@ -1644,6 +2011,15 @@ func NewDPOSV3Command() *cobra.Command {
 		UnjailValidatorCmdV3(),
 		EnableValidatorJailingCmd(),
 		IgnoreUnbondLocktimeCmd(),
+		SetPowerCapCmdV3(),
+		SetMinSelfStakeCmdV3(),
+		SetEntityPowerCapCmdV3(),
+		GetElectionParamsCmdV3(),
+		ListCandidateOperatorsCmdV3(),
+		SetAutoCompoundCmdV3(),
+		GetAutoCompoundSettingsCmdV3(),
+		ListSlashesCmdV3(),
+		GetSlashesForDelegatorCmdV3(),
 	)
 	return cmd
 }
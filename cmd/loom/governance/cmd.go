@@ -0,0 +1,279 @@
+package governance
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom/cli"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	gov "github.com/loomnetwork/loomchain/builtin/plugins/governance"
+)
+
+var (
+	governanceContractName = "governance"
+)
+
+func NewGovernanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "governance <command>",
+		Short: "Governance CLI",
+	}
+	cmd.AddCommand(
+		submitParamChangeCmd(),
+		submitFeatureActivationCmd(),
+		submitContractUpgradeCmd(),
+		voteCmd(),
+		getProposalCmd(),
+		listProposalsCmd(),
+		getParamsCmd(),
+	)
+	return cmd
+}
+
+const submitParamChangeCmdExample = `
+loom governance submit-param-change dposV3 SetPowerCap SetPowerCapRequest '{"powerCap": "2500"}' \
+  --description "Lower the validator power cap to 25%"
+`
+
+func submitParamChangeCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var description string
+	cmd := &cobra.Command{
+		Use:     "submit-param-change <contract name> <method> <request type> <request JSON>",
+		Short:   "Submit a proposal to call a method on a Go contract",
+		Example: submitParamChangeCmdExample,
+		Args:    cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqType := proto.MessageType(args[2])
+			if reqType == nil {
+				return fmt.Errorf("unknown request type %s", args[2])
+			}
+			// MessageType returns the pointer type of the registered message
+			methodReq, ok := reflect.New(reqType.Elem()).Interface().(proto.Message)
+			if !ok {
+				return fmt.Errorf("%s is not a protobuf message", args[2])
+			}
+			if err := jsonpb.UnmarshalString(args[3], methodReq); err != nil {
+				return errors.Wrap(err, "failed to parse request JSON")
+			}
+			methodArgs, err := proto.Marshal(methodReq)
+			if err != nil {
+				return err
+			}
+			return submitProposal(&flags, &gov.SubmitProposalRequest{
+				Description: description,
+				ParamChange: &gov.ParamChange{
+					ContractName: args[0],
+					Method:       args[1],
+					Args:         methodArgs,
+				},
+			})
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&description, "description", "", "Description of the proposal")
+	return cmd
+}
+
+const submitFeatureActivationCmdExample = `
+loom governance submit-feature-activation dpos:v3.11 --description "Enable configurable election params"
+`
+
+func submitFeatureActivationCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var description string
+	cmd := &cobra.Command{
+		Use:     "submit-feature-activation <feature name>",
+		Short:   "Submit a proposal to enable a feature",
+		Example: submitFeatureActivationCmdExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if args[0] == "" {
+				return fmt.Errorf("Invalid feature name")
+			}
+			return submitProposal(&flags, &gov.SubmitProposalRequest{
+				Description:       description,
+				FeatureActivation: &gov.FeatureActivation{FeatureName: args[0]},
+			})
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().StringVar(&description, "description", "", "Description of the proposal")
+	return cmd
+}
+
+const submitContractUpgradeCmdExample = `
+loom governance submit-contract-upgrade dposV3 dposV3:3.1.0 --height 1500000
+`
+
+func submitContractUpgradeCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var description string
+	var blockHeight int64
+	cmd := &cobra.Command{
+		Use:     "submit-contract-upgrade <contract name> <new plugin name:version>",
+		Short:   "Submit a proposal to switch a Go contract to a different version of its code",
+		Example: submitContractUpgradeCmdExample,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitProposal(&flags, &gov.SubmitProposalRequest{
+				Description: description,
+				ContractUpgrade: &gov.ContractUpgrade{
+					ContractName: args[0],
+					PluginName:   args[1],
+					BlockHeight:  blockHeight,
+				},
+			})
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	cmdFlags := cmd.Flags()
+	cmdFlags.StringVar(&description, "description", "", "Description of the proposal")
+	cmdFlags.Int64Var(&blockHeight, "height", 0, "Block height at which the upgrade should take effect")
+	cmd.MarkFlagRequired("height")
+	return cmd
+}
+
+const voteCmdExample = `
+loom governance vote 1 yes
+`
+
+func voteCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "vote <proposal id> <yes|no>",
+		Short:   "Vote for or against a proposal, votes are weighted by the caller's delegated stake",
+		Example: voteCmdExample,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid proposal id")
+			}
+			var approve bool
+			switch strings.ToLower(args[1]) {
+			case "yes":
+				approve = true
+			case "no":
+				approve = false
+			default:
+				return fmt.Errorf("vote must be either yes or no")
+			}
+			req := &gov.VoteRequest{
+				ProposalId: id,
+				Approve:    approve,
+			}
+			return cli.CallContractWithFlags(&flags, governanceContractName, "Vote", req, nil)
+		},
+	}
+	cli.AddContractCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const getProposalCmdExample = `
+loom governance get-proposal 1
+`
+
+func getProposalCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "get-proposal <proposal id>",
+		Short:   "Display a proposal and the votes cast on it",
+		Example: getProposalCmdExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid proposal id")
+			}
+			var resp gov.GetProposalResponse
+			err = cli.StaticCallContractWithFlags(&flags, governanceContractName, "GetProposal",
+				&gov.GetProposalRequest{ProposalId: id}, &resp)
+			if err != nil {
+				return err
+			}
+			return printJSON(&resp)
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+const listProposalsCmdExample = `
+loom governance list-proposals --pending
+`
+
+func listProposalsCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	var pendingOnly bool
+	cmd := &cobra.Command{
+		Use:     "list-proposals",
+		Short:   "Display all proposals",
+		Example: listProposalsCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resp gov.ListProposalsResponse
+			err := cli.StaticCallContractWithFlags(&flags, governanceContractName, "ListProposals",
+				&gov.ListProposalsRequest{PendingOnly: pendingOnly}, &resp)
+			if err != nil {
+				return err
+			}
+			return printJSON(&resp)
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	cmd.Flags().BoolVar(&pendingOnly, "pending", false, "Only display proposals that are open for voting")
+	return cmd
+}
+
+const getParamsCmdExample = `
+loom governance get-params
+`
+
+func getParamsCmd() *cobra.Command {
+	var flags cli.ContractCallFlags
+	cmd := &cobra.Command{
+		Use:     "get-params",
+		Short:   "Display the voting period, quorum, pass threshold, and min proposer stake",
+		Example: getParamsCmdExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resp gov.GetParamsResponse
+			err := cli.StaticCallContractWithFlags(&flags, governanceContractName, "GetParams",
+				&gov.GetParamsRequest{}, &resp)
+			if err != nil {
+				return err
+			}
+			return printJSON(&resp)
+		},
+	}
+	cli.AddContractStaticCallFlags(cmd.Flags(), &flags)
+	return cmd
+}
+
+func submitProposal(flags *cli.ContractCallFlags, req *gov.SubmitProposalRequest) error {
+	var resp gov.SubmitProposalResponse
+	err := cli.CallContractWithFlags(flags, governanceContractName, "SubmitProposal", req, &resp)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Submitted proposal %d\n", resp.ProposalId)
+	return nil
+}
+
+func printJSON(pb proto.Message) error {
+	marshaler := jsonpb.Marshaler{
+		Indent:       "  ",
+		EmitDefaults: true,
+	}
+	out, err := marshaler.MarshalToString(pb)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/loomnetwork/loomchain"
 	"github.com/loomnetwork/loomchain/abci/backend"
 	"github.com/loomnetwork/loomchain/auth"
+	"github.com/loomnetwork/loomchain/builtin/plugins/address_mapper"
+	"github.com/loomnetwork/loomchain/builtin/plugins/coin"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv2"
 	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
 	plasmaConfig "github.com/loomnetwork/loomchain/builtin/plugins/plasma_cash/config"
@@ -47,6 +49,7 @@ import (
 	"github.com/loomnetwork/loomchain/cmd/loom/dbg"
 	deployer "github.com/loomnetwork/loomchain/cmd/loom/deployerwhitelist"
 	gatewaycmd "github.com/loomnetwork/loomchain/cmd/loom/gateway"
+	govcmd "github.com/loomnetwork/loomchain/cmd/loom/governance"
 	userdeployer "github.com/loomnetwork/loomchain/cmd/loom/userdeployerwhitelist"
 	"github.com/loomnetwork/loomchain/config"
 	"github.com/loomnetwork/loomchain/core"
@@ -59,6 +62,7 @@ import (
 	"github.com/loomnetwork/loomchain/log"
 	"github.com/loomnetwork/loomchain/migrations"
 	"github.com/loomnetwork/loomchain/plugin"
+	"github.com/loomnetwork/loomchain/plugin/abibridge"
 	"github.com/loomnetwork/loomchain/receipts"
 	"github.com/loomnetwork/loomchain/receipts/handler"
 	regcommon "github.com/loomnetwork/loomchain/registry"
@@ -73,6 +77,8 @@ import (
 	"github.com/pkg/errors"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	ttypes "github.com/tendermint/tendermint/types"
 	"golang.org/x/crypto/ed25519"
 )
 
@@ -332,6 +338,7 @@ func newNodeKeyCommand() *cobra.Command {
 func newRunCommand() *cobra.Command {
 	var abciServerAddr string
 	var appHeight int64
+	var replica bool
 
 	cfg, err := common.ParseConfig()
 	cmd := &cobra.Command{
@@ -341,7 +348,10 @@ func newRunCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			log.Setup(cfg.LoomLogLevel, cfg.LogDestination)
+			if replica {
+				cfg.Replica.Enabled = true
+			}
+			log.SetupWithConfig(cfg.LoomLogLevel, cfg.LogDestination, cfg.Log)
 			logger := log.Default
 			configureGeth(cfg.Geth)
 			if cfg.PrometheusPushGateway.Enabled {
@@ -353,35 +363,33 @@ func newRunCommand() *cobra.Command {
 				go startPushGatewayMonitoring(cfg.PrometheusPushGateway, logger, host)
 			}
 			var fnRegistry fnConsensus.FnRegistry
-			if cfg.FnConsensus.Enabled {
+			if cfg.FnConsensus.Enabled && !cfg.Replica.Enabled {
 				fnRegistry = fnConsensus.NewInMemoryFnRegistry()
 			}
-			var loaders []plugin.Loader
-			for _, loader := range cfg.ContractLoaders {
-				if strings.EqualFold("static", loader) {
-					loaders = append(loaders, common.NewDefaultContractsLoader(cfg))
-				}
-				if strings.EqualFold("dynamic", loader) {
-					loaders = append(loaders, plugin.NewManager(cfg.PluginsPath()))
-				}
-				if strings.EqualFold("external", loader) {
-					loaders = append(loaders, plugin.NewExternalLoader(cfg.PluginsPath()))
-				}
-			}
 			backend := initBackend(cfg, abciServerAddr, fnRegistry)
-			loader := plugin.NewMultiLoader(loaders...)
+			loader := newContractLoader(cfg)
+			shutdown := &shutdownHooks{}
 			termChan := make(chan os.Signal)
 			go func(c <-chan os.Signal, l plugin.Loader) {
 				<-c
+				shutdown.Run()
 				l.UnloadContracts()
 				os.Exit(0)
 			}(termChan, loader)
 
-			signal.Notify(termChan, syscall.SIGHUP,
+			signal.Notify(termChan,
 				syscall.SIGINT,
 				syscall.SIGTERM,
 				syscall.SIGQUIT)
 
+			// The config may have been modified by command line flags, the config reloader needs
+			// the config as it was in the file so it can tell which settings changed.
+			fileCfg, err := common.ParseConfig()
+			if err != nil {
+				return err
+			}
+			reloader := newConfigReloader(fileCfg)
+
 			chainID, err := backend.ChainID()
 			if err != nil {
 				return err
@@ -402,7 +410,12 @@ func newRunCommand() *cobra.Command {
 			}
 			appDB.Close()
 
-			app, err := loadApp(chainID, cfg, loader, backend, appHeight)
+			// The ABI bridge is shared by the tx handlers & the query service.
+			abiBridge, err := newABIBridge()
+			if err != nil {
+				return err
+			}
+			app, err := loadApp(chainID, cfg, loader, backend, appHeight, abiBridge, reloader, shutdown)
 			if err != nil {
 				return err
 			}
@@ -415,9 +428,20 @@ func newRunCommand() *cobra.Command {
 				return err
 			}
 
-			if err := initQueryService(app, chainID, cfg, loader, app.ReceiptHandlerProvider); err != nil {
+			if err := initQueryService(
+				app, chainID, cfg, loader, app.ReceiptHandlerProvider, abiBridge, reloader, shutdown,
+			); err != nil {
 				return err
 			}
+			reloader.ListenForSignals()
+
+			// Replicas only serve queries, so none of the processes that submit txs on behalf of the
+			// node should be started.
+			if cfg.Replica.Enabled {
+				logger.Info("Running in read-only replica mode", "upstream", cfg.Replica.UpstreamURI)
+				backend.RunForever()
+				return nil
+			}
 
 			// If this node is meant to be a custom reactor validator start the gateway reactors.
 			if cfg.FnConsensus.Reactor.IsValidator && fnRegistry != nil {
@@ -443,9 +467,56 @@ func newRunCommand() *cobra.Command {
 	cmd.Flags().StringVar(&cfg.PersistentPeers, "persistent-peers", "", "persistent peers")
 	cmd.Flags().StringVar(&abciServerAddr, "abci-server", "", "Serve ABCI app at specified address")
 	cmd.Flags().Int64Var(&appHeight, "app-height", 0, "Start at the given block instead of the last block saved")
+	cmd.Flags().BoolVar(
+		&replica, "replica", false,
+		"Run as a read-only replica that follows the committed blocks & serves queries, but doesn't accept txs",
+	)
 	return cmd
 }
 
+func newContractLoader(cfg *config.Config) plugin.Loader {
+	var loaders []plugin.Loader
+	for _, loader := range cfg.ContractLoaders {
+		if strings.EqualFold("static", loader) {
+			loaders = append(loaders, common.NewDefaultContractsLoader(cfg))
+		}
+		if strings.EqualFold("dynamic", loader) {
+			loaders = append(loaders, plugin.NewManager(cfg.PluginsPath()))
+		}
+		if strings.EqualFold("external", loader) {
+			loaders = append(loaders, plugin.NewExternalLoader(cfg.PluginsPath()))
+		}
+	}
+	return plugin.NewMultiLoader(loaders...)
+}
+
+// loadReplayApp loads the app without starting the node, the app is used to re-execute blocks
+// that have already been committed.
+func loadReplayApp(cfg *config.Config, appHeight int64) (*loomchain.Application, error) {
+	log.SetupWithConfig(cfg.LoomLogLevel, cfg.LogDestination, cfg.Log)
+	configureGeth(cfg.Geth)
+	backend := initBackend(cfg, "", nil)
+	chainID, err := backend.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	// Replaying a block must not modify the node's databases, so they're opened in read-only mode,
+	// which also prevents the app store from being pruned or compacted.
+	appStore, err := loadReadOnlyAppStore(cfg, appHeight)
+	if err != nil {
+		return nil, err
+	}
+	evmAuxStore, err := evmaux.LoadReadOnlyStore()
+	if err != nil {
+		return nil, err
+	}
+	abiBridge, err := newABIBridge()
+	if err != nil {
+		return nil, err
+	}
+	return newApp(chainID, cfg, newContractLoader(cfg), backend, appStore, evmAuxStore, abiBridge, nil, nil)
+}
+
 const contractInfoCommandExample = `
 loom contract default:0x81ee596ba88eF371a51d4B535E07cB243A8C692d
 `
@@ -682,6 +753,38 @@ func loadAppStore(cfg *config.Config, logger *loom.Logger, targetVersion int64)
 	return appStore, nil
 }
 
+// loadReadOnlyAppStore loads the app store at the given version from DBs opened in read-only mode.
+// The store is never pruned, so it can't be used to run a node.
+func loadReadOnlyAppStore(cfg *config.Config, targetVersion int64) (store.VersionedKVStore, error) {
+	db, err := cdb.LoadReadOnlyDB(cfg.DBBackend, cfg.DBName, cfg.RootPath(), cfg.DBBackendConfig.CacheSizeMegs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open app store")
+	}
+	iavlStore, err := store.NewIAVLStore(db, 0, targetVersion, cfg.AppStore.IAVLFlushInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.AppStore.Version {
+	case 1:
+		return iavlStore, nil
+	case 3:
+		evmDB, err := cdb.LoadReadOnlyDB(
+			cfg.EvmStore.DBBackend, cfg.EvmStore.DBName, cfg.RootPath(), cfg.EvmStore.CacheSizeMegs,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open EVM store")
+		}
+		evmStore := store.NewEvmStore(evmDB, cfg.EvmStore.NumCachedRoots)
+		if err := evmStore.LoadVersion(iavlStore.Version()); err != nil {
+			return nil, err
+		}
+		return store.NewMultiWriterAppStore(iavlStore, evmStore, cfg.AppStore.SaveEVMStateToIAVL)
+	default:
+		return nil, errors.New("Invalid AppStore.Version config setting")
+	}
+}
+
 func loadEventStore(cfg *config.Config, logger *loom.Logger) (store.EventStore, error) {
 	eventStoreCfg := cfg.EventStore
 	db, err := cdb.LoadDB(
@@ -723,14 +826,35 @@ func loadApp(
 	loader plugin.Loader,
 	b backend.Backend,
 	appHeight int64,
+	abiBridge *abibridge.Bridge,
+	reloader *configReloader,
+	shutdown *shutdownHooks,
 ) (*loomchain.Application, error) {
-	logger := log.Root
-
 	appStore, err := loadAppStore(cfg, log.Default, appHeight)
-
 	if err != nil {
 		return nil, err
 	}
+	evmAuxStore, err := evmaux.LoadStore()
+	if err != nil {
+		return nil, err
+	}
+	return newApp(chainID, cfg, loader, b, appStore, evmAuxStore, abiBridge, reloader, shutdown)
+}
+
+// newApp creates the app from the given stores.
+func newApp(
+	chainID string,
+	cfg *config.Config,
+	loader plugin.Loader,
+	b backend.Backend,
+	appStore store.VersionedKVStore,
+	evmAuxStore *evmaux.EvmAuxStore,
+	abiBridge *abibridge.Bridge,
+	reloader *configReloader,
+	shutdown *shutdownHooks,
+) (*loomchain.Application, error) {
+	logger := log.Root
+	var err error
 
 	if !cfg.SkipMinBuildCheck {
 		if buildBytes := appStore.Get([]byte(loomchain.MinBuildKey)); len(buildBytes) > 0 {
@@ -766,6 +890,42 @@ func loadApp(
 	case events.DispatcherLog:
 		logger.Info("Using simple log event dispatcher")
 		eventDispatcher = events.NewLogEventDispatcher()
+	case events.DispatcherStream:
+		streamCfg := cfg.EventDispatcher.Stream
+		if streamCfg == nil {
+			streamCfg = events.DefaultStreamEventDispatcherConfig()
+		}
+		var sink events.StreamEventSink
+		switch streamCfg.Sink {
+		case events.EventSinkRedis:
+			sink = events.NewRedisEventSink(cfg.EventDispatcher.Redis.URI)
+		case events.EventSinkKafka:
+			if streamCfg.Kafka == nil {
+				return nil, errors.New("Kafka event sink isn't configured")
+			}
+			sink = events.NewKafkaEventSink(streamCfg.Kafka)
+		case events.EventSinkNATS:
+			if streamCfg.NATS == nil {
+				return nil, errors.New("NATS event sink isn't configured")
+			}
+			sink = events.NewNATSEventSink(streamCfg.NATS)
+		default:
+			return nil, fmt.Errorf("invalid event sink %s", streamCfg.Sink)
+		}
+		outboxDB, err := cdb.LoadDB(
+			streamCfg.OutboxDBBackend, streamCfg.OutboxDBName, cfg.RootPath(),
+			20, 4, cfg.Metrics.Database,
+		)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Using stream event dispatcher", "sink", streamCfg.Sink)
+		streamDispatcher, err := events.NewStreamEventDispatcher(outboxDB, sink, streamCfg)
+		if err != nil {
+			return nil, err
+		}
+		shutdown.Add(streamDispatcher.Stop)
+		eventDispatcher = streamDispatcher
 	default:
 		return nil, fmt.Errorf("invalid event dispatcher %s", cfg.EventDispatcher.Dispatcher)
 	}
@@ -787,12 +947,6 @@ func loadApp(
 		return nil, err
 	}
 
-	// load EVM Auxiliary Store
-	evmAuxStore, err := evmaux.LoadStore()
-	if err != nil {
-		return nil, err
-	}
-
 	receiptHandlerProvider := receipts.NewReceiptHandlerProvider(eventHandler, cfg.EVMPersistentTxReceiptsMax, evmAuxStore)
 
 	var newABMFactory plugin.NewAccountBalanceManagerFactoryFunc
@@ -807,7 +961,7 @@ func loadApp(
 			state,
 			createRegistry(state),
 			eventHandler,
-			log.Default,
+			log.LoomLogger(state.Context()),
 			newABMFactory,
 			receiptHandlerProvider.Writer(),
 			receiptHandlerProvider.Reader(),
@@ -824,7 +978,7 @@ func loadApp(
 					state,
 					createRegistry(state),
 					eventHandler,
-					log.Default,
+					log.LoomLogger(state.Context()),
 					newABMFactory,
 					receiptHandlerProvider.Writer(),
 					receiptHandlerProvider.Reader(),
@@ -852,6 +1006,8 @@ func loadApp(
 	ethTxHandler := &tx_handler.EthTxHandler{
 		Manager:        vmManager,
 		CreateRegistry: createRegistry,
+		ABIBridge:      abiBridge,
+		ReceiptWriter:  receiptHandlerProvider.Writer(),
 	}
 
 	migrationTxHandler := &tx_handler.MigrationTxHandler{
@@ -947,6 +1103,9 @@ func loadApp(
 		loomchain.LogTxMiddleware,
 		loomchain.RecoveryTxMiddleware,
 	}
+	if cfg.Replica.Enabled {
+		txMiddleWare = append(txMiddleWare, loomchain.ReplicaTxMiddleware)
+	}
 
 	postCommitMiddlewares := []loomchain.PostCommitMiddleware{
 		loomchain.LogPostCommitMiddleware,
@@ -968,15 +1127,25 @@ func loadApp(
 		))
 	}
 
+	// The tx limiters can be enabled, disabled, or reconfigured while the node is running, so they're
+	// always added to the middleware chain.
+	txLimiter := loomchain.NewSwappableTxMiddleware(nil)
 	if cfg.TxLimiter.Enabled {
-		txMiddleWare = append(txMiddleWare, throttle.NewTxLimiterMiddleware(cfg.TxLimiter))
+		txLimiter.Swap(throttle.NewTxLimiterMiddleware(cfg.TxLimiter))
 	}
+	txMiddleWare = append(txMiddleWare, txLimiter)
 
+	contractTxLimiterCtx := getContractCtx("user-deployer-whitelist", vmManager)
+	contractTxLimiter := loomchain.NewSwappableTxMiddleware(nil)
 	if cfg.ContractTxLimiter.Enabled {
-		contextFactory := getContractCtx("user-deployer-whitelist", vmManager)
-		txMiddleWare = append(
-			txMiddleWare, throttle.NewContractTxLimiterMiddleware(cfg.ContractTxLimiter, contextFactory),
-		)
+		contractTxLimiter.Swap(throttle.NewContractTxLimiterMiddleware(cfg.ContractTxLimiter, contractTxLimiterCtx))
+	}
+	txMiddleWare = append(txMiddleWare, contractTxLimiter)
+
+	if reloader != nil {
+		reloader.txLimiter = txLimiter
+		reloader.contractTxLimiter = contractTxLimiter
+		reloader.contractTxLimiterCtx = contractTxLimiterCtx
 	}
 
 	if cfg.DeployerWhitelist.ContractEnabled {
@@ -1046,6 +1215,16 @@ func loadApp(
 	}
 
 	nonceTxHandler := auth.NewNonceHandler()
+	if cfg.Auth.NonceQueue != nil && cfg.Auth.NonceQueue.Enabled && !cfg.Replica.Enabled {
+		nonceQueue := auth.NewNonceQueue(cfg.Auth.NonceQueue)
+		// Released txs are resubmitted asynchronously because they're released while the mempool is
+		// processing CheckTx for the tx that precedes them.
+		nonceQueue.Start(func(txBytes []byte) error {
+			_, err := rpccore.BroadcastTxAsync(ttypes.Tx(txBytes))
+			return err
+		})
+		nonceTxHandler = auth.NewNonceHandlerWithQueue(nonceQueue)
+	}
 	txMiddleWare = append(txMiddleWare, nonceTxHandler.TxMiddleware(appStore))
 
 	if cfg.GoContractDeployerWhitelist.Enabled {
@@ -1093,6 +1272,26 @@ func loadApp(
 		return m, nil
 	}
 
+	createGovernanceManager := func(state loomchain.State) (loomchain.GovernanceManager, error) {
+		if !cfg.Governance.ContractEnabled {
+			return nil, nil
+		}
+		pvm, err := vmManager.InitVM(vm.VMType_PLUGIN, state)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := plugin.NewGovernanceManager(pvm.(*plugin.PluginVM), state)
+		if err != nil {
+			// Proposals won't be executed until the Governance contract is deployed
+			if err == plugin.ErrGovernanceContractNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return m, nil
+	}
+
 	if !cfg.Karma.Enabled && cfg.Karma.UpkeepEnabled {
 		logger.Info("Karma disabled, upkeep enabled ignored")
 	}
@@ -1129,11 +1328,14 @@ func loadApp(
 		ReceiptHandlerProvider:      receiptHandlerProvider,
 		CreateValidatorManager:      createValidatorsManager,
 		CreateChainConfigManager:    createChainConfigManager,
+		CreateGovernanceManager:     createGovernanceManager,
 		CreateContractUpkeepHandler: createContractUpkeepHandler,
 		EventStore:                  eventStore,
 		GetValidatorSet:             getValidatorSet,
 		EvmAuxStore:                 evmAuxStore,
 		ReceiptsVersion:             cfg.ReceiptsVersion,
+		// txs held in the nonce queue are released once the txs preceding them are committed
+		BlockCommitted: nonceTxHandler.ReleaseCommitted,
 	}, nil
 }
 
@@ -1217,6 +1419,7 @@ func initBackend(cfg *config.Config, abciServerAddr string, fnRegistry fnConsens
 		HsmConfig:                cfg.HsmConfig,
 		FnConsensusReactorConfig: cfg.FnConsensus.Reactor,
 		MempoolWalEnabled:        cfg.MempoolWalEnabled,
+		ReplicaMode:              cfg.Replica.Enabled,
 	}
 	return &backend.TendermintBackend{
 		RootPath:    path.Join(cfg.RootPath(), "chaindata"),
@@ -1226,9 +1429,26 @@ func initBackend(cfg *config.Config, abciServerAddr string, fnRegistry fnConsens
 	}
 }
 
+// newABIBridge creates the bridge that exposes built-in Go contracts to Ethereum txs & eth_call.
+func newABIBridge() (*abibridge.Bridge, error) {
+	return abibridge.NewBridge(
+		abibridge.ContractSpec{
+			Name:     "coin",
+			Contract: &coin.Coin{},
+			Events: map[string]proto.Message{
+				coin.TransferEventTopic: &coin.TransferEvent{},
+				coin.ApprovalEventTopic: &coin.ApprovalEvent{},
+			},
+		},
+		abibridge.ContractSpec{Name: "dposV3", Contract: &dposv3.DPOS{}},
+		abibridge.ContractSpec{Name: "addressmapper", Contract: &address_mapper.AddressMapper{}},
+	)
+}
+
 func initQueryService(
 	app *loomchain.Application, chainID string, cfg *config.Config, loader plugin.Loader,
-	receiptHandlerProvider loomchain.ReceiptHandlerProvider,
+	receiptHandlerProvider loomchain.ReceiptHandlerProvider, abiBridge *abibridge.Bridge,
+	reloader *configReloader, shutdown *shutdownHooks,
 ) error {
 	// metrics
 	fieldKeys := []string{"method", "error"}
@@ -1260,7 +1480,25 @@ func initQueryService(
 		newABMFactory = plugin.NewAccountBalanceManagerFactory
 	}
 
-	blockstore, err := store.NewBlockStore(cfg.BlockStore)
+	tmBlockStore, err := store.NewBlockStore(cfg.BlockStore)
+	if err != nil {
+		return err
+	}
+	blockstore := store.NewSwappableBlockStore(tmBlockStore)
+
+	nodeStatusProvider := rpc.NewTendermintNodeStatusProvider()
+	syncStatusMonitor := rpc.NewSyncStatusMonitor(
+		nodeStatusProvider,
+		app.EventHandler.EthSubscriptionSet(),
+		app.EventHandler.LegacyEthSubscriptionSet(),
+		5*time.Second,
+	)
+	syncStatusMonitor.Start()
+	shutdown.Add(syncStatusMonitor.Stop)
+
+	mempoolProvider := rpc.NewTendermintMempoolProvider()
+
+	ethPolls, err := polls.LoadEthSubscriptions(cfg.Web3.FilterStore, cfg.RootPath(), app.EvmAuxStore, blockstore)
 	if err != nil {
 		return err
 	}
@@ -1272,7 +1510,7 @@ func initQueryService(
 		Subscriptions:          app.EventHandler.SubscriptionSet(),
 		EthSubscriptions:       app.EventHandler.EthSubscriptionSet(),
 		EthLegacySubscriptions: app.EventHandler.LegacyEthSubscriptionSet(),
-		EthPolls:               *polls.NewEthSubscriptions(app.EvmAuxStore, blockstore),
+		EthPolls:               *ethPolls,
 		CreateRegistry:         createRegistry,
 		NewABMFactory:          newABMFactory,
 		ReceiptHandlerProvider: receiptHandlerProvider,
@@ -1284,14 +1522,45 @@ func initQueryService(
 		EvmAuxStore:            app.EvmAuxStore,
 		Web3Cfg:                cfg.Web3,
 		DPOSCfg:                cfg.DPOS,
+		NodeStatusProvider:     nodeStatusProvider,
+		ABIBridge:              abiBridge,
+		MempoolProvider:        mempoolProvider,
+	}
+	qs.GasPriceProvider, err = rpc.NewGasPriceProvider(
+		cfg.Web3.GasPrice, blockstore, app, qs.CreateChainConfigCtx,
+	)
+	if err != nil {
+		return err
 	}
+
 	bus := &rpc.QueryEventBus{
 		Subs:    *app.EventHandler.SubscriptionSet(),
 		EthSubs: *app.EventHandler.LegacyEthSubscriptionSet(),
 	}
-	var qsvc rpc.QueryService = rpc.NewInstrumentingMiddleWare(requestCount, requestLatency, qs)
+	var txForwarder *rpc.TxForwarder
+	if cfg.Replica.Enabled && cfg.Replica.UpstreamURI != "" {
+		txForwarder = rpc.NewTxForwarder(cfg.Replica.UpstreamURI)
+	}
+	var qsvc rpc.QueryService = qs
+	var rateLimiter *rpc.RateLimiter
+	if cfg.RPCRateLimit.Enabled {
+		rateLimiter = rpc.NewRateLimiter(cfg.RPCRateLimit)
+	}
+	qsvc = rpc.NewInstrumentingMiddleWare(requestCount, requestLatency, qsvc)
+
+	var configReloader rpc.ConfigReloader
+	if reloader != nil {
+		reloader.queryServer = qs
+		reloader.blockStore = blockstore
+		reloader.rateLimiter = rateLimiter
+		configReloader = reloader
+	}
+
 	logger := log.Root.With("module", "query-server")
-	err = rpc.RPCServer(qsvc, chainID, logger, bus, cfg.RPCBindAddress, cfg.UnsafeRPCEnabled, cfg.UnsafeRPCBindAddress)
+	err = rpc.RPCServer(
+		qsvc, chainID, logger, bus, cfg.RPCBindAddress, cfg.UnsafeRPCEnabled, cfg.UnsafeRPCBindAddress,
+		cfg.Web3.WebSocket, txForwarder, rateLimiter, cfg.UnsafeRPCAuthToken, configReloader,
+	)
 	if err != nil {
 		return err
 	}
@@ -1347,7 +1616,8 @@ func main() {
 		chaincfgcmd.NewChainCfgCommand(),
 		deployer.NewDeployCommand(),
 		userdeployer.NewUserDeployCommand(),
-		dbg.NewDebugCommand(),
+		govcmd.NewGovernanceCommand(),
+		dbg.NewDebugCommand(loadReplayApp),
 		contractInfoCommand(),
 	)
 	err := RootCmd.Execute()
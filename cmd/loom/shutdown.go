@@ -0,0 +1,38 @@
+package main
+
+import (
+	"sync"
+)
+
+// shutdownHooks keeps track of the background services that must be stopped before the node
+// process exits.
+type shutdownHooks struct {
+	mutex sync.Mutex
+	hooks []func()
+}
+
+// Add registers a function that should be called when the node shuts down, it's safe to call
+// this on a nil instance (in which case the function is never called).
+func (h *shutdownHooks) Add(hook func()) {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+// Run calls all the registered functions in the reverse order to which they were registered.
+func (h *shutdownHooks) Run() {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mutex.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/loomnetwork/loomchain/db"
 
 	"github.com/loomnetwork/loomchain/fnConsensus"
+	"github.com/loomnetwork/loomchain/log"
 )
 
 type (
@@ -62,6 +63,9 @@ type Config struct {
 	RPCBindAddress       string
 	UnsafeRPCBindAddress string
 	UnsafeRPCEnabled     bool
+	// If set, requests to the unsafe RPC must carry this token in an "Authorization: Bearer <token>"
+	// header. If not set the unsafe_set_log_level & unsafe_reload_config routes are disabled.
+	UnsafeRPCAuthToken string
 
 	Peers           string
 	PersistentPeers string
@@ -82,6 +86,7 @@ type Config struct {
 	BlockchainLogLevel      string
 	LogStateDB              bool
 	LogEthDbBatch           bool
+	Log                     *log.Config
 	Metrics                 *Metrics
 	SampleGoContractEnabled bool
 
@@ -94,6 +99,9 @@ type Config struct {
 	// UserDeployerWhitelist
 	UserDeployerWhitelist *UserDeployerWhitelistConfig
 
+	// Governance
+	Governance *GovernanceConfig
+
 	// Transfer gateway
 	TransferGateway         *TransferGatewayConfig
 	LoomCoinTransferGateway *TransferGatewayConfig
@@ -138,6 +146,10 @@ type Config struct {
 
 	FnConsensus *FnConsensusConfig
 
+	Replica *ReplicaConfig
+
+	RPCRateLimit *RPCRateLimitConfig
+
 	Auth *auth.Config
 
 	EvmStore *evm.EvmStoreConfig
@@ -184,6 +196,77 @@ func DefaultFnConsensusConfig() *FnConsensusConfig {
 	}
 }
 
+// ReplicaConfig controls the read-only replica mode, in which the node follows the blocks committed
+// by the validators and serves queries, but doesn't accept txs, or run any of the validator
+// processes (ChainConfig routine, FnConsensus reactor, oracles).
+type ReplicaConfig struct {
+	Enabled bool
+	// Tendermint RPC endpoint of the node txs submitted to the replica should be forwarded to,
+	// e.g. http://validator-1:46657. If empty, txs submitted to the replica will be rejected.
+	UpstreamURI string
+}
+
+func DefaultReplicaConfig() *ReplicaConfig {
+	return &ReplicaConfig{
+		Enabled:     false,
+		UpstreamURI: "",
+	}
+}
+
+// RPCRateLimitConfig contains settings that control how many requests clients can make to the
+// /query and /eth endpoints, and how much data a single request can pull out of the node.
+type RPCRateLimitConfig struct {
+	Enabled bool
+	// Name of the HTTP header clients can use to provide an API key. Requests with a valid API key
+	// are subject to the per-API-key limits, all other requests are subject to the per-IP limits.
+	APIKeyHeader string
+	// List of valid API keys.
+	APIKeys []string
+	// If true the client IP is read from the X-Forwarded-For header, this should only be enabled if
+	// the node is behind a reverse proxy that sets the header.
+	TrustForwardedFor bool
+	// Limits for each method, methods that don't have their own entry are subject to the limits of
+	// the "*" entry (if there is one).
+	Methods []*RPCMethodRateLimit
+	// Maximum size (in bytes) of a response, zero means no limit.
+	MaxResponseSize int
+}
+
+// RPCMethodRateLimit specifies how many requests a single client can make to a method.
+type RPCMethodRateLimit struct {
+	// Name of the method (e.g. eth_getLogs, query), or "*" to match any method.
+	Method string
+	// Number of seconds each limiting period lasts
+	Period int64
+	// Maximum number of requests a single IP can make per period, zero means no limit.
+	IPLimit int64
+	// Maximum number of requests a single API key can make per period, zero means no limit.
+	APIKeyLimit int64
+}
+
+func DefaultRPCRateLimitConfig() *RPCRateLimitConfig {
+	return &RPCRateLimitConfig{
+		Enabled:           false,
+		APIKeyHeader:      "X-API-Key",
+		TrustForwardedFor: false,
+		Methods: []*RPCMethodRateLimit{
+			{
+				Method:      "*",
+				Period:      1,
+				IPLimit:     50,
+				APIKeyLimit: 500,
+			},
+			{
+				Method:      "eth_getLogs",
+				Period:      1,
+				IPLimit:     5,
+				APIKeyLimit: 50,
+			},
+		},
+		MaxResponseSize: 10 * 1024 * 1024,
+	}
+}
+
 type DPOSConfig struct {
 	BootstrapNodes           []string
 	TotalStakedCacheDuration int64
@@ -258,6 +341,11 @@ type UserDeployerWhitelistConfig struct {
 	ContractEnabled bool
 }
 
+type GovernanceConfig struct {
+	// Allow deployment of the Governance contract
+	ContractEnabled bool
+}
+
 func DefaultDBBackendConfig() *DBBackendConfig {
 	return &DBBackendConfig{
 		CacheSizeMegs:   1042, //1 Gigabyte
@@ -317,6 +405,12 @@ func DefaultUserDeployerWhitelistConfig() *UserDeployerWhitelistConfig {
 	}
 }
 
+func DefaultGovernanceConfig() *GovernanceConfig {
+	return &GovernanceConfig{
+		ContractEnabled: false,
+	}
+}
+
 //Structure for LOOM ENV
 
 type Env struct {
@@ -360,6 +454,7 @@ func ParseConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	conf.applyDefaults()
 
 	return conf, err
 }
@@ -382,10 +477,19 @@ func ParseConfigFrom(filename string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	conf.applyDefaults()
 
 	return conf, err
 }
 
+// applyDefaults replaces any invalid settings that would break the node at runtime with their
+// default values.
+func (c *Config) applyDefaults() {
+	if c.Web3 != nil {
+		c.Web3.WebSocket = c.Web3.WebSocket.WithDefaults()
+	}
+}
+
 func ReadGenesis(path string) (*Genesis, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -460,6 +564,7 @@ func DefaultConfig() *Config {
 	cfg.ChainConfig = DefaultChainConfigConfig(cfg.RPCProxyPort)
 	cfg.DeployerWhitelist = DefaultDeployerWhitelistConfig()
 	cfg.UserDeployerWhitelist = DefaultUserDeployerWhitelistConfig()
+	cfg.Governance = DefaultGovernanceConfig()
 	cfg.DBBackendConfig = DefaultDBBackendConfig()
 	cfg.PrometheusPushGateway = DefaultPrometheusPushGatewayConfig()
 	cfg.EventDispatcher = events.DefaultEventDispatcherConfig()
@@ -470,6 +575,9 @@ func DefaultConfig() *Config {
 	cfg.DPOS = DefaultDPOSConfig()
 
 	cfg.FnConsensus = DefaultFnConsensusConfig()
+	cfg.Replica = DefaultReplicaConfig()
+	cfg.RPCRateLimit = DefaultRPCRateLimitConfig()
+	cfg.Log = log.DefaultConfig()
 
 	cfg.Auth = auth.DefaultConfig()
 	return cfg
@@ -572,6 +680,7 @@ RPCProxyPort: {{ .RPCProxyPort }}
 RPCBindAddress: "{{ .RPCBindAddress }}"
 UnsafeRPCEnabled: {{ .UnsafeRPCEnabled }}
 UnsafeRPCBindAddress: "{{ .UnsafeRPCBindAddress }}"
+UnsafeRPCAuthToken: "{{ .UnsafeRPCAuthToken }}"
 Peers: "{{ .Peers }}"
 PersistentPeers: "{{ .PersistentPeers }}"
 #
@@ -618,6 +727,16 @@ LoomLogLevel: "{{ .LoomLogLevel }}"
 BlockchainLogLevel: "{{ .BlockchainLogLevel }}"
 LogStateDB: {{ .LogStateDB }}
 LogEthDbBatch: {{ .LogEthDbBatch }}
+{{- if .Log }}
+Log:
+  # Either "fmt" or "json"
+  Format: "{{ .Log.Format }}"
+  # Comma separated list of module:level pairs, e.g. "store:debug,rpc:error"
+  ModuleLevels: "{{ .Log.ModuleLevels }}"
+  # Max size of the log file before it's rotated, zero disables rotation
+  MaxFileSizeMegs: {{ .Log.MaxFileSizeMegs }}
+  MaxBackups: {{ .Log.MaxBackups }}
+{{- end}}
 Metrics:
   BlockIndexStore: {{ .Metrics.BlockIndexStore }} 
   EventHandling: {{ .Metrics.EventHandling }}
@@ -653,6 +772,13 @@ DeployerWhitelist:
 #
 UserDeployerWhitelist:
   ContractEnabled: {{ .UserDeployerWhitelist.ContractEnabled }}
+
+#
+# Governance
+#
+Governance:
+  # Allow deployment of the Governance contract
+  ContractEnabled: {{ .Governance.ContractEnabled }}
 #
 # SampleGoContractEnabled
 #
@@ -780,8 +906,47 @@ EvmStore:
 # Configuration of Web3 JSON-RPC methods served on the /eth endpoint.
 #
 Web3:
-  # Specifies the maximum number of blocks eth_getLogs will query per request
+  # Specifies the maximum number of blocks eth_getLogs, getevmlogs & contractevents will query
+  # per request
   GetLogsMaxBlockRange: {{.Web3.GetLogsMaxBlockRange}}
+  {{- if .Web3.GasPrice}}
+  # Controls the gas price (in wei) returned by eth_gasPrice
+  GasPrice:
+    # "fixed" always returns FixedPrice, "median" returns the median gas price of the txs in the
+    # last NumBlocks blocks (or FixedPrice if none of those txs specify a gas price).
+    Oracle: {{.Web3.GasPrice.Oracle}}
+    FixedPrice: {{.Web3.GasPrice.FixedPrice}}
+    MinPrice: {{.Web3.GasPrice.MinPrice}}
+    NumBlocks: {{.Web3.GasPrice.NumBlocks}}
+  {{- end}}
+  {{- if .Web3.WebSocket}}
+  # Controls how messages are written to websocket clients, each connection has its own bounded
+  # send queue so slow clients can't hold up eth_subscribe notifications to other clients.
+  WebSocket:
+    SendQueueSize: {{.Web3.WebSocket.SendQueueSize}}
+    # Timeouts & intervals are in seconds
+    WriteTimeout: {{.Web3.WebSocket.WriteTimeout}}
+    PingInterval: {{.Web3.WebSocket.PingInterval}}
+    PongTimeout: {{.Web3.WebSocket.PongTimeout}}
+    # "drop" discards messages that don't fit into the send queue, "disconnect" closes the
+    # connection instead.
+    OverflowPolicy: {{.Web3.WebSocket.OverflowPolicy}}
+  {{- end}}
+  {{- if .Web3.FilterStore}}
+  # Controls where filters created via eth_newFilter, eth_newBlockFilter, and
+  # eth_newPendingTransactionFilter are kept. Persistent filters are stored in a local DB, so they
+  # survive node restarts, but they can't be shared with other nodes. Setting RedisURI stores the
+  # filters in a Redis server instead, so all the nodes connected to it share the same filters.
+  FilterStore:
+    Persistent: {{.Web3.FilterStore.Persistent}}
+    # goleveldb | cleveldb | memdb
+    DBBackend: {{.Web3.FilterStore.DBBackend}}
+    DBName: {{.Web3.FilterStore.DBName}}
+    CacheSizeMegs: {{.Web3.FilterStore.CacheSizeMegs}}
+    WriteBufferMegs: {{.Web3.FilterStore.WriteBufferMegs}}
+    RedisURI: "{{.Web3.FilterStore.RedisURI}}"
+    RedisKeyPrefix: "{{.Web3.FilterStore.RedisKeyPrefix}}"
+  {{- end}}
 {{end}}
 
 # 
@@ -805,17 +970,87 @@ FnConsensus:
   {{- end }}
 {{- end }}
 
+#
+# Read-only replica mode, can also be enabled with the --replica flag of the run command.
+#
+{{- if .Replica }}
+Replica:
+  Enabled: {{ .Replica.Enabled }}
+  # Tendermint RPC endpoint of the node txs submitted to the replica should be forwarded to,
+  # if empty txs submitted to the replica will be rejected.
+  UpstreamURI: "{{ .Replica.UpstreamURI }}"
+{{- end }}
+
+#
+# Rate limits & quotas applied to the /query and /eth endpoints
+#
+{{- if .RPCRateLimit }}
+RPCRateLimit:
+  Enabled: {{ .RPCRateLimit.Enabled }}
+  # Requests with a valid API key in this header are subject to the APIKeyLimit of each method,
+  # all other requests are subject to the IPLimit.
+  APIKeyHeader: "{{ .RPCRateLimit.APIKeyHeader }}"
+  {{- if .RPCRateLimit.APIKeys }}
+  APIKeys:
+    {{- range .RPCRateLimit.APIKeys }}
+    - "{{ . }}"
+    {{- end }}
+  {{- end }}
+  # Only enable if the node is behind a reverse proxy that sets the X-Forwarded-For header
+  TrustForwardedFor: {{ .RPCRateLimit.TrustForwardedFor }}
+  # Limits per method, the "*" entry applies to all methods that don't have their own entry.
+  # Period is in seconds, a zero limit means the method isn't limited.
+  {{- if .RPCRateLimit.Methods }}
+  Methods:
+    {{- range .RPCRateLimit.Methods }}
+    - Method: "{{ .Method }}"
+      Period: {{ .Period }}
+      IPLimit: {{ .IPLimit }}
+      APIKeyLimit: {{ .APIKeyLimit }}
+    {{- end }}
+  {{- end }}
+  # Maximum size of a response in bytes, zero means no limit
+  MaxResponseSize: {{ .RPCRateLimit.MaxResponseSize }}
+{{- end }}
+
 #
 # EventDispatcher
 #
 EventDispatcher:
-  # Available dispatcher: "db_indexer" | "log" | "redis"
+  # Available dispatcher: "db_indexer" | "log" | "redis" | "stream"
   Dispatcher: {{.EventDispatcher.Dispatcher}}
-  {{if eq .EventDispatcher.Dispatcher "redis"}}
-  # Redis will be use when Dispatcher is "redis"
+  {{if or (eq .EventDispatcher.Dispatcher "redis") (eq .EventDispatcher.Dispatcher "stream")}}
+  # Redis will be use when Dispatcher is "redis", or when Dispatcher is "stream" and the stream
+  # sink is "redis"
   Redis:
     URI: "{{.EventDispatcher.Redis.URI}}"
   {{end}}
+  {{- if and (eq .EventDispatcher.Dispatcher "stream") .EventDispatcher.Stream}}
+  # Stream will be used when Dispatcher is "stream"
+  Stream:
+    Sink: "{{.EventDispatcher.Stream.Sink}}"
+    OutboxDBName: "{{.EventDispatcher.Stream.OutboxDBName}}"
+    OutboxDBBackend: "{{.EventDispatcher.Stream.OutboxDBBackend}}"
+    RetainBlocks: {{.EventDispatcher.Stream.RetainBlocks}}
+    ResumeFromHeight: {{.EventDispatcher.Stream.ResumeFromHeight}}
+    MinRetryDelayMs: {{.EventDispatcher.Stream.MinRetryDelayMs}}
+    MaxRetryDelayMs: {{.EventDispatcher.Stream.MaxRetryDelayMs}}
+    {{- if and (eq .EventDispatcher.Stream.Sink "kafka") .EventDispatcher.Stream.Kafka}}
+    # Kafka will be used when Sink is "kafka"
+    Kafka:
+      Brokers:
+      {{- range .EventDispatcher.Stream.Kafka.Brokers}}
+        - "{{.}}"
+      {{- end}}
+      Topic: "{{.EventDispatcher.Stream.Kafka.Topic}}"
+    {{- end}}
+    {{- if and (eq .EventDispatcher.Stream.Sink "nats") .EventDispatcher.Stream.NATS}}
+    # NATS will be used when Sink is "nats"
+    NATS:
+      URL: "{{.EventDispatcher.Stream.NATS.URL}}"
+      Subject: "{{.EventDispatcher.Stream.NATS.Subject}}"
+    {{- end}}
+  {{- end}}
 #
 # Tx signing & accounts
 #
@@ -826,6 +1061,18 @@ Auth:
       TxType: "{{.TxType -}}"
       AccountType: {{.AccountType -}}
     {{- end}}
+  {{- if .Auth.NonceQueue}}
+  # Txs with future nonces are held by the node until the preceding txs are received, instead of
+  # being rejected.
+  NonceQueue:
+    Enabled: {{.Auth.NonceQueue.Enabled}}
+    # Maximum number of txs that can be queued for a single account
+    MaxTxsPerAccount: {{.Auth.NonceQueue.MaxTxsPerAccount}}
+    # Maximum distance between the nonce of a queued tx and the next expected nonce
+    MaxNonceGap: {{.Auth.NonceQueue.MaxNonceGap}}
+    # Number of seconds a tx can remain in the queue before it's dropped
+    TTL: {{.Auth.NonceQueue.TTL}}
+  {{- end}}
 # These should pretty much never be changed
 RootDir: "{{ .RootDir }}"
 DBName: "{{ .DBName }}"
@@ -35,3 +35,29 @@ func TestConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, reflect.DeepEqual(exampleRead, confRead))
 }
+
+func TestCompareConfigs(t *testing.T) {
+	oldCfg := DefaultConfig()
+	newCfg := oldCfg.Clone()
+	changes := CompareConfigs(oldCfg, newCfg)
+	require.Len(t, changes.Reloadable, 0)
+	require.Len(t, changes.RestartRequired, 0)
+
+	newCfg.TxLimiter.MaxTxsPerSession = 10
+	blockStoreCfg := *oldCfg.BlockStore
+	newCfg.BlockStore = &blockStoreCfg
+	newCfg.BlockStore.CacheSize = oldCfg.BlockStore.CacheSize + 1
+	newCfg.LoomLogLevel = "debug"
+	newCfg.ChainID = "other"
+	newCfg.RPCRateLimit = &RPCRateLimitConfig{Enabled: true}
+	changes = CompareConfigs(oldCfg, newCfg)
+	require.Contains(t, changes.Reloadable, "TxLimiter.MaxTxsPerSession")
+	require.Contains(t, changes.Reloadable, "BlockStore.CacheSize")
+	require.Contains(t, changes.Reloadable, "LoomLogLevel")
+	require.Contains(t, changes.Reloadable, "RPCRateLimit.Methods")
+	require.Equal(t, []string{"ChainID", "RPCRateLimit.Enabled"}, changes.RestartRequired)
+	require.True(t, changes.Changed("TxLimiter"))
+	require.True(t, changes.Changed("BlockStore.CacheSize"))
+	require.False(t, changes.Changed("ContractTxLimiter"))
+	require.False(t, changes.Changed("Web3"))
+}
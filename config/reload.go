@@ -0,0 +1,102 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// reloadableSettings lists the settings that can be changed while the node is running, none of
+// these settings affect consensus. A setting that's a struct covers all of its fields.
+var reloadableSettings = []string{
+	"LoomLogLevel",
+	"Log.ModuleLevels",
+	"TxLimiter",
+	"ContractTxLimiter",
+	"BlockStore.CacheAlgorithm",
+	"BlockStore.CacheSize",
+	"Web3.GetLogsMaxBlockRange",
+	"Web3.GasPrice",
+	"RPCRateLimit.APIKeyHeader",
+	"RPCRateLimit.APIKeys",
+	"RPCRateLimit.TrustForwardedFor",
+	"RPCRateLimit.Methods",
+	"RPCRateLimit.MaxResponseSize",
+}
+
+// ConfigChanges lists the settings that differ between two configs.
+type ConfigChanges struct {
+	// Settings that can be applied to the running node.
+	Reloadable []string `json:"reloadable"`
+	// Settings that will only take effect after the node is restarted.
+	RestartRequired []string `json:"restartRequired"`
+}
+
+// Changed returns true if any reloadable setting matching the given name changed, the name can
+// refer to an individual setting (e.g. BlockStore.CacheSize) or a group of settings (e.g. Web3).
+func (c *ConfigChanges) Changed(name string) bool {
+	for _, setting := range c.Reloadable {
+		if setting == name || strings.HasPrefix(setting, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareConfigs returns the settings that differ between the old & new configs, split into the
+// ones that can be applied to the running node, and the ones that require a restart.
+func CompareConfigs(oldCfg, newCfg *Config) *ConfigChanges {
+	changes := &ConfigChanges{
+		Reloadable:      []string{},
+		RestartRequired: []string{},
+	}
+	for _, setting := range diffValues("", reflect.ValueOf(oldCfg), reflect.ValueOf(newCfg)) {
+		if isReloadable(setting) {
+			changes.Reloadable = append(changes.Reloadable, setting)
+		} else {
+			changes.RestartRequired = append(changes.RestartRequired, setting)
+		}
+	}
+	return changes
+}
+
+func isReloadable(setting string) bool {
+	for _, s := range reloadableSettings {
+		if setting == s || strings.HasPrefix(setting, s+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// diffValues returns the dotted paths of all the fields that differ between the given values,
+// nested structs are compared field by field, all other values are compared as a whole.
+func diffValues(path string, a, b reflect.Value) []string {
+	if a.Kind() == reflect.Ptr && b.Kind() == reflect.Ptr {
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() && b.IsNil() {
+				return nil
+			}
+			return []string{path}
+		}
+		return diffValues(path, a.Elem(), b.Elem())
+	}
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return nil
+		}
+		return []string{path}
+	}
+	var diffs []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		diffs = append(diffs, diffValues(fieldPath, a.Field(i), b.Field(i))...)
+	}
+	return diffs
+}
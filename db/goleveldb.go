@@ -52,3 +52,18 @@ func LoadGoLevelDB(name, dir string, cacheSizeMeg int, bufferSizeMeg int, collec
 	}
 	return &GoLevelDB{GoLevelDB: db}, nil
 }
+
+// LoadReadOnlyGoLevelDB opens an existing GoLevelDB in read-only mode.
+func LoadReadOnlyGoLevelDB(name, dir string, cacheSizeMeg int) (*GoLevelDB, error) {
+	o := &opt.Options{
+		BlockCacheCapacity:     cacheSizeMeg * opt.MiB,
+		OpenFilesCacheCapacity: 1000,
+		ErrorIfMissing:         true,
+		ReadOnly:               true,
+	}
+	db, err := dbm.NewGoLevelDBWithOpts(name, dir, o)
+	if err != nil {
+		return nil, err
+	}
+	return &GoLevelDB{GoLevelDB: db}, nil
+}
@@ -41,3 +41,14 @@ func LoadDB(
 		return nil, fmt.Errorf("unknown db backend: %s", dbBackend)
 	}
 }
+
+// LoadReadOnlyDB opens an existing DB in read-only mode, any attempt to write to the DB will fail.
+// Only the goleveldb backend supports read-only mode.
+func LoadReadOnlyDB(dbBackend, name, directory string, cacheSizeMegs int) (DBWrapper, error) {
+	switch dbBackend {
+	case GoLevelDBBackend:
+		return LoadReadOnlyGoLevelDB(name, directory, cacheSizeMegs)
+	default:
+		return nil, fmt.Errorf("db backend %s doesn't support read-only mode", dbBackend)
+	}
+}
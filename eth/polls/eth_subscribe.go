@@ -3,20 +3,24 @@
 package polls
 
 import (
-	"fmt"
 	"sync"
 
+	"github.com/pkg/errors"
+
 	evmaux "github.com/loomnetwork/loomchain/store/evm_aux"
 
 	"github.com/loomnetwork/loomchain/store"
 
 	"github.com/loomnetwork/loomchain"
 	"github.com/loomnetwork/loomchain/eth/utils"
+	"github.com/loomnetwork/loomchain/log"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 )
 
 var (
 	BlockTimeout = uint64(10 * 60) // blocks
+
+	logger = log.Module("eth")
 )
 
 type EthPoll interface {
@@ -26,26 +30,48 @@ type EthPoll interface {
 }
 
 type EthSubscriptions struct {
-	polls      map[string]EthPoll
-	lastPoll   map[string]uint64
-	timestamps map[uint64][]string
-	mutex      sync.RWMutex // locks the 3 maps above
+	store PollStore
+	mutex sync.Mutex // serializes poll updates
 
 	lastPrune   uint64
 	evmAuxStore *evmaux.EvmAuxStore
 	blockStore  store.BlockStore
 }
 
+// NewEthSubscriptions creates a registry of polls that's only kept in memory.
 func NewEthSubscriptions(evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore) *EthSubscriptions {
-	p := &EthSubscriptions{
-		polls:      make(map[string]EthPoll),
-		lastPoll:   make(map[string]uint64),
-		timestamps: make(map[uint64][]string),
+	return NewEthSubscriptionsWithStore(NewMemPollStore(), evmAuxStore, blockStore)
+}
 
+// NewEthSubscriptionsWithStore creates a registry of polls that's kept in the given store.
+func NewEthSubscriptionsWithStore(
+	pollStore PollStore, evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore,
+) *EthSubscriptions {
+	return &EthSubscriptions{
+		store:       pollStore,
 		evmAuxStore: evmAuxStore,
 		blockStore:  blockStore,
 	}
-	return p
+}
+
+// LoadEthSubscriptions creates a registry of polls that's kept in the store specified by the config.
+func LoadEthSubscriptions(
+	cfg *eth.FilterStoreConfig, directory string, evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore,
+) (*EthSubscriptions, error) {
+	if cfg != nil && cfg.RedisURI != "" {
+		pollStore := NewRedisPollStore(cfg.RedisURI, cfg.RedisKeyPrefix, evmAuxStore, blockStore)
+		return NewEthSubscriptionsWithStore(pollStore, evmAuxStore, blockStore), nil
+	}
+	if cfg == nil || !cfg.Persistent {
+		return NewEthSubscriptions(evmAuxStore, blockStore), nil
+	}
+	pollStore, err := NewDBPollStore(
+		cfg.DBBackend, cfg.DBName, directory, cfg.CacheSizeMegs, cfg.WriteBufferMegs, evmAuxStore, blockStore,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load filter store")
+	}
+	return NewEthSubscriptionsWithStore(pollStore, evmAuxStore, blockStore), nil
 }
 
 func (s *EthSubscriptions) Add(poll EthPoll, height uint64) string {
@@ -54,37 +80,21 @@ func (s *EthSubscriptions) Add(poll EthPoll, height uint64) string {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.polls[id] = poll
-	s.lastPoll[id] = height
-	s.timestamps[height] = append(s.timestamps[height], id)
-
-	if height > BlockTimeout {
-		for h := s.lastPrune; h < height-BlockTimeout; h++ {
-			for _, id := range s.timestamps[h] {
-				delete(s.polls, id)
-				delete(s.lastPoll, id)
-			}
+	if err := s.store.Set(id, poll, height); err != nil {
+		logger.Error("Failed to store poll", "id", id, "err", err)
+	}
 
-			delete(s.timestamps, h)
+	if height > BlockTimeout && height-BlockTimeout > s.lastPrune {
+		if err := s.store.Prune(height - BlockTimeout); err != nil {
+			logger.Error("Failed to prune polls", "err", err)
+		} else {
+			s.lastPrune = height - BlockTimeout
 		}
-		s.lastPrune = height
 	}
 
 	return id
 }
 
-// This function is not thread-safe. The mutex must be locked before calling it.
-func (s *EthSubscriptions) resetTimestamp(polledId string, height uint64) {
-	lp := s.lastPoll[polledId]
-	for i, id := range s.timestamps[lp] {
-		if id == polledId {
-			s.timestamps[lp] = append(s.timestamps[lp][:i], s.timestamps[lp][i+1:]...)
-		}
-	}
-	s.timestamps[height] = append(s.timestamps[height], polledId)
-	s.lastPoll[polledId] = height
-}
-
 func (s *EthSubscriptions) AddLogPoll(filter eth.EthFilter, height uint64) (string, error) {
 	return s.Add(&EthLogPoll{
 		filter:        filter,
@@ -113,14 +123,11 @@ func (s *EthSubscriptions) AddTxPoll(height uint64) string {
 func (s *EthSubscriptions) AllLogs(
 	state loomchain.ReadOnlyState, id string, readReceipts loomchain.ReadReceiptHandler,
 ) (interface{}, error) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if poll, ok := s.polls[id]; !ok {
-		return nil, fmt.Errorf("subscription not found")
-	} else {
-		return poll.AllLogs(state, id, readReceipts)
+	poll, err := s.store.Get(id)
+	if err != nil {
+		return nil, err
 	}
+	return poll.AllLogs(state, id, readReceipts)
 }
 
 func (s *EthSubscriptions) Poll(
@@ -129,16 +136,15 @@ func (s *EthSubscriptions) Poll(
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	poll, ok := s.polls[id]
-	if !ok {
-		return nil, fmt.Errorf("subscription not found")
+	poll, err := s.store.Get(id)
+	if err != nil {
+		return nil, err
 	}
 	newPoll, result, err := poll.Poll(state, id, readReceipts)
-	s.polls[id] = newPoll
-
-	s.resetTimestamp(id, uint64(state.Block().Height))
+	if storeErr := s.store.Set(id, newPoll, uint64(state.Block().Height)); storeErr != nil {
+		return nil, storeErr
+	}
 	return result, err
-
 }
 
 func (s *EthSubscriptions) LegacyPoll(
@@ -147,22 +153,27 @@ func (s *EthSubscriptions) LegacyPoll(
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	poll, ok := s.polls[id]
-	if !ok {
-		return nil, fmt.Errorf("subscription not found")
+	poll, err := s.store.Get(id)
+	if err != nil {
+		return nil, err
 	}
 	newPoll, result, err := poll.LegacyPoll(state, id, readReceipts)
-	s.polls[id] = newPoll
-
-	s.resetTimestamp(id, uint64(state.Block().Height))
+	if storeErr := s.store.Set(id, newPoll, uint64(state.Block().Height)); storeErr != nil {
+		return nil, storeErr
+	}
 	return result, err
-
 }
 
 func (s *EthSubscriptions) Remove(id string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	delete(s.polls, id)
-	delete(s.lastPoll, id)
+	if err := s.store.Delete(id); err != nil {
+		logger.Error("Failed to remove poll", "id", id, "err", err)
+	}
+}
+
+// Close releases the resources held by the underlying store.
+func (s *EthSubscriptions) Close() {
+	s.store.Close()
 }
@@ -49,6 +49,15 @@ func (s EthSubscriptions) AddLogPoll(_ eth.EthFilter, _ uint64) (string, error)
 	return "", nil
 }
 
+func (s *EthSubscriptions) Close() {
+}
+
 func NewEthSubscriptions(_ *evmaux.EvmAuxStore, _ store.BlockStore) *EthSubscriptions {
 	return &EthSubscriptions{}
 }
+
+func LoadEthSubscriptions(
+	_ *eth.FilterStoreConfig, _ string, _ *evmaux.EvmAuxStore, _ store.BlockStore,
+) (*EthSubscriptions, error) {
+	return &EthSubscriptions{}, nil
+}
@@ -0,0 +1,318 @@
+// +build evm
+
+package polls
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/db"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+	"github.com/loomnetwork/loomchain/store"
+	evmaux "github.com/loomnetwork/loomchain/store/evm_aux"
+)
+
+const (
+	logPollType   = "log"
+	blockPollType = "block"
+	txPollType    = "tx"
+)
+
+var (
+	// ErrPollNotFound is returned by PollStore.Get() when there's no poll matching the given ID.
+	ErrPollNotFound = errors.New("subscription not found")
+
+	pollKeyPrefix      = []byte("poll:")
+	timestampKeyPrefix = []byte("ts:")
+)
+
+// PollStore keeps track of the polls created via EthSubscriptions, and the height at which each
+// poll was last polled, so polls that are no longer in use can be expired.
+type PollStore interface {
+	// Get looks up the poll with the given ID, returns ErrPollNotFound if the poll doesn't exist.
+	Get(id string) (EthPoll, error)
+	// Set stores the poll, and records the height at which it was last polled.
+	Set(id string, poll EthPoll, height uint64) error
+	// Delete removes the poll with the given ID.
+	Delete(id string) error
+	// Prune removes all the polls that haven't been polled since the given height.
+	Prune(height uint64) error
+	Close()
+}
+
+// MemPollStore is a PollStore that only keeps polls in memory.
+type MemPollStore struct {
+	polls      map[string]EthPoll
+	lastPoll   map[string]uint64
+	timestamps map[uint64][]string
+	mutex      sync.RWMutex // locks the 3 maps above
+
+	lastPrune uint64
+}
+
+func NewMemPollStore() *MemPollStore {
+	return &MemPollStore{
+		polls:      make(map[string]EthPoll),
+		lastPoll:   make(map[string]uint64),
+		timestamps: make(map[uint64][]string),
+	}
+}
+
+func (s *MemPollStore) Get(id string) (EthPoll, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	poll, ok := s.polls[id]
+	if !ok {
+		return nil, ErrPollNotFound
+	}
+	return poll, nil
+}
+
+func (s *MemPollStore) Set(id string, poll EthPoll, height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.polls[id] = poll
+	s.resetTimestamp(id, height)
+	return nil
+}
+
+// This function is not thread-safe. The mutex must be locked before calling it.
+func (s *MemPollStore) resetTimestamp(polledId string, height uint64) {
+	if lp, ok := s.lastPoll[polledId]; ok {
+		s.removeTimestamp(polledId, lp)
+	}
+	s.timestamps[height] = append(s.timestamps[height], polledId)
+	s.lastPoll[polledId] = height
+}
+
+// This function is not thread-safe. The mutex must be locked before calling it.
+func (s *MemPollStore) removeTimestamp(polledId string, height uint64) {
+	for i, id := range s.timestamps[height] {
+		if id == polledId {
+			s.timestamps[height] = append(s.timestamps[height][:i], s.timestamps[height][i+1:]...)
+			break
+		}
+	}
+}
+
+func (s *MemPollStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if lp, ok := s.lastPoll[id]; ok {
+		s.removeTimestamp(id, lp)
+	}
+	delete(s.polls, id)
+	delete(s.lastPoll, id)
+	return nil
+}
+
+func (s *MemPollStore) Prune(height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for h := s.lastPrune; h < height; h++ {
+		for _, id := range s.timestamps[h] {
+			delete(s.polls, id)
+			delete(s.lastPoll, id)
+		}
+		delete(s.timestamps, h)
+	}
+	if height > s.lastPrune {
+		s.lastPrune = height
+	}
+	return nil
+}
+
+func (s *MemPollStore) Close() {
+}
+
+// pollRecord is the serialized form of a poll persisted by DBPollStore.
+type pollRecord struct {
+	Type       string
+	Filter     *eth.EthFilter `json:",omitempty"`
+	StartBlock uint64
+	LastBlock  uint64
+	// Height at which the poll was last polled
+	LastPoll uint64
+}
+
+func newPollRecord(poll EthPoll, height uint64) (*pollRecord, error) {
+	switch p := poll.(type) {
+	case *EthLogPoll:
+		filter := p.filter
+		return &pollRecord{Type: logPollType, Filter: &filter, LastBlock: p.lastBlockRead, LastPoll: height}, nil
+	case *EthBlockPoll:
+		return &pollRecord{Type: blockPollType, StartBlock: p.startBlock, LastBlock: p.lastBlock, LastPoll: height}, nil
+	case *EthTxPoll:
+		return &pollRecord{Type: txPollType, StartBlock: p.startBlock, LastBlock: p.lastBlockRead, LastPoll: height}, nil
+	default:
+		return nil, errors.Errorf("unsupported poll type %T", poll)
+	}
+}
+
+func (r *pollRecord) toPoll(evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore) (EthPoll, error) {
+	switch r.Type {
+	case logPollType:
+		if r.Filter == nil {
+			return nil, errors.New("log poll has no filter")
+		}
+		return &EthLogPoll{
+			filter:        *r.Filter,
+			lastBlockRead: r.LastBlock,
+			evmAuxStore:   evmAuxStore,
+			blockStore:    blockStore,
+		}, nil
+	case blockPollType:
+		return &EthBlockPoll{
+			startBlock:  r.StartBlock,
+			lastBlock:   r.LastBlock,
+			evmAuxStore: evmAuxStore,
+			blockStore:  blockStore,
+		}, nil
+	case txPollType:
+		return &EthTxPoll{
+			startBlock:    r.StartBlock,
+			lastBlockRead: r.LastBlock,
+			evmAuxStore:   evmAuxStore,
+			blockStore:    blockStore,
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported poll type %s", r.Type)
+	}
+}
+
+func pollKey(id string) []byte {
+	return append(append([]byte{}, pollKeyPrefix...), []byte(id)...)
+}
+
+func timestampKey(height uint64, id string) []byte {
+	key := make([]byte, len(timestampKeyPrefix)+8, len(timestampKeyPrefix)+8+len(id))
+	copy(key, timestampKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(timestampKeyPrefix):], height)
+	return append(key, []byte(id)...)
+}
+
+// DBPollStore is a PollStore that persists polls to a DB, polls stored in the DB survive node
+// restarts. The DB is local to the node (and locked by the process that opens it), so polls can't be
+// shared between nodes. Alongside each poll the store keeps a height -> poll ID index that's used to
+// expire polls that haven't been polled recently.
+type DBPollStore struct {
+	db          db.DBWrapper
+	evmAuxStore *evmaux.EvmAuxStore
+	blockStore  store.BlockStore
+	mutex       sync.Mutex // serializes updates to the poll & timestamp keys
+}
+
+// NewDBPollStore returns a new instance of the store backed by the given DB. The EVM aux & block
+// stores are used to query the results of the polls loaded from the DB.
+func NewDBPollStore(
+	dbBackend, name, directory string, cacheSizeMegs, writeBufferMegs int,
+	evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore,
+) (*DBPollStore, error) {
+	dbWrapper, err := db.LoadDB(dbBackend, name, directory, cacheSizeMegs, writeBufferMegs, false)
+	if err != nil {
+		return nil, err
+	}
+	return &DBPollStore{
+		db:          dbWrapper,
+		evmAuxStore: evmAuxStore,
+		blockStore:  blockStore,
+	}, nil
+}
+
+func (s *DBPollStore) loadRecord(id string) (*pollRecord, error) {
+	data := s.db.Get(pollKey(id))
+	if data == nil {
+		return nil, ErrPollNotFound
+	}
+	var rec pollRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal poll %s", id)
+	}
+	return &rec, nil
+}
+
+func (s *DBPollStore) Get(id string) (EthPoll, error) {
+	rec, err := s.loadRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	return rec.toPoll(s.evmAuxStore, s.blockStore)
+}
+
+func (s *DBPollStore) Set(id string, poll EthPoll, height uint64) error {
+	rec, err := newPollRecord(poll, height)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal poll %s", id)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch := s.db.NewBatch()
+	if prevRec, err := s.loadRecord(id); err == nil {
+		batch.Delete(timestampKey(prevRec.LastPoll, id))
+	}
+	batch.Set(pollKey(id), data)
+	batch.Set(timestampKey(height, id), []byte{1})
+	batch.Write()
+	return nil
+}
+
+func (s *DBPollStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rec, err := s.loadRecord(id)
+	if err == ErrPollNotFound {
+		return nil
+	}
+	batch := s.db.NewBatch()
+	if err == nil {
+		batch.Delete(timestampKey(rec.LastPoll, id))
+	}
+	batch.Delete(pollKey(id))
+	batch.Write()
+	return nil
+}
+
+func (s *DBPollStore) Prune(height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	start := timestampKey(0, "")
+	end := timestampKey(height, "")
+	var keys [][]byte
+	iter := s.db.Iterator(start, end)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	batch := s.db.NewBatch()
+	for _, key := range keys {
+		id := string(key[len(timestampKeyPrefix)+8:])
+		batch.Delete(key)
+		batch.Delete(pollKey(id))
+	}
+	batch.Write()
+	return nil
+}
+
+func (s *DBPollStore) Close() {
+	s.db.Close()
+}
@@ -71,6 +71,8 @@ func (p *EthLogPoll) Poll(
 	newLogPoll := &EthLogPoll{
 		filter:        p.filter,
 		lastBlockRead: end,
+		evmAuxStore:   p.evmAuxStore,
+		blockStore:    p.blockStore,
 	}
 	return newLogPoll, eth.EncLogs(eventLogs), nil
 }
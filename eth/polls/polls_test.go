@@ -3,6 +3,8 @@
 package polls
 
 import (
+	"io/ioutil"
+	"os"
 	"strconv"
 	"sync"
 	"testing"
@@ -362,12 +364,72 @@ func TestAddRemove(t *testing.T) {
 	myFilter, err := eth.DecLogFilter(jsonFilter)
 	id, err := s.AddLogPoll(myFilter, 1)
 	require.NoError(t, err)
-	_, ok := s.polls[id]
-	require.True(t, ok, "map key does not exists")
+	_, err = s.store.Get(id)
+	require.NoError(t, err, "poll not stored")
 
 	s.Remove(id)
-	_, ok = s.polls[id]
-	require.False(t, ok, "id key not deleted")
+	_, err = s.store.Get(id)
+	require.Equal(t, ErrPollNotFound, err, "poll not removed")
+}
+
+func TestDBPollStore(t *testing.T) {
+	evmAuxStore, err := common.NewMockEvmAuxStore()
+	require.NoError(t, err)
+	blockStore := store.NewMockBlockStore()
+	eventDispatcher := events.NewLogEventDispatcher()
+	eventHandler := loomchain.NewDefaultEventHandler(eventDispatcher)
+	receiptHandler := handler.NewReceiptHandler(eventHandler, handler.DefaultMaxReceipts, evmAuxStore)
+	state := makeMockState(t, receiptHandler, blockStore)
+
+	dir, err := ioutil.TempDir("", "eth-filters")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pollStore, err := NewDBPollStore("goleveldb", "eth_filters", dir, 0, 0, evmAuxStore, blockStore)
+	require.NoError(t, err)
+	subs := NewEthSubscriptionsWithStore(pollStore, evmAuxStore, blockStore)
+	txPollID := subs.AddTxPoll(uint64(5))
+	ethFilter, err := eth.DecLogFilter(eth.JsonFilter{FromBlock: "earliest", ToBlock: "latest"})
+	require.NoError(t, err)
+	logPollID, err := subs.AddLogPoll(ethFilter, uint64(5))
+	require.NoError(t, err)
+
+	result, err := subs.Poll(common.MockStateAt(state, uint64(27)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(result.([]eth.Data)), "wrong number of logs returned")
+	result, err = subs.Poll(common.MockStateAt(state, uint64(27)), logPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(result.([]eth.JsonLog)), "wrong number of logs returned")
+
+	// after a restart the node should pick up the polls where it left off
+	pollStore.Close()
+	pollStore, err = NewDBPollStore("goleveldb", "eth_filters", dir, 0, 0, evmAuxStore, blockStore)
+	require.NoError(t, err)
+	subs = NewEthSubscriptionsWithStore(pollStore, evmAuxStore, blockStore)
+	result, err = subs.Poll(common.MockStateAt(state, uint64(50)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.([]eth.Data)), "wrong number of logs returned")
+	result, err = subs.Poll(common.MockStateAt(state, uint64(50)), logPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.([]eth.JsonLog)), "wrong number of logs returned")
+
+	// polls that haven't been polled in a while should expire
+	require.NoError(t, pollStore.Prune(uint64(50)))
+	_, err = subs.Poll(common.MockStateAt(state, uint64(60)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	require.NoError(t, pollStore.Prune(uint64(55)))
+	_, err = subs.Poll(common.MockStateAt(state, uint64(60)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	_, err = subs.Poll(common.MockStateAt(state, uint64(60)), logPollID, receiptHandler)
+	require.Equal(t, ErrPollNotFound, err)
+
+	subs.Remove(txPollID)
+	_, err = subs.Poll(common.MockStateAt(state, uint64(60)), txPollID, receiptHandler)
+	require.Equal(t, ErrPollNotFound, err)
+
+	pollStore.Close()
+	require.NoError(t, receiptHandler.Close())
+	evmAuxStore.ClearData()
 }
 
 func mockSignedTx(t *testing.T, id uint32, to loom.Address, from loom.Address, data []byte) []byte {
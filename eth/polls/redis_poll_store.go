@@ -0,0 +1,146 @@
+// +build evm
+
+package polls
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/store"
+	evmaux "github.com/loomnetwork/loomchain/store/evm_aux"
+)
+
+// RedisPollStore is a PollStore that keeps polls in a Redis server, which can be shared by all the
+// nodes behind a load balancer, so a filter created via one node can be polled via any other node.
+// Each poll is stored under its own key, and a sorted set that maps poll IDs to the height at which
+// each poll was last polled is used to expire polls that haven't been polled recently.
+type RedisPollStore struct {
+	pool        *redis.Pool
+	prefix      string
+	evmAuxStore *evmaux.EvmAuxStore
+	blockStore  store.BlockStore
+}
+
+// NewRedisPollStore returns a new instance of the store that connects to the Redis server at the
+// given URI, all the keys used by the store are prefixed by the given prefix. The EVM aux & block
+// stores are used to query the results of the polls loaded from Redis.
+func NewRedisPollStore(
+	uri, prefix string, evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore,
+) *RedisPollStore {
+	pool := &redis.Pool{
+		MaxIdle:     8,
+		IdleTimeout: 5 * time.Minute,
+		Dial: func() (redis.Conn, error) {
+			return redis.DialURL(
+				uri,
+				redis.DialConnectTimeout(10*time.Second),
+				redis.DialReadTimeout(10*time.Second),
+				redis.DialWriteTimeout(10*time.Second),
+			)
+		},
+	}
+	return NewRedisPollStoreWithPool(pool, prefix, evmAuxStore, blockStore)
+}
+
+// NewRedisPollStoreWithPool returns a new instance of the store that obtains connections to the
+// Redis server from the given pool.
+func NewRedisPollStoreWithPool(
+	pool *redis.Pool, prefix string, evmAuxStore *evmaux.EvmAuxStore, blockStore store.BlockStore,
+) *RedisPollStore {
+	return &RedisPollStore{
+		pool:        pool,
+		prefix:      prefix,
+		evmAuxStore: evmAuxStore,
+		blockStore:  blockStore,
+	}
+}
+
+func (s *RedisPollStore) pollKey(id string) string {
+	return s.prefix + string(pollKeyPrefix) + id
+}
+
+func (s *RedisPollStore) timestampsKey() string {
+	return s.prefix + string(timestampKeyPrefix)
+}
+
+func (s *RedisPollStore) Get(id string) (EthPoll, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GET", s.pollKey(id)))
+	if err == redis.ErrNil {
+		return nil, ErrPollNotFound
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to load poll %s", id)
+	}
+	var rec pollRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal poll %s", id)
+	}
+	return rec.toPoll(s.evmAuxStore, s.blockStore)
+}
+
+func (s *RedisPollStore) Set(id string, poll EthPoll, height uint64) error {
+	rec, err := newPollRecord(poll, height)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal poll %s", id)
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	if _, err := conn.Do("SET", s.pollKey(id), data); err != nil {
+		return errors.Wrapf(err, "failed to store poll %s", id)
+	}
+	if _, err := conn.Do("ZADD", s.timestampsKey(), height, id); err != nil {
+		return errors.Wrapf(err, "failed to store timestamp of poll %s", id)
+	}
+	return nil
+}
+
+func (s *RedisPollStore) Delete(id string) error {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	if _, err := conn.Do("DEL", s.pollKey(id)); err != nil {
+		return errors.Wrapf(err, "failed to delete poll %s", id)
+	}
+	if _, err := conn.Do("ZREM", s.timestampsKey(), id); err != nil {
+		return errors.Wrapf(err, "failed to delete timestamp of poll %s", id)
+	}
+	return nil
+}
+
+func (s *RedisPollStore) Prune(height uint64) error {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	// "(" makes the max score exclusive, so polls that were polled at the given height are kept
+	ids, err := redis.Strings(conn.Do("ZRANGEBYSCORE", s.timestampsKey(), "-inf", "("+strconv.FormatUint(height, 10)))
+	if err != nil {
+		return errors.Wrap(err, "failed to load expired polls")
+	}
+	for _, id := range ids {
+		if _, err := conn.Do("DEL", s.pollKey(id)); err != nil {
+			return errors.Wrapf(err, "failed to delete poll %s", id)
+		}
+		if _, err := conn.Do("ZREM", s.timestampsKey(), id); err != nil {
+			return errors.Wrapf(err, "failed to delete timestamp of poll %s", id)
+		}
+	}
+	return nil
+}
+
+func (s *RedisPollStore) Close() {
+	if err := s.pool.Close(); err != nil {
+		logger.Error("Failed to close Redis connection pool", "err", err)
+	}
+}
@@ -0,0 +1,194 @@
+// +build evm
+
+package polls
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/events"
+	"github.com/loomnetwork/loomchain/receipts/common"
+	"github.com/loomnetwork/loomchain/receipts/handler"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+func TestRedisPollStoreSharedBetweenNodes(t *testing.T) {
+	evmAuxStore, err := common.NewMockEvmAuxStore()
+	require.NoError(t, err)
+	blockStore := store.NewMockBlockStore()
+	eventDispatcher := events.NewLogEventDispatcher()
+	eventHandler := loomchain.NewDefaultEventHandler(eventDispatcher)
+	receiptHandler := handler.NewReceiptHandler(eventHandler, handler.DefaultMaxReceipts, evmAuxStore)
+	state := makeMockState(t, receiptHandler, blockStore)
+
+	// each node has its own store & connection pool, but they're connected to the same server
+	server := newFakeRedisServer()
+	newNode := func() (*EthSubscriptions, *RedisPollStore) {
+		pool := &redis.Pool{
+			Dial: func() (redis.Conn, error) { return &fakeRedisConn{server: server}, nil },
+		}
+		pollStore := NewRedisPollStoreWithPool(pool, "test:", evmAuxStore, blockStore)
+		return NewEthSubscriptionsWithStore(pollStore, evmAuxStore, blockStore), pollStore
+	}
+	node1, pollStore1 := newNode()
+	defer pollStore1.Close()
+	node2, pollStore2 := newNode()
+	defer pollStore2.Close()
+
+	txPollID := node1.AddTxPoll(uint64(5))
+	ethFilter, err := eth.DecLogFilter(eth.JsonFilter{FromBlock: "earliest", ToBlock: "latest"})
+	require.NoError(t, err)
+	logPollID, err := node1.AddLogPoll(ethFilter, uint64(5))
+	require.NoError(t, err)
+
+	// polls created via one node can be polled via the other
+	result, err := node2.Poll(common.MockStateAt(state, uint64(27)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(result.([]eth.Data)), "wrong number of logs returned")
+	result, err = node2.Poll(common.MockStateAt(state, uint64(27)), logPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(result.([]eth.JsonLog)), "wrong number of logs returned")
+
+	// and each node picks up the polls where the other left off
+	result, err = node1.Poll(common.MockStateAt(state, uint64(50)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.([]eth.Data)), "wrong number of logs returned")
+	result, err = node1.Poll(common.MockStateAt(state, uint64(50)), logPollID, receiptHandler)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.([]eth.JsonLog)), "wrong number of logs returned")
+
+	// polls that haven't been polled in a while expire on all nodes
+	_, err = node2.Poll(common.MockStateAt(state, uint64(60)), txPollID, receiptHandler)
+	require.NoError(t, err)
+	require.NoError(t, pollStore2.Prune(uint64(55)))
+	_, err = node1.Poll(common.MockStateAt(state, uint64(60)), logPollID, receiptHandler)
+	require.Equal(t, ErrPollNotFound, err)
+
+	// polls removed via one node are removed from the other too
+	node1.Remove(txPollID)
+	_, err = node2.Poll(common.MockStateAt(state, uint64(60)), txPollID, receiptHandler)
+	require.Equal(t, ErrPollNotFound, err)
+
+	require.NoError(t, receiptHandler.Close())
+	evmAuxStore.ClearData()
+}
+
+// fakeRedisServer implements the subset of Redis commands used by RedisPollStore.
+type fakeRedisServer struct {
+	mutex   sync.Mutex
+	strings map[string][]byte
+	zsets   map[string]map[string]float64
+}
+
+func newFakeRedisServer() *fakeRedisServer {
+	return &fakeRedisServer{
+		strings: make(map[string][]byte),
+		zsets:   make(map[string]map[string]float64),
+	}
+}
+
+func (s *fakeRedisServer) do(cmd string, args []string) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch strings.ToUpper(cmd) {
+	case "":
+		return nil, nil
+	case "GET":
+		if v, ok := s.strings[args[0]]; ok {
+			return v, nil
+		}
+		return nil, nil
+	case "SET":
+		s.strings[args[0]] = []byte(args[1])
+		return "OK", nil
+	case "DEL":
+		_, ok := s.strings[args[0]]
+		delete(s.strings, args[0])
+		return boolToInt64(ok), nil
+	case "ZADD":
+		score, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		zset := s.zsets[args[0]]
+		if zset == nil {
+			zset = make(map[string]float64)
+			s.zsets[args[0]] = zset
+		}
+		_, ok := zset[args[2]]
+		zset[args[2]] = score
+		return boolToInt64(!ok), nil
+	case "ZREM":
+		_, ok := s.zsets[args[0]][args[1]]
+		delete(s.zsets[args[0]], args[1])
+		return boolToInt64(ok), nil
+	case "ZRANGEBYSCORE":
+		if args[1] != "-inf" || !strings.HasPrefix(args[2], "(") {
+			return nil, errors.Errorf("unsupported range %s %s", args[1], args[2])
+		}
+		max, err := strconv.ParseFloat(args[2][1:], 64)
+		if err != nil {
+			return nil, err
+		}
+		var members []string
+		for member, score := range s.zsets[args[0]] {
+			if score < max {
+				members = append(members, member)
+			}
+		}
+		sort.Strings(members)
+		reply := make([]interface{}, len(members))
+		for i, member := range members {
+			reply[i] = []byte(member)
+		}
+		return reply, nil
+	default:
+		return nil, errors.Errorf("unsupported command %s", cmd)
+	}
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type fakeRedisConn struct {
+	server *fakeRedisServer
+}
+
+func (c *fakeRedisConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	strArgs := make([]string, len(args))
+	for i, arg := range args {
+		if b, ok := arg.([]byte); ok {
+			strArgs[i] = string(b)
+		} else {
+			strArgs[i] = fmt.Sprint(arg)
+		}
+	}
+	return c.server.do(cmd, strArgs)
+}
+
+func (c *fakeRedisConn) Send(cmd string, args ...interface{}) error {
+	return errors.New("not supported")
+}
+
+func (c *fakeRedisConn) Receive() (interface{}, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeRedisConn) Flush() error { return nil }
+func (c *fakeRedisConn) Close() error { return nil }
+func (c *fakeRedisConn) Err() error   { return nil }
@@ -19,6 +19,7 @@ import (
 	evmaux "github.com/loomnetwork/loomchain/store/evm_aux"
 	"github.com/pkg/errors"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	ttypes "github.com/tendermint/tendermint/types"
 )
 
 var (
@@ -121,16 +122,30 @@ func GetBlockByNumber(
 func GetTxObjectFromBlockResult(
 	blockResult *ctypes.ResultBlock, txResultData []byte, txIndex int64, evmAuxStore *evmaux.EvmAuxStore,
 ) (eth.JsonTxObject, *eth.Data, error) {
-	tx := blockResult.Block.Data.Txs[txIndex]
+	txObj, contractAddress, err := decodeTxObject(
+		blockResult.Block.Data.Txs[txIndex], txResultData, evmAuxStore,
+	)
+	if err != nil {
+		return txObj, nil, err
+	}
+	txObj.BlockHash = eth.EncBytes(blockResult.BlockMeta.BlockID.Hash)
+	txObj.BlockNumber = eth.EncInt(blockResult.Block.Header.Height)
+	txObj.TransactionIndex = eth.EncInt(int64(txIndex))
+	return txObj, contractAddress, nil
+}
+
+// decodeTxObject decodes the given tx into a tx object, the block related fields of the object
+// are left unset. The tx result data is optional, when it's not available the tx hash will
+// always be the Tendermint tx hash.
+func decodeTxObject(
+	tx ttypes.Tx, txResultData []byte, evmAuxStore *evmaux.EvmAuxStore,
+) (eth.JsonTxObject, *eth.Data, error) {
 	var contractAddress *eth.Data
 	txObj := eth.JsonTxObject{
-		BlockHash:        eth.EncBytes(blockResult.BlockMeta.BlockID.Hash),
-		BlockNumber:      eth.EncInt(blockResult.Block.Header.Height),
-		TransactionIndex: eth.EncInt(int64(txIndex)),
-		Value:            eth.EncInt(0),
-		GasPrice:         eth.EncInt(0),
-		Gas:              eth.EncInt(0),
-		Hash:             eth.EncBytes(tx.Hash()),
+		Value:    eth.EncInt(0),
+		GasPrice: eth.EncInt(0),
+		Gas:      eth.EncInt(0),
+		Hash:     eth.EncBytes(tx.Hash()),
 	}
 
 	var signedTx auth.SignedTx
@@ -89,7 +89,11 @@ func GetBlockLogRange(
 	}
 	eventLogs := []*ptypes.EthFilterLog{}
 
-	for height := from; height <= to; height++ {
+	heights, err := getLogBlockHeights(evmAuxStore, from, to, ethFilter)
+	if err != nil {
+		return nil, err
+	}
+	for _, height := range heights {
 		blockLogs, err := getBlockLogs(blockStore, state, ethFilter, height, readReceipts, evmAuxStore)
 		if err != nil {
 			return nil, err
@@ -99,6 +103,48 @@ func GetBlockLogRange(
 	return eventLogs, nil
 }
 
+// getLogBlockHeights returns the heights of the blocks within the given range that may contain
+// logs matching the filter. If the filter names any contract addresses or topic0 values the log
+// index is used to skip blocks that don't contain any matching logs, blocks that precede the start
+// of the index are always returned.
+func getLogBlockHeights(
+	evmAuxStore *evmaux.EvmAuxStore, from, to uint64, ethFilter eth.EthBlockFilter,
+) ([]uint64, error) {
+	var topics []string
+	if len(ethFilter.Topics) > 0 {
+		topics = ethFilter.Topics[0]
+	}
+
+	indexStart := uint64(0)
+	if len(ethFilter.Addresses) > 0 || len(topics) > 0 {
+		var err error
+		if indexStart, err = evmAuxStore.GetLogIndexStart(); err != nil {
+			return nil, err
+		}
+	}
+
+	var heights []uint64
+	for height := from; height <= to && (indexStart == 0 || height < indexStart); height++ {
+		heights = append(heights, height)
+	}
+	if indexStart == 0 || to < indexStart {
+		return heights, nil
+	}
+
+	if from < indexStart {
+		from = indexStart
+	}
+	addresses := make([][]byte, 0, len(ethFilter.Addresses))
+	for _, addr := range ethFilter.Addresses {
+		addresses = append(addresses, addr)
+	}
+	indexedHeights, err := evmAuxStore.GetIndexedLogHeights(addresses, topics, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query log index")
+	}
+	return append(heights, indexedHeights...), nil
+}
+
 func getBlockLogs(
 	blockStore store.BlockStore,
 	state loomchain.ReadOnlyState,
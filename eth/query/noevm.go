@@ -3,12 +3,14 @@
 package query
 
 import (
+	"github.com/loomnetwork/go-loom"
 	"github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/loomchain"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/store"
 	evmaux "github.com/loomnetwork/loomchain/store/evm_aux"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	ttypes "github.com/tendermint/tendermint/types"
 )
 
 func DeprecatedQueryChain(
@@ -41,6 +43,20 @@ func GetPendingBlock(_ int64, _ bool, _ loomchain.ReadReceiptHandler) ([]byte, e
 	return nil, nil
 }
 
+func GetPendingTxObject(_ ttypes.Tx) (eth.JsonTxObject, error) {
+	return eth.JsonTxObject{}, nil
+}
+
+func GetPendingBlockObject(_ int64, _ []byte, _ bool, _ []ttypes.Tx) eth.JsonBlockObject {
+	return eth.JsonBlockObject{}
+}
+
+func GetPendingNonce(
+	nonce uint64, _ loom.Address, _ []ttypes.Tx, _ func(caller loom.Address) (loom.Address, error),
+) uint64 {
+	return nonce
+}
+
 func DeprecatedGetBlockByHash(
 	_ store.BlockStore, _ loomchain.ReadOnlyState, _ []byte, _ bool, _ loomchain.ReadReceiptHandler,
 	_ *evmaux.EvmAuxStore,
@@ -0,0 +1,124 @@
+// +build evm
+
+package query
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/auth"
+	"github.com/loomnetwork/go-loom/vm"
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+	"github.com/pkg/errors"
+	ttypes "github.com/tendermint/tendermint/types"
+)
+
+var logger = log.Module("eth")
+
+// GetPendingTxObject decodes a tx that's still in the mempool into a tx object. Since the tx
+// hasn't been executed yet the hash of the tx object is always the Tendermint tx hash, and the
+// block related fields are left unset.
+func GetPendingTxObject(tx ttypes.Tx) (eth.JsonTxObject, error) {
+	txObj, _, err := decodeTxObject(tx, nil, nil)
+	return txObj, err
+}
+
+// GetPendingBlockObject builds the block that will be created at the given height if all the txs
+// currently in the mempool make it into it. The hash, nonce & logs bloom of the pending block
+// are unknown so they're left unset. Txs that can't be decoded are skipped.
+func GetPendingBlockObject(
+	height int64, parentHash []byte, full bool, pendingTxs []ttypes.Tx,
+) eth.JsonBlockObject {
+	blockInfo := eth.JsonBlockObject{
+		Number:           eth.EncInt(height),
+		ParentHash:       eth.EncBytes(parentHash),
+		Timestamp:        eth.EncInt(time.Now().Unix()),
+		GasLimit:         eth.EncInt(0),
+		GasUsed:          eth.EncInt(0),
+		Size:             eth.EncInt(0),
+		Sha3Uncles:       eth.ZeroedData32Bytes,
+		TransactionsRoot: eth.ZeroedData32Bytes,
+		StateRoot:        eth.ZeroedData32Bytes,
+		ReceiptsRoot:     eth.ZeroedData32Bytes,
+		Miner:            eth.ZeroedData20Bytes,
+		Difficulty:       eth.ZeroedQuantity,
+		TotalDifficulty:  eth.ZeroedQuantity,
+		ExtraData:        eth.ZeroedData,
+		Transactions:     make([]interface{}, 0, len(pendingTxs)),
+		Uncles:           []eth.Data{},
+	}
+
+	for _, tx := range pendingTxs {
+		txObj, err := GetPendingTxObject(tx)
+		if err != nil {
+			logger.Debug("Failed to decode pending tx", "hash", eth.EncBytes(tx.Hash()), "err", err)
+			continue
+		}
+		if full {
+			blockInfo.Transactions = append(blockInfo.Transactions, txObj)
+		} else {
+			blockInfo.Transactions = append(blockInfo.Transactions, txObj.Hash)
+		}
+	}
+	return blockInfo
+}
+
+// GetPendingNonce returns the nonce the given account will have once all of its txs currently in
+// the mempool are committed. Only txs with consecutive nonces following the committed nonce are
+// counted, since a gap in the nonces will prevent any txs after the gap from being committed.
+// The caller of a tx may be a foreign address (e.g. when the tx was signed with an Ethereum or Tron
+// key), so resolveAccount is used to look up the account whose nonce is incremented by the tx.
+func GetPendingNonce(
+	nonce uint64, account loom.Address, pendingTxs []ttypes.Tx,
+	resolveAccount func(caller loom.Address) (loom.Address, error),
+) uint64 {
+	queued := make(map[uint64]bool)
+	// The same caller is likely to have sent multiple txs, so cache the resolved accounts.
+	isAccount := make(map[string]bool)
+	for _, tx := range pendingTxs {
+		caller, seq, err := decodeTxSender(tx)
+		if err != nil {
+			continue
+		}
+		match, ok := isAccount[caller.String()]
+		if !ok {
+			resolved, err := resolveAccount(caller)
+			match = err == nil && resolved.Compare(account) == 0
+			isAccount[caller.String()] = match
+		}
+		if match {
+			queued[seq] = true
+		}
+	}
+	for queued[nonce+1] {
+		nonce++
+	}
+	return nonce
+}
+
+// decodeTxSender returns the address of the caller & the nonce of the given tx.
+func decodeTxSender(tx ttypes.Tx) (loom.Address, uint64, error) {
+	var signedTx auth.SignedTx
+	if err := proto.Unmarshal(tx, &signedTx); err != nil {
+		return loom.Address{}, 0, err
+	}
+	var nonceTx auth.NonceTx
+	if err := proto.Unmarshal(signedTx.Inner, &nonceTx); err != nil {
+		return loom.Address{}, 0, err
+	}
+	var txTx loomchain.Transaction
+	if err := proto.Unmarshal(nonceTx.Inner, &txTx); err != nil {
+		return loom.Address{}, 0, err
+	}
+	var msg vm.MessageTx
+	if err := proto.Unmarshal(txTx.Data, &msg); err != nil {
+		return loom.Address{}, 0, err
+	}
+	if msg.From == nil {
+		return loom.Address{}, 0, errors.New("tx has no caller")
+	}
+	return loom.UnmarshalAddressPB(msg.From), nonceTx.Sequence, nil
+}
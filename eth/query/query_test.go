@@ -22,6 +22,7 @@ import (
 	"github.com/loomnetwork/loomchain/receipts/handler"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/store"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	ttypes "github.com/tendermint/tendermint/types"
@@ -385,3 +386,74 @@ func getRandomTxHash() []byte {
 	h.Write(token)
 	return h.Sum(nil)
 }
+
+func mockNonceTx(t *testing.T, from loom.Address, nonce uint64) ttypes.Tx {
+	messageTx, err := proto.Marshal(&vm.MessageTx{
+		To:   addr2.MarshalPB(),
+		From: from.MarshalPB(),
+		Data: []byte{},
+	})
+	require.NoError(t, err)
+	txTx, err := proto.Marshal(&loomchain.Transaction{
+		Data: messageTx,
+		Id:   uint32(ltypes.TxID_MIGRATION),
+	})
+	require.NoError(t, err)
+	nonceTx, err := proto.Marshal(&auth.NonceTx{
+		Sequence: nonce,
+		Inner:    txTx,
+	})
+	require.NoError(t, err)
+	signedTx, err := proto.Marshal(&auth.SignedTx{
+		Inner: nonceTx,
+	})
+	require.NoError(t, err)
+	return ttypes.Tx(signedTx)
+}
+
+func TestPendingTxs(t *testing.T) {
+	ethAddr := loom.MustParseAddress("eth:0x5cecd1f7261e1f4c684e297be3edf03b825e01c4")
+	pendingTxs := []ttypes.Tx{
+		mockNonceTx(t, addr1, 3),
+		mockNonceTx(t, addr2, 6),
+		mockNonceTx(t, ethAddr, 4),
+		mockNonceTx(t, addr1, 7),
+		ttypes.Tx([]byte("garbage")),
+	}
+
+	// ethAddr is mapped to addr1
+	resolveAccount := func(caller loom.Address) (loom.Address, error) {
+		if caller.Compare(ethAddr) == 0 {
+			return addr1, nil
+		}
+		return caller, nil
+	}
+	// txs from any of the addresses mapped to the account should be counted, up to the first gap in
+	// the nonces
+	require.Equal(t, uint64(4), GetPendingNonce(2, addr1, pendingTxs, resolveAccount))
+	require.Equal(t, uint64(2), GetPendingNonce(2, addr2, pendingTxs, resolveAccount))
+	require.Equal(t, uint64(6), GetPendingNonce(5, addr2, pendingTxs, resolveAccount))
+	// txs from callers that can't be resolved should be ignored
+	resolveUnmapped := func(caller loom.Address) (loom.Address, error) {
+		if caller.Compare(ethAddr) == 0 {
+			return loom.Address{}, errors.New("unmapped")
+		}
+		return caller, nil
+	}
+	require.Equal(t, uint64(3), GetPendingNonce(2, addr1, pendingTxs, resolveUnmapped))
+
+	txObj, err := GetPendingTxObject(pendingTxs[0])
+	require.NoError(t, err)
+	require.Equal(t, eth.EncBytes(pendingTxs[0].Hash()), txObj.Hash)
+	require.Equal(t, eth.EncInt(3), txObj.Nonce)
+	require.Equal(t, eth.EncAddress(addr1.MarshalPB()), txObj.From)
+	require.Equal(t, eth.Data(""), txObj.BlockHash)
+
+	block := GetPendingBlockObject(11, []byte{1, 2, 3}, false, pendingTxs)
+	require.Equal(t, eth.EncInt(11), block.Number)
+	require.Equal(t, eth.EncBytes([]byte{1, 2, 3}), block.ParentHash)
+	require.Equal(t, eth.Data(""), block.Hash)
+	// the undecodable tx should be skipped
+	require.Len(t, block.Transactions, 4)
+	require.Equal(t, eth.EncBytes(pendingTxs[1].Hash()), block.Transactions[1])
+}
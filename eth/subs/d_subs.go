@@ -7,6 +7,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/phonkee/go-pubsub"
+	"github.com/pkg/errors"
 
 	"github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/go-loom/vm"
@@ -60,7 +61,7 @@ func (s *LegacyEthSubscriptionSet) AddSubscription(id, method, filter string) er
 	case NewPendingTransactions:
 		topics = NewPendingTransactions
 	case Syncing:
-		err = fmt.Errorf("syncing not supported")
+		topics = Syncing
 	default:
 		err = fmt.Errorf("unrecognised method %s", method)
 	}
@@ -169,3 +170,18 @@ func (s *LegacyEthSubscriptionSet) EmitBlockEvent(header abci.Header) (err error
 	}
 	return nil
 }
+
+func (s *LegacyEthSubscriptionSet) EmitSyncingEvent(status SyncStatus) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("caught panic publishing event: %v", r)
+		}
+	}()
+	emitMsg, err := encSyncingEvent(status)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode syncing event")
+	}
+	s.Reset()
+	s.Publish(pubsub.NewMessage(Syncing, emitMsg))
+	return nil
+}
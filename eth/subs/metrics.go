@@ -0,0 +1,27 @@
+package subs
+
+import (
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	subscriberCount metrics.Gauge
+	droppedMessages metrics.Counter
+)
+
+func init() {
+	subscriberCount = kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "loomchain",
+		Subsystem: "eth_subscriptions",
+		Name:      "subscribers",
+		Help:      "Number of active eth_subscribe subscriptions.",
+	}, []string{"topic"})
+	droppedMessages = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "loomchain",
+		Subsystem: "eth_subscriptions",
+		Name:      "dropped_messages",
+		Help:      "Number of subscription notifications that couldn't be queued for delivery to a client.",
+	}, []string{"topic"})
+}
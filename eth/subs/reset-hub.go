@@ -16,16 +16,27 @@ type ethResetHub struct {
 	mutex   *sync.RWMutex
 	unsent  map[string]bool
 	clients map[string]pubsub.Subscriber
+	// Subscription type (logs, newHeads, etc.) served by the hub, used to label metrics.
+	topic string
 }
 
-func newEthResetHub() *ethResetHub {
+func newEthResetHub(topic string) *ethResetHub {
 	return &ethResetHub{
 		mutex:   &sync.RWMutex{},
 		unsent:  make(map[string]bool),
 		clients: make(map[string]pubsub.Subscriber),
+		topic:   topic,
 	}
 }
 
+func (h *ethResetHub) addClient(id string, sub pubsub.Subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.clients[id] = sub
+	h.unsent[id] = true
+	subscriberCount.With("topic", h.topic).Add(1)
+}
+
 // CloseSubscriber removes subscriber from hub
 func (h *ethResetHub) CloseSubscriber(subscriber pubsub.Subscriber) {
 	panic("should never be called")
@@ -34,8 +45,12 @@ func (h *ethResetHub) CloseSubscriber(subscriber pubsub.Subscriber) {
 func (h *ethResetHub) closeSubscription(id string) {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
+	if _, ok := h.clients[id]; !ok {
+		return
+	}
 	delete(h.clients, id)
 	delete(h.unsent, id)
+	subscriberCount.With("topic", h.topic).Add(-1)
 }
 
 // Publish publishes message to subscribers
@@ -4,9 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"sync"
 
-	"github.com/gorilla/websocket"
 	"github.com/loomnetwork/loomchain/eth/utils"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/phonkee/go-pubsub"
@@ -26,17 +24,16 @@ type headsResetHub struct {
 }
 
 func newHeadsResetHub() *headsResetHub {
-	hub := newEthResetHub()
+	hub := newEthResetHub(NewHeads)
 	return &headsResetHub{
 		ethResetHub: *hub,
 	}
 }
 
-func (pt *headsResetHub) addSubscriber(conn *websocket.Conn) string {
+func (pt *headsResetHub) addSubscriber(conn *eth.WSConn) string {
 	id := utils.GetId()
 	sub := newTopicSubscriber(pt, id, NewHeads, conn)
-	pt.clients[id] = sub
-	pt.unsent[id] = true
+	pt.addClient(id, sub)
 	return id
 }
 
@@ -89,17 +86,16 @@ type pendingTxsResetHub struct {
 }
 
 func newPendingTxsResetHub() *pendingTxsResetHub {
-	hub := newEthResetHub()
+	hub := newEthResetHub(NewPendingTransactions)
 	return &pendingTxsResetHub{
 		ethResetHub: *hub,
 	}
 }
 
-func (pt *pendingTxsResetHub) addSubscriber(conn *websocket.Conn) string {
+func (pt *pendingTxsResetHub) addSubscriber(conn *eth.WSConn) string {
 	id := utils.GetId()
 	sub := newTopicSubscriber(pt, id, NewPendingTransactions, conn)
-	pt.clients[id] = sub
-	pt.unsent[id] = true
+	pt.addClient(id, sub)
 	return id
 }
 
@@ -117,20 +113,18 @@ func (pt *pendingTxsResetHub) emitTxEvent(txHash []byte) (err error) {
 
 type logsResetHub struct {
 	ethResetHub
-	lMutex *sync.RWMutex
 }
 
 func newLogsResetHubResetHub() *logsResetHub {
-	hub := newEthResetHub()
+	hub := newEthResetHub(Logs)
 	return &logsResetHub{
 		ethResetHub: *hub,
-		lMutex:      &sync.RWMutex{},
 	}
 }
 
 func (l *logsResetHub) getFilter(id string) (*eth.EthFilter, error) {
-	l.lMutex.RLock()
-	defer l.lMutex.RUnlock()
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
 	if _, ok := l.clients[id]; !ok {
 		return nil, fmt.Errorf("finding subscriber for id %s", id)
 	}
@@ -142,10 +136,68 @@ func (l *logsResetHub) getFilter(id string) (*eth.EthFilter, error) {
 	}
 }
 
-func (l *logsResetHub) addSubscriber(filter eth.EthFilter, conn *websocket.Conn) string {
+func (l *logsResetHub) addSubscriber(filter eth.EthFilter, conn *eth.WSConn) string {
 	id := utils.GetId()
 	sub := newLogSubscriber(l, id, filter, conn)
-	l.clients[id] = sub
-	l.unsent[id] = true
+	l.addClient(id, sub)
 	return id
 }
+
+// SyncStatus describes the progress of a node that's catching up to the rest of the network.
+type SyncStatus struct {
+	Syncing       bool
+	StartingBlock int64
+	CurrentBlock  int64
+	HighestBlock  int64
+}
+
+type jsonSyncingResult struct {
+	Syncing bool               `json:"syncing"`
+	Status  eth.JsonSyncStatus `json:"status"`
+}
+
+// encSyncingEvent encodes the sync status in the same format as go-ethereum, false when the node
+// isn't syncing, otherwise an object containing the sync progress.
+func encSyncingEvent(status SyncStatus) ([]byte, error) {
+	if !status.Syncing {
+		return json.Marshal(false)
+	}
+	return json.Marshal(&jsonSyncingResult{
+		Syncing: true,
+		Status: eth.JsonSyncStatus{
+			StartingBlock: eth.EncInt(status.StartingBlock),
+			CurrentBlock:  eth.EncInt(status.CurrentBlock),
+			HighestBlock:  eth.EncInt(status.HighestBlock),
+		},
+	})
+}
+
+type syncingResetHub struct {
+	ethResetHub
+}
+
+func newSyncingResetHub() *syncingResetHub {
+	hub := newEthResetHub(Syncing)
+	return &syncingResetHub{
+		ethResetHub: *hub,
+	}
+}
+
+func (sh *syncingResetHub) addSubscriber(conn *eth.WSConn) string {
+	id := utils.GetId()
+	sub := newTopicSubscriber(sh, id, Syncing, conn)
+	sh.addClient(id, sub)
+	return id
+}
+
+func (sh *syncingResetHub) emitSyncingEvent(status SyncStatus) error {
+	if len(sh.clients) > 0 {
+		emitMsg, err := encSyncingEvent(status)
+		if err != nil {
+			return errors.Wrapf(err, "json marshaling sync status %v", status)
+		}
+		sh.Reset()
+		sh.Publish(pubsub.NewMessage(Syncing, emitMsg))
+	}
+	return nil
+}
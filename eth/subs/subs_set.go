@@ -3,7 +3,6 @@ package subs
 import (
 	"fmt"
 	"github.com/gogo/protobuf/proto"
-	"github.com/gorilla/websocket"
 	"github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/phonkee/go-pubsub"
@@ -15,6 +14,7 @@ type EthSubscriptionSet struct {
 	logsHub      logsResetHub
 	newHeadsHub  headsResetHub
 	pendingTxHub pendingTxsResetHub
+	syncingHub   syncingResetHub
 }
 
 func NewEthSubscriptionSet() *EthSubscriptionSet {
@@ -22,6 +22,7 @@ func NewEthSubscriptionSet() *EthSubscriptionSet {
 		logsHub:      *newLogsResetHubResetHub(),
 		newHeadsHub:  *newHeadsResetHub(),
 		pendingTxHub: *newPendingTxsResetHub(),
+		syncingHub:   *newSyncingResetHub(),
 	}
 	return s
 }
@@ -29,7 +30,7 @@ func NewEthSubscriptionSet() *EthSubscriptionSet {
 func (s *EthSubscriptionSet) AddSubscription(
 	method string,
 	filter eth.EthFilter,
-	conn *websocket.Conn) (string, error) {
+	conn *eth.WSConn) (string, error) {
 	var id string
 	switch method {
 	case Logs:
@@ -39,10 +40,12 @@ func (s *EthSubscriptionSet) AddSubscription(
 	case NewPendingTransactions:
 		id = s.pendingTxHub.addSubscriber(conn)
 	case Syncing:
-		return "", fmt.Errorf("syncing not supported")
+		id = s.syncingHub.addSubscriber(conn)
 	default:
 		return "", fmt.Errorf("unrecognised method %s", method)
 	}
+	// Clean up the subscription when the client disconnects
+	conn.OnClose(func() { s.Remove(id) })
 	return id, nil
 }
 
@@ -54,6 +57,11 @@ func (s *EthSubscriptionSet) EmitTxEvent(txHash []byte) (err error) {
 	return s.pendingTxHub.emitTxEvent(txHash)
 }
 
+// EmitSyncingEvent publishes the current sync status of the node to the syncing subscribers.
+func (s *EthSubscriptionSet) EmitSyncingEvent(status SyncStatus) error {
+	return s.syncingHub.emitSyncingEvent(status)
+}
+
 func (s *EthSubscriptionSet) EmitEvent(data types.EventData) error {
 	ethMsg, err := proto.Marshal(&data)
 	if err != nil {
@@ -71,6 +79,7 @@ func (s *EthSubscriptionSet) Remove(id string) {
 	s.logsHub.closeSubscription(id)
 	s.newHeadsHub.closeSubscription(id)
 	s.pendingTxHub.closeSubscription(id)
+	s.syncingHub.closeSubscription(id)
 }
 
 func (s *EthSubscriptionSet) GetFilter(id string) (*eth.EthFilter, error) {
@@ -2,7 +2,6 @@ package subs
 
 import (
 	"github.com/gogo/protobuf/proto"
-	"github.com/gorilla/websocket"
 	"github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/loomchain/eth/utils"
 	"github.com/loomnetwork/loomchain/rpc/eth"
@@ -14,8 +13,8 @@ type topicSubscriber struct {
 	topic string
 }
 
-func newTopicSubscriber(hub pubsub.ResetHub, id, topic string, conn *websocket.Conn) pubsub.Subscriber {
-	wsSub := newWsSubscriber(hub, conn, id)
+func newTopicSubscriber(hub pubsub.ResetHub, id, topic string, conn *eth.WSConn) pubsub.Subscriber {
+	wsSub := newWsSubscriber(hub, conn, id, topic)
 	return topicSubscriber{
 		wsSubscriber: *wsSub,
 		topic:        topic,
@@ -31,8 +30,8 @@ type logSubscriber struct {
 	filter eth.EthBlockFilter
 }
 
-func newLogSubscriber(hub pubsub.ResetHub, id string, filter eth.EthFilter, conn *websocket.Conn) logSubscriber {
-	wsSub := newWsSubscriber(hub, conn, id)
+func newLogSubscriber(hub pubsub.ResetHub, id string, filter eth.EthFilter, conn *eth.WSConn) logSubscriber {
+	wsSub := newWsSubscriber(hub, conn, id, Logs)
 	return logSubscriber{
 		wsSubscriber: *wsSub,
 		filter:       filter.EthBlockFilter,
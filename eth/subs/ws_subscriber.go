@@ -2,12 +2,16 @@ package subs
 
 import (
 	"encoding/json"
-	"github.com/gorilla/websocket"
-	"github.com/loomnetwork/loomchain/log"
-	"github.com/phonkee/go-pubsub"
 	"sync"
+
+	"github.com/phonkee/go-pubsub"
+
+	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/rpc/eth"
 )
 
+var logger = log.Module("eth")
+
 type ethWSJsonResult struct {
 	Result       json.RawMessage `json:"result"`
 	Subscription string          `json:"subscription"`
@@ -24,10 +28,10 @@ type wsSubscriber struct {
 	mutex *sync.RWMutex
 	sf    pubsub.SubscriberFunc
 	id    string
-	conn  *websocket.Conn
+	conn  *eth.WSConn
 }
 
-func newWsSubscriber(hub pubsub.ResetHub, conn *websocket.Conn, id string) *wsSubscriber {
+func newWsSubscriber(hub pubsub.ResetHub, conn *eth.WSConn, id, topic string) *wsSubscriber {
 	sf := func(msg pubsub.Message) {
 		resp := ethWSJsonRpcResponse{
 			Params:  ethWSJsonResult{msg.Body(), id},
@@ -37,10 +41,14 @@ func newWsSubscriber(hub pubsub.ResetHub, conn *websocket.Conn, id string) *wsSu
 
 		jsonBytes, err := json.MarshalIndent(resp, "", "  ")
 		if err != nil {
-			log.Error("error %v marshalling event %v, id %s", err, msg, id)
+			logger.Error("Failed to marshal subscription event", "id", id, "err", err)
+			return
 		}
-		if err := conn.WriteMessage(websocket.TextMessage, jsonBytes); err != nil {
-			log.Error("error %v writing event %v to websocket, id %s ", err, jsonBytes, id)
+		// This doesn't block, the message is queued & written to the client by the writer goroutine
+		// of the connection.
+		if err := conn.Send(jsonBytes); err != nil {
+			droppedMessages.With("topic", topic).Add(1)
+			logger.Debug("Failed to send subscription event", "id", id, "err", err)
 		}
 	}
 
@@ -4,6 +4,11 @@ const (
 	DispatcherDBIndexer = "db_indexer"
 	DispatcherRedis     = "redis"
 	DispatcherLog       = "log"
+	DispatcherStream    = "stream"
+
+	EventSinkRedis = "redis"
+	EventSinkKafka = "kafka"
+	EventSinkNATS  = "nats"
 )
 
 type EventStoreConfig struct {
@@ -33,6 +38,7 @@ func (c *EventStoreConfig) Clone() *EventStoreConfig {
 type EventDispatcherConfig struct {
 	Dispatcher string
 	Redis      *RedisEventDispatcherConfig
+	Stream     *StreamEventDispatcherConfig
 }
 
 func DefaultEventDispatcherConfig() *EventDispatcherConfig {
@@ -41,6 +47,72 @@ func DefaultEventDispatcherConfig() *EventDispatcherConfig {
 		Redis: &RedisEventDispatcherConfig{
 			URI: "127.0.0.1",
 		},
+		Stream: DefaultStreamEventDispatcherConfig(),
+	}
+}
+
+type StreamEventDispatcherConfig struct {
+	// Sink specifies where events should be streamed to: "redis" | "kafka" | "nats".
+	// The connection to Redis is configured via the Redis section of the EventDispatcher config,
+	// the connections to Kafka & NATS are configured via the Kafka & NATS sections below.
+	Sink  string
+	Kafka *KafkaEventSinkConfig
+	NATS  *NATSEventSinkConfig
+	// OutboxDBName defines the name of the DB events are stored in until they're delivered
+	OutboxDBName string
+	// OutboxDBBackend defines the backend type of the outbox DB,
+	// available backend types are 'goleveldb', or 'cleveldb'
+	OutboxDBBackend string
+	// Number of blocks worth of delivered events that should be retained in the outbox,
+	// zero means delivered events are never deleted.
+	RetainBlocks uint64
+	// If non-zero all the events from this block height onwards that are still in the outbox will
+	// be redelivered when the node starts. Each resume height is only applied once, so restarting
+	// the node with the same setting doesn't redeliver the events again.
+	ResumeFromHeight uint64
+	// Minimum & maximum delay (in milliseconds) between attempts to deliver events to the sink
+	MinRetryDelayMs int64
+	MaxRetryDelayMs int64
+}
+
+func DefaultStreamEventDispatcherConfig() *StreamEventDispatcherConfig {
+	return &StreamEventDispatcherConfig{
+		Sink:            EventSinkRedis,
+		OutboxDBName:    "event_outbox",
+		OutboxDBBackend: "goleveldb",
+		RetainBlocks:    10000,
+		MinRetryDelayMs: 500,
+		MaxRetryDelayMs: 60000,
+		Kafka:           DefaultKafkaEventSinkConfig(),
+		NATS:            DefaultNATSEventSinkConfig(),
+	}
+}
+
+type KafkaEventSinkConfig struct {
+	// Addresses (host:port) of the Kafka brokers to connect to
+	Brokers []string
+	// Topic events should be published to
+	Topic string
+}
+
+func DefaultKafkaEventSinkConfig() *KafkaEventSinkConfig {
+	return &KafkaEventSinkConfig{
+		Brokers: []string{"127.0.0.1:9092"},
+		Topic:   "loomevents",
+	}
+}
+
+type NATSEventSinkConfig struct {
+	// URL of the NATS server to connect to
+	URL string
+	// Events are published to <Subject>.<block height>.<event index>
+	Subject string
+}
+
+func DefaultNATSEventSinkConfig() *NATSEventSinkConfig {
+	return &NATSEventSinkConfig{
+		URL:     "nats://127.0.0.1:4222",
+		Subject: "loomevents",
 	}
 }
 
@@ -51,5 +123,18 @@ func (c *EventDispatcherConfig) Clone() *EventDispatcherConfig {
 	}
 	clone := *c
 	*clone.Redis = *c.Redis
+	if c.Stream != nil {
+		stream := *c.Stream
+		if c.Stream.Kafka != nil {
+			kafka := *c.Stream.Kafka
+			kafka.Brokers = append([]string(nil), c.Stream.Kafka.Brokers...)
+			stream.Kafka = &kafka
+		}
+		if c.Stream.NATS != nil {
+			nats := *c.Stream.NATS
+			stream.NATS = &nats
+		}
+		clone.Stream = &stream
+	}
 	return &clone
 }
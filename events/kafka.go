@@ -0,0 +1,65 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+)
+
+// KafkaEventSink publishes events to a Kafka topic. The key of each message is the block height &
+// the index of the event within the block, e.g. "1234:2", so consumers can detect redelivered
+// events. Messages are partitioned by key, so events are only consumed in order if the topic has a
+// single partition.
+type KafkaEventSink struct {
+	cfg      *KafkaEventSinkConfig
+	producer sarama.SyncProducer
+}
+
+var _ StreamEventSink = &KafkaEventSink{}
+
+// NewKafkaEventSink creates a new sink that will connect to the given Kafka brokers.
+func NewKafkaEventSink(cfg *KafkaEventSinkConfig) *KafkaEventSink {
+	return &KafkaEventSink{cfg: cfg}
+}
+
+func (s *KafkaEventSink) Connect() error {
+	cfg := sarama.NewConfig()
+	cfg.Net.DialTimeout = 10 * time.Second
+	cfg.Net.ReadTimeout = 10 * time.Second
+	cfg.Net.WriteTimeout = 10 * time.Second
+	// An event is only considered delivered once all the in-sync replicas have it, retries are
+	// handled by the dispatcher.
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.Retry.Max = 0
+	cfg.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(s.cfg.Brokers, cfg)
+	if err != nil {
+		return err
+	}
+	s.producer = producer
+	return nil
+}
+
+func (s *KafkaEventSink) Publish(event *StreamEvent) error {
+	if s.producer == nil {
+		return errors.New("not connected")
+	}
+	_, _, err := s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: s.cfg.Topic,
+		Key:   sarama.StringEncoder(fmt.Sprintf("%d:%d", event.BlockHeight, event.EventIndex)),
+		Value: sarama.ByteEncoder(event.Data),
+	})
+	return err
+}
+
+func (s *KafkaEventSink) Close() error {
+	if s.producer == nil {
+		return nil
+	}
+	err := s.producer.Close()
+	s.producer = nil
+	return err
+}
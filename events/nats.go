@@ -0,0 +1,57 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	nats "github.com/nats-io/go-nats"
+	"github.com/pkg/errors"
+)
+
+// NATSEventSink publishes events to a NATS server. Each event is published to a subject that ends
+// with the block height & the index of the event within the block, e.g. "loomevents.1234.2", so
+// consumers should subscribe to "loomevents.>", and can use the subject to detect redelivered
+// events.
+type NATSEventSink struct {
+	cfg  *NATSEventSinkConfig
+	conn *nats.Conn
+}
+
+var _ StreamEventSink = &NATSEventSink{}
+
+// NewNATSEventSink creates a new sink that will connect to the given NATS server.
+func NewNATSEventSink(cfg *NATSEventSinkConfig) *NATSEventSink {
+	return &NATSEventSink{cfg: cfg}
+}
+
+func (s *NATSEventSink) Connect() error {
+	// Reconnects are handled by the dispatcher, so that events published while the connection is
+	// down aren't silently buffered by the client.
+	conn, err := nats.Connect(s.cfg.URL, nats.Timeout(10*time.Second), nats.NoReconnect())
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *NATSEventSink) Publish(event *StreamEvent) error {
+	if s.conn == nil {
+		return errors.New("not connected")
+	}
+	subject := fmt.Sprintf("%s.%d.%d", s.cfg.Subject, event.BlockHeight, event.EventIndex)
+	if err := s.conn.Publish(subject, event.Data); err != nil {
+		return err
+	}
+	// Wait for the server to acknowledge it has received everything published so far.
+	return s.conn.FlushTimeout(10 * time.Second)
+}
+
+func (s *NATSEventSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	s.conn.Close()
+	s.conn = nil
+	return nil
+}
@@ -1,8 +1,12 @@
 package events
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/loomnetwork/loomchain"
 	log "github.com/loomnetwork/loomchain/log"
+	"github.com/pkg/errors"
 
 	"github.com/gomodule/redigo/redis"
 )
@@ -43,3 +47,60 @@ func (ed *RedisEventDispatcher) Send(index uint64, eventIndex int, msg []byte) e
 
 func (ed *RedisEventDispatcher) Flush() {
 }
+
+// RedisEventSink publishes events to a sorted set in Redis, the score of each event is the height
+// of the block the event was emitted in. Each member is the event data prefixed by the block height
+// & the (zero padded) index of the event within the block, e.g. "1234:0000000002:<data>", so events
+// with identical data are kept separately, and events from the same block are ordered by index.
+// Since the sorted set doesn't allow duplicate members redelivered events are effectively ignored.
+type RedisEventSink struct {
+	uri   string
+	queue string
+	conn  redis.Conn
+}
+
+var _ StreamEventSink = &RedisEventSink{}
+
+// NewRedisEventSink creates a new sink that will connect to the Redis server at the given URI.
+func NewRedisEventSink(uri string) *RedisEventSink {
+	return &RedisEventSink{
+		uri:   uri,
+		queue: "loomevents",
+	}
+}
+
+func (s *RedisEventSink) Connect() error {
+	conn, err := redis.DialURL(
+		s.uri,
+		redis.DialConnectTimeout(10*time.Second),
+		redis.DialReadTimeout(10*time.Second),
+		redis.DialWriteTimeout(10*time.Second),
+	)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *RedisEventSink) Publish(event *StreamEvent) error {
+	if s.conn == nil {
+		return errors.New("not connected")
+	}
+	_, err := s.conn.Do("ZADD", s.queue, event.BlockHeight, redisEventMember(event))
+	return err
+}
+
+func redisEventMember(event *StreamEvent) []byte {
+	prefix := fmt.Sprintf("%d:%010d:", event.BlockHeight, event.EventIndex)
+	return append([]byte(prefix), event.Data...)
+}
+
+func (s *RedisEventSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
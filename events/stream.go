@@ -0,0 +1,317 @@
+package events
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/pkg/errors"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/log"
+)
+
+var (
+	outboxPrefix = []byte("outbox:")
+	cursorKey    = []byte("cursor")
+	// stores the last ResumeFromHeight that was applied to the cursor
+	resumeHeightKey = []byte("resume-height")
+
+	streamEventsDelivered metrics.Counter
+	streamDeliveryErrors  metrics.Counter
+)
+
+func init() {
+	const namespace = "loomchain"
+	const subsystem = "stream_event_dispatcher"
+
+	streamEventsDelivered = kitprometheus.NewCounterFrom(
+		stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "events_delivered",
+			Help:      "Number of events delivered to the event sink.",
+		}, []string{},
+	)
+	streamDeliveryErrors = kitprometheus.NewCounterFrom(
+		stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "delivery_errors",
+			Help:      "Number of times the delivery of an event to the event sink failed.",
+		}, []string{},
+	)
+}
+
+// StreamEvent is an event that's delivered to a StreamEventSink.
+type StreamEvent struct {
+	BlockHeight uint64
+	EventIndex  uint32
+	Data        []byte
+}
+
+// StreamEventSink delivers events to a downstream message broker.
+// Events may be delivered more than once (e.g. if the node is restarted before it records the
+// delivery), so sinks should publish events in a way that allows consumers to detect duplicates,
+// the block height & event index uniquely identify each event.
+type StreamEventSink interface {
+	// Connect establishes a connection to the broker. It's called before the first event is
+	// published, and again after any failure.
+	Connect() error
+	Publish(event *StreamEvent) error
+	Close() error
+}
+
+// StreamEventDispatcher streams events to an event sink with at-least-once delivery semantics.
+// Events are written to a durable local outbox when each block is flushed, and then delivered to
+// the sink by a background goroutine that retries failed deliveries with an exponential backoff,
+// reconnecting to the sink as needed. The position of the last delivered event is persisted, so
+// delivery resumes where it left off when the node is restarted.
+type StreamEventDispatcher struct {
+	db   dbm.DB
+	sink StreamEventSink
+	cfg  *StreamEventDispatcherConfig
+
+	mutex sync.Mutex
+	batch dbm.Batch
+
+	connected bool
+	notifyCh  chan struct{}
+	quitCh    chan struct{}
+	doneCh    chan struct{}
+	stopOnce  sync.Once
+}
+
+var _ loomchain.EventDispatcher = &StreamEventDispatcher{}
+
+// NewStreamEventDispatcher creates a new dispatcher that uses the given DB as the outbox, and
+// starts delivering any undelivered events in the outbox to the given sink.
+func NewStreamEventDispatcher(
+	db dbm.DB, sink StreamEventSink, cfg *StreamEventDispatcherConfig,
+) (*StreamEventDispatcher, error) {
+	if cfg == nil {
+		cfg = DefaultStreamEventDispatcherConfig()
+	}
+	if cfg.MinRetryDelayMs <= 0 || cfg.MaxRetryDelayMs < cfg.MinRetryDelayMs {
+		return nil, errors.New("invalid retry delay")
+	}
+
+	d := &StreamEventDispatcher{
+		db:       db,
+		sink:     sink,
+		cfg:      cfg,
+		batch:    db.NewBatch(),
+		notifyCh: make(chan struct{}, 1),
+		quitCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+
+	// The resume height is only applied once, otherwise every restart would rewind the cursor and
+	// redeliver the same events until the setting is removed from the config.
+	if cfg.ResumeFromHeight > 0 && cfg.ResumeFromHeight != d.loadResumeHeight() {
+		if oldest, ok := d.oldestEventHeight(); ok && oldest > cfg.ResumeFromHeight {
+			log.Warn(
+				"Outbox doesn't contain events from resume height",
+				"resumeFromHeight", cfg.ResumeFromHeight, "oldestHeight", oldest,
+			)
+		}
+		d.saveCursor(outboxKey(cfg.ResumeFromHeight, 0))
+		d.saveResumeHeight(cfg.ResumeFromHeight)
+	}
+
+	go d.run()
+	d.notify()
+	return d, nil
+}
+
+// Send adds the event to the outbox, the event will be persisted when Flush is called.
+func (d *StreamEventDispatcher) Send(blockHeight uint64, eventIndex int, msg []byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.batch.Set(outboxKey(blockHeight, uint32(eventIndex)), msg)
+	return nil
+}
+
+// Flush persists all the events sent since the last flush, and wakes up the delivery goroutine.
+func (d *StreamEventDispatcher) Flush() {
+	d.mutex.Lock()
+	d.batch.WriteSync()
+	d.batch = d.db.NewBatch()
+	d.mutex.Unlock()
+
+	d.notify()
+}
+
+// Stop stops delivering events, and closes the connection to the sink. Events sent after the
+// dispatcher is stopped are still added to the outbox, and will be delivered after a restart.
+func (d *StreamEventDispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.quitCh)
+		<-d.doneCh
+		d.disconnect()
+	})
+}
+
+func (d *StreamEventDispatcher) notify() {
+	select {
+	case d.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (d *StreamEventDispatcher) run() {
+	defer close(d.doneCh)
+
+	minDelay := time.Duration(d.cfg.MinRetryDelayMs) * time.Millisecond
+	maxDelay := time.Duration(d.cfg.MaxRetryDelayMs) * time.Millisecond
+	delay := minDelay
+	for {
+		if err := d.deliverPending(); err != nil {
+			streamDeliveryErrors.Add(1)
+			log.Error("Failed to deliver events", "err", err, "retryIn", delay)
+			d.disconnect()
+
+			select {
+			case <-time.After(delay):
+			case <-d.quitCh:
+				return
+			}
+			delay *= 2
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+			continue
+		}
+		delay = minDelay
+
+		select {
+		case <-d.notifyCh:
+		case <-d.quitCh:
+			return
+		}
+	}
+}
+
+// deliverPending delivers all the events in the outbox that haven't been delivered yet, and
+// prunes events that have been delivered.
+func (d *StreamEventDispatcher) deliverPending() error {
+	start := d.loadCursor()
+	it := d.db.Iterator(start, prefixEnd(outboxPrefix))
+	defer it.Close()
+
+	var lastHeight uint64
+	for ; it.Valid(); it.Next() {
+		select {
+		case <-d.quitCh:
+			return nil
+		default:
+		}
+
+		height, index := parseOutboxKey(it.Key())
+		if !d.connected {
+			if err := d.sink.Connect(); err != nil {
+				return errors.Wrap(err, "failed to connect to event sink")
+			}
+			d.connected = true
+		}
+		err := d.sink.Publish(&StreamEvent{
+			BlockHeight: height,
+			EventIndex:  index,
+			Data:        it.Value(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to publish event %d:%d", height, index)
+		}
+		streamEventsDelivered.Add(1)
+		d.saveCursor(outboxKey(height, index+1))
+		lastHeight = height
+	}
+
+	if lastHeight > 0 && d.cfg.RetainBlocks > 0 && lastHeight > d.cfg.RetainBlocks {
+		d.prune(lastHeight - d.cfg.RetainBlocks)
+	}
+	return nil
+}
+
+func (d *StreamEventDispatcher) disconnect() {
+	if !d.connected {
+		return
+	}
+	if err := d.sink.Close(); err != nil {
+		log.Error("Failed to close event sink", "err", err)
+	}
+	d.connected = false
+}
+
+// prune deletes all events below the given height from the outbox.
+func (d *StreamEventDispatcher) prune(height uint64) {
+	it := d.db.Iterator(outboxPrefix, outboxKey(height, 0))
+	defer it.Close()
+
+	batch := d.db.NewBatch()
+	for ; it.Valid(); it.Next() {
+		batch.Delete(it.Key())
+	}
+	batch.Write()
+}
+
+func (d *StreamEventDispatcher) oldestEventHeight() (uint64, bool) {
+	it := d.db.Iterator(outboxPrefix, prefixEnd(outboxPrefix))
+	defer it.Close()
+	if !it.Valid() {
+		return 0, false
+	}
+	height, _ := parseOutboxKey(it.Key())
+	return height, true
+}
+
+// loadCursor returns the outbox key of the next event that should be delivered.
+func (d *StreamEventDispatcher) loadCursor() []byte {
+	if cursor := d.db.Get(cursorKey); cursor != nil {
+		return cursor
+	}
+	return outboxPrefix
+}
+
+func (d *StreamEventDispatcher) saveCursor(key []byte) {
+	d.db.Set(cursorKey, key)
+}
+
+func (d *StreamEventDispatcher) loadResumeHeight() uint64 {
+	if b := d.db.Get(resumeHeightKey); len(b) == 8 {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (d *StreamEventDispatcher) saveResumeHeight(height uint64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+	d.db.Set(resumeHeightKey, b)
+}
+
+func outboxKey(blockHeight uint64, eventIndex uint32) []byte {
+	key := make([]byte, len(outboxPrefix)+12)
+	copy(key, outboxPrefix)
+	binary.BigEndian.PutUint64(key[len(outboxPrefix):], blockHeight)
+	binary.BigEndian.PutUint32(key[len(outboxPrefix)+8:], eventIndex)
+	return key
+}
+
+func parseOutboxKey(key []byte) (uint64, uint32) {
+	key = key[len(outboxPrefix):]
+	return binary.BigEndian.Uint64(key), binary.BigEndian.Uint32(key[8:])
+}
+
+// Returns the bytes that mark the end of the key range for the given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	end[len(end)-1]++
+	return end
+}
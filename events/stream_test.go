@@ -0,0 +1,188 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// fakeBroker is an in-process stand-in for a message broker that can be made to fail on demand.
+type fakeBroker struct {
+	mutex         sync.Mutex
+	connected     bool
+	failConnects  int
+	failPublishes int
+	connects      int
+	events        []*StreamEvent
+}
+
+func (b *fakeBroker) Connect() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.failConnects > 0 {
+		b.failConnects--
+		return errors.New("connection refused")
+	}
+	b.connects++
+	b.connected = true
+	return nil
+}
+
+func (b *fakeBroker) Publish(event *StreamEvent) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.connected {
+		return errors.New("not connected")
+	}
+	if b.failPublishes > 0 {
+		b.failPublishes--
+		b.connected = false
+		return errors.New("connection reset")
+	}
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *fakeBroker) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.connected = false
+	return nil
+}
+
+func (b *fakeBroker) received() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ids := make([]string, len(b.events))
+	for i, e := range b.events {
+		ids[i] = fmt.Sprintf("%d:%d:%s", e.BlockHeight, e.EventIndex, e.Data)
+	}
+	return ids
+}
+
+func waitForEvents(t *testing.T, broker *fakeBroker, count int) []string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if ids := broker.received(); len(ids) >= count {
+			return ids
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.FailNow(t, "timed out waiting for events", "received %v", broker.received())
+	return nil
+}
+
+func testStreamConfig() *StreamEventDispatcherConfig {
+	cfg := DefaultStreamEventDispatcherConfig()
+	cfg.RetainBlocks = 0
+	cfg.MinRetryDelayMs = 1
+	cfg.MaxRetryDelayMs = 10
+	return cfg
+}
+
+func sendBlock(t *testing.T, d *StreamEventDispatcher, height uint64, msgs ...string) {
+	for i, msg := range msgs {
+		require.NoError(t, d.Send(height, i, []byte(msg)))
+	}
+	d.Flush()
+}
+
+func TestStreamEventDispatcherRetries(t *testing.T) {
+	broker := &fakeBroker{failConnects: 2, failPublishes: 1}
+	d, err := NewStreamEventDispatcher(dbm.NewMemDB(), broker, testStreamConfig())
+	require.NoError(t, err)
+	defer d.Stop()
+
+	sendBlock(t, d, 1, "a", "b")
+	sendBlock(t, d, 2)
+	sendBlock(t, d, 3, "c")
+
+	ids := waitForEvents(t, broker, 3)
+	require.Equal(t, []string{"1:0:a", "1:1:b", "3:0:c"}, ids)
+	// should've reconnected after the failed publish
+	require.Equal(t, 2, broker.connects)
+}
+
+func TestStreamEventDispatcherResume(t *testing.T) {
+	db := dbm.NewMemDB()
+	broker := &fakeBroker{}
+	d, err := NewStreamEventDispatcher(db, broker, testStreamConfig())
+	require.NoError(t, err)
+	sendBlock(t, d, 1, "a")
+	sendBlock(t, d, 2, "b")
+	sendBlock(t, d, 3, "c")
+	waitForEvents(t, broker, 3)
+	d.Stop()
+
+	// events that were already delivered shouldn't be delivered again after a restart,
+	// but any new events should be
+	broker = &fakeBroker{}
+	d, err = NewStreamEventDispatcher(db, broker, testStreamConfig())
+	require.NoError(t, err)
+	sendBlock(t, d, 4, "d")
+	require.Equal(t, []string{"4:0:d"}, waitForEvents(t, broker, 1))
+	d.Stop()
+
+	// all events from the resume height onwards should be redelivered
+	broker = &fakeBroker{}
+	cfg := testStreamConfig()
+	cfg.ResumeFromHeight = 2
+	d, err = NewStreamEventDispatcher(db, broker, cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2:0:b", "3:0:c", "4:0:d"}, waitForEvents(t, broker, 3))
+	d.Stop()
+
+	// restarting with the same resume height shouldn't redeliver the events again
+	broker = &fakeBroker{}
+	d, err = NewStreamEventDispatcher(db, broker, cfg)
+	require.NoError(t, err)
+	defer d.Stop()
+	sendBlock(t, d, 5, "e")
+	require.Equal(t, []string{"5:0:e"}, waitForEvents(t, broker, 1))
+}
+
+func TestStreamEventDispatcherPruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	broker := &fakeBroker{}
+	cfg := testStreamConfig()
+	cfg.RetainBlocks = 2
+	d, err := NewStreamEventDispatcher(db, broker, cfg)
+	require.NoError(t, err)
+
+	for h := uint64(1); h <= 5; h++ {
+		sendBlock(t, d, h, fmt.Sprintf("e%d", h))
+	}
+	waitForEvents(t, broker, 5)
+	d.Stop()
+
+	oldest, ok := d.oldestEventHeight()
+	require.True(t, ok)
+	require.Equal(t, uint64(3), oldest)
+}
+
+func TestStreamEventDispatcherStopTwice(t *testing.T) {
+	broker := &fakeBroker{}
+	d, err := NewStreamEventDispatcher(dbm.NewMemDB(), broker, testStreamConfig())
+	require.NoError(t, err)
+
+	sendBlock(t, d, 1, "a")
+	waitForEvents(t, broker, 1)
+	d.Stop()
+	d.Stop()
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	require.False(t, broker.connected)
+}
+
+func TestRedisEventMember(t *testing.T) {
+	a := redisEventMember(&StreamEvent{BlockHeight: 12, EventIndex: 2, Data: []byte("data")})
+	b := redisEventMember(&StreamEvent{BlockHeight: 12, EventIndex: 10, Data: []byte("data")})
+	require.Equal(t, "12:0000000002:data", string(a))
+	// identical events from the same block must be kept separately, in order
+	require.True(t, string(a) < string(b))
+}
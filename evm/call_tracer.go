@@ -0,0 +1,206 @@
+// +build evm
+
+package evm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/pkg/errors"
+)
+
+// CallFrame is a single call in the call tree produced by the call tracer.
+type CallFrame struct {
+	Type    string       `json:"type"`
+	From    string       `json:"from"`
+	To      string       `json:"to,omitempty"`
+	Value   string       `json:"value,omitempty"`
+	Gas     string       `json:"gas"`
+	GasUsed string       `json:"gasUsed"`
+	Input   string       `json:"input"`
+	Output  string       `json:"output,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Calls   []*CallFrame `json:"calls,omitempty"`
+
+	// Bookkeeping used while the call is in progress
+	depth  int
+	gasIn  uint64
+	outOff uint64
+	outLen uint64
+	isCall bool
+}
+
+// callTracer implements vm.Tracer, it reconstructs the tree of calls made by a tx from the opcodes
+// executed by the EVM, in the same way as the go-ethereum JS callTracer.
+type callTracer struct {
+	root      *CallFrame
+	callstack []*CallFrame
+}
+
+var _ vm.Tracer = &callTracer{}
+
+func newCallTracer() *callTracer {
+	return &callTracer{}
+}
+
+func (t *callTracer) CaptureStart(
+	from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int,
+) error {
+	t.root = &CallFrame{
+		Type:  "CALL",
+		From:  from.Hex(),
+		To:    to.Hex(),
+		Value: encodeBig(value),
+		Gas:   hexutil.EncodeUint64(gas),
+		Input: hexutil.Encode(input),
+		depth: 0,
+		gasIn: gas,
+	}
+	if create {
+		t.root.Type = "CREATE"
+	}
+	t.callstack = []*CallFrame{t.root}
+	return nil
+}
+
+func (t *callTracer) CaptureState(
+	env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack,
+	contract *vm.Contract, depth int, err error,
+) error {
+	if t.root == nil {
+		return nil
+	}
+
+	// Any calls made at the same or higher depth than the current opcode have returned, this also
+	// covers calls that never entered the callee (e.g. precompiles, or calls that failed before any
+	// code could be executed).
+	for len(t.callstack) > 1 && t.callstack[len(t.callstack)-1].depth >= depth {
+		frame := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+		t.finalizeFrame(frame, gas, memory, stack)
+	}
+
+	if err != nil {
+		return nil
+	}
+
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		inOff := stack.Back(1).Uint64()
+		inLen := stack.Back(2).Uint64()
+		t.pushFrame(&CallFrame{
+			Type:  op.String(),
+			From:  contract.Address().Hex(),
+			Value: encodeBig(stack.Back(0)),
+			Gas:   hexutil.EncodeUint64(cost),
+			Input: hexutil.Encode(memory.GetCopy(int64(inOff), int64(inLen))),
+			depth: depth,
+			gasIn: gas,
+		})
+
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		frame := &CallFrame{
+			Type:   op.String(),
+			From:   contract.Address().Hex(),
+			To:     common.BigToAddress(stack.Back(1)).Hex(),
+			Gas:    hexutil.EncodeUint64(cost),
+			depth:  depth,
+			gasIn:  gas,
+			outOff: stack.Back(4 + off).Uint64(),
+			outLen: stack.Back(5 + off).Uint64(),
+			isCall: true,
+		}
+		inOff := stack.Back(2 + off).Uint64()
+		inLen := stack.Back(3 + off).Uint64()
+		frame.Input = hexutil.Encode(memory.GetCopy(int64(inOff), int64(inLen)))
+		if off == 1 {
+			frame.Value = encodeBig(stack.Back(2))
+		}
+		t.pushFrame(frame)
+	}
+	return nil
+}
+
+func (t *callTracer) pushFrame(frame *CallFrame) {
+	parent := t.callstack[len(t.callstack)-1]
+	parent.Calls = append(parent.Calls, frame)
+	t.callstack = append(t.callstack, frame)
+}
+
+// finalizeFrame fills in the results of a call once the caller resumes execution, at which point
+// the top of the stack contains the result of the call.
+func (t *callTracer) finalizeFrame(frame *CallFrame, gas uint64, memory *vm.Memory, stack *vm.Stack) {
+	if frame.gasIn > gas {
+		frame.GasUsed = hexutil.EncodeUint64(frame.gasIn - gas)
+	} else {
+		frame.GasUsed = hexutil.EncodeUint64(0)
+	}
+
+	result := stack.Back(0)
+	if frame.isCall {
+		if result.Sign() == 0 && frame.Error == "" {
+			frame.Error = "internal failure"
+		}
+		if frame.outLen > 0 {
+			frame.Output = hexutil.Encode(memory.GetCopy(int64(frame.outOff), int64(frame.outLen)))
+		}
+		return
+	}
+
+	if result.Sign() == 0 {
+		if frame.Error == "" {
+			frame.Error = "internal failure"
+		}
+		return
+	}
+	frame.To = common.BigToAddress(result).Hex()
+}
+
+func (t *callTracer) CaptureFault(
+	env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack,
+	contract *vm.Contract, depth int, err error,
+) error {
+	if t.root == nil || err == nil {
+		return nil
+	}
+	// Attribute the error to the innermost call executing at the depth of the fault.
+	for i := len(t.callstack) - 1; i >= 0; i-- {
+		frame := t.callstack[i]
+		if frame == t.root || frame.depth < depth {
+			if frame.Error == "" {
+				frame.Error = err.Error()
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	t.root.GasUsed = hexutil.EncodeUint64(gasUsed)
+	t.root.Output = hexutil.Encode(output)
+	if err != nil {
+		t.root.Error = err.Error()
+		if err.Error() == errExecutionRevertedMsg {
+			t.root.Error = "execution reverted"
+		}
+	}
+	return nil
+}
+
+func (t *callTracer) result() (*CallFrame, error) {
+	if t.root == nil {
+		return nil, errors.New("call tracer didn't capture any calls")
+	}
+	return t.root, nil
+}
@@ -0,0 +1,54 @@
+package evm
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+// Function selector of Error(string), which Solidity uses to encode revert reasons.
+var revertReasonSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// ExecutionRevertedError is returned when the EVM reverts a call or contract deployment.
+type ExecutionRevertedError struct {
+	// Reason is the revert reason decoded from Data, empty if Data doesn't contain a reason.
+	Reason string
+	// Data is the raw output of the reverted call.
+	Data []byte
+}
+
+func (e *ExecutionRevertedError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return fmt.Sprintf("execution reverted: %s", e.Reason)
+}
+
+// OutOfGasError is returned when a call or contract deployment can't be completed within the
+// available gas limit.
+type OutOfGasError struct {
+	GasLimit uint64
+}
+
+func (e *OutOfGasError) Error() string {
+	return fmt.Sprintf("gas required exceeds allowance (%d)", e.GasLimit)
+}
+
+// UnpackRevertReason extracts the revert reason string from the output of a reverted call,
+// returns an empty string if the output doesn't contain an ABI encoded Error(string).
+func UnpackRevertReason(data []byte) string {
+	if len(data) < len(revertReasonSelector)+64 || !bytes.Equal(data[:4], revertReasonSelector) {
+		return ""
+	}
+	data = data[4:]
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return ""
+	}
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(data[start-32 : start])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data))-start {
+		return ""
+	}
+	return string(data[start : start+length.Uint64()])
+}
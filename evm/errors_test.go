@@ -0,0 +1,29 @@
+package evm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackRevertReason(t *testing.T) {
+	// Output of require(false, "not enough funds")
+	data, err := hex.DecodeString(
+		"08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000010" +
+			"6e6f7420656e6f7567682066756e647300000000000000000000000000000000",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "not enough funds", UnpackRevertReason(data))
+
+	// Output of revert() without a reason
+	require.Equal(t, "", UnpackRevertReason(nil))
+	// Different selector
+	data[0] = 0xff
+	require.Equal(t, "", UnpackRevertReason(data))
+	// Truncated output
+	data[0] = 0x08
+	require.Equal(t, "", UnpackRevertReason(data[:len(data)-20]))
+}
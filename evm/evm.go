@@ -255,6 +255,23 @@ func (e Evm) StaticCall(caller, addr loom.Address, input []byte) ([]byte, error)
 	return ret, err
 }
 
+// callWithGas executes a call with the given gas limit, returns the output of the call and the
+// amount of gas left over.
+func (e Evm) callWithGas(caller, addr loom.Address, input []byte, value *big.Int, gas uint64) ([]byte, uint64, error) {
+	origin := common.BytesToAddress(caller.Local)
+	vmenv := e.NewEnv(origin)
+	return vmenv.Call(vm.AccountRef(origin), common.BytesToAddress(addr.Local), input, gas, value)
+}
+
+// createWithGas deploys a contract with the given gas limit, returns the output of the constructor
+// and the amount of gas left over.
+func (e Evm) createWithGas(caller loom.Address, code []byte, value *big.Int, gas uint64) ([]byte, uint64, error) {
+	origin := common.BytesToAddress(caller.Local)
+	vmenv := e.NewEnv(origin)
+	ret, _, leftOverGas, err := vmenv.Create(vm.AccountRef(origin), code, gas, value)
+	return ret, leftOverGas, err
+}
+
 func (e Evm) GetCode(addr loom.Address) []byte {
 	return e.sdb.GetCode(common.BytesToAddress(addr.Local))
 }
@@ -309,7 +326,7 @@ func defaultVmConfig(evmDebuggingEnabled bool) vm.Config {
 	debug := false
 
 	if evmDebuggingEnabled {
-		log.Error("WARNING!!!! EVM Debug mode enabled, do NOT run this on a production server!!!")
+		log.Module("evm").Error("WARNING!!!! EVM Debug mode enabled, do NOT run this on a production server!!!")
 		logCfg = vm.LogConfig{
 			DisableMemory:  true, // disable memory capture
 			DisableStack:   true, // disable stack capture
@@ -0,0 +1,98 @@
+// +build evm
+
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/loomnetwork/go-loom"
+	"github.com/pkg/errors"
+)
+
+// go-ethereum doesn't export the error the interpreter returns when a call is reverted.
+const errExecutionRevertedMsg = "evm: execution reverted"
+
+// EstimateGas returns the lowest gas limit at which the given call succeeds. If contract is nil
+// the input is treated as contract creation code. Every execution is performed against a fresh
+// state obtained from newState, and none of the changes made to that state are committed, so
+// newState should return throwaway states (see loomchain.NewThrowawayState).
+// If gasCap is zero the call will be allowed to consume an unlimited amount of gas.
+func EstimateGas(
+	newState NewThrowawayStateFunc,
+	caller loom.Address, contract *loom.Address, input []byte, value *loom.BigUInt,
+	gasCap uint64,
+) (uint64, error) {
+	val := common.Big0
+	if value != nil && value.Int != nil {
+		val = value.Int
+	}
+	if val.Sign() < 0 {
+		return 0, errors.Errorf("value %v must be non negative", value)
+	}
+	if gasCap == 0 {
+		gasCap = defaultGasLimit
+	}
+
+	execute := func(gas uint64) (uint64, error) {
+		state, createABM, err := newState()
+		if err != nil {
+			return 0, err
+		}
+		var abm AccountBalanceManager
+		if createABM != nil {
+			abm = createABM(false)
+		}
+		levm, err := NewLoomEvm(state, abm, nil, false)
+		if err != nil {
+			return 0, err
+		}
+		var ret []byte
+		var leftOverGas uint64
+		if contract == nil {
+			ret, leftOverGas, err = levm.createWithGas(caller, input, val, gas)
+		} else {
+			ret, leftOverGas, err = levm.callWithGas(caller, *contract, input, val, gas)
+		}
+		if err != nil {
+			return 0, toExecutionError(err, ret, gas)
+		}
+		return gas - leftOverGas, nil
+	}
+
+	usedGas, err := execute(gasCap)
+	if err != nil {
+		return 0, err
+	}
+	if usedGas == 0 {
+		return 0, nil
+	}
+	if _, err := execute(usedGas); err == nil {
+		return usedGas, nil
+	}
+
+	// Due to the 63/64 rule (EIP-150) & gas refunds a call may need a higher gas limit than the
+	// amount of gas it ends up using, so look for the lowest gas limit at which it doesn't fail.
+	lo, hi := usedGas, gasCap
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		if _, err := execute(mid); err != nil {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
+
+func toExecutionError(err error, ret []byte, gas uint64) error {
+	switch {
+	case err.Error() == errExecutionRevertedMsg:
+		return &ExecutionRevertedError{
+			Reason: UnpackRevertReason(ret),
+			Data:   ret,
+		}
+	case err == vm.ErrOutOfGas, err == vm.ErrCodeStoreOutOfGas:
+		return &OutOfGasError{GasLimit: gas}
+	}
+	return err
+}
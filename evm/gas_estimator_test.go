@@ -0,0 +1,92 @@
+// +build evm
+
+package evm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/loomnetwork/go-loom"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loomnetwork/loomchain"
+)
+
+func TestEstimateGas(t *testing.T) {
+	caller := loom.Address{
+		ChainID: "myChainID",
+		Local:   []byte("myCaller"),
+	}
+	newState := func() (loomchain.State, AccountBalanceManagerFactoryFunc, error) {
+		return mockState(), nil, nil
+	}
+	const gasCap = uint64(100000)
+
+	tests := []struct {
+		name string
+		// Contract creation code to estimate the gas for
+		code        string
+		expectedGas uint64
+		// Checks the error returned by EstimateGas, nil if no error is expected
+		checkErr func(t *testing.T, err error)
+	}{
+		{
+			// PUSH1 0, PUSH1 0, RETURN
+			name:        "fits the gas it uses",
+			code:        "60006000f3",
+			expectedGas: 6,
+		},
+		{
+			// GAS, PUSH3 50000, GT, PUSH1 0x0a, JUMPI, STOP, JUMPDEST, PUSH1 0, DUP1, REVERT
+			// Uses 21 gas, but reverts unless there's at least 50000 gas left after GAS executes.
+			name:        "needs more than the gas it uses",
+			code:        "5a62" + "00c350" + "1160" + "0a57" + "00" + "5b600080fd",
+			expectedGas: 50002,
+		},
+		{
+			// Same as above, but reverts unless there's at least gasCap-2 gas left after GAS
+			// executes, so it only succeeds with the full gas cap.
+			name:        "needs the gas cap",
+			code:        "5a62" + "01869e" + "1160" + "0a57" + "00" + "5b600080fd",
+			expectedGas: gasCap,
+		},
+		{
+			// JUMPDEST, PUSH1 0, JUMP
+			name: "always runs out of gas",
+			code: "5b600056",
+			checkErr: func(t *testing.T, err error) {
+				require.Equal(t, &OutOfGasError{GasLimit: gasCap}, err)
+			},
+		},
+		{
+			// PUSH1 100, PUSH1 12, PUSH1 0, CODECOPY, PUSH1 100, PUSH1 0, REVERT, followed by the
+			// output of require(false, "not enough funds")
+			name: "reverts",
+			code: "6064600c6000396064" + "6000fd" +
+				"08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000010" +
+				"6e6f7420656e6f7567682066756e647300000000000000000000000000000000",
+			checkErr: func(t *testing.T, err error) {
+				revertErr, ok := err.(*ExecutionRevertedError)
+				require.True(t, ok, "unexpected error: %v", err)
+				require.Equal(t, "not enough funds", revertErr.Reason)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, err := hex.DecodeString(test.code)
+			require.NoError(t, err)
+			gas, err := EstimateGas(newState, caller, nil, code, nil, gasCap)
+			if test.checkErr != nil {
+				require.Error(t, err)
+				test.checkErr(t, err)
+				require.Equal(t, uint64(0), gas)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedGas, gas)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package evm
 
 import (
 	"github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/loomchain"
 )
 
 // AccountBalanceManager can be implemented to override the builtin account balance management in the EVM.
@@ -14,3 +15,7 @@ type AccountBalanceManager interface {
 }
 
 type AccountBalanceManagerFactoryFunc func(readOnly bool) AccountBalanceManager
+
+// NewThrowawayStateFunc should return a state whose changes will be discarded, and an optional
+// factory for account balance managers that operate on that same state.
+type NewThrowawayStateFunc func() (loomchain.State, AccountBalanceManagerFactoryFunc, error)
@@ -3,8 +3,10 @@
 package evm
 
 import (
+	"github.com/loomnetwork/go-loom"
 	"github.com/loomnetwork/loomchain"
 	lvm "github.com/loomnetwork/loomchain/vm"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -25,3 +27,18 @@ func NewLoomVm(
 }
 
 func AddLoomPrecompiles() {}
+
+func EstimateGas(
+	newState NewThrowawayStateFunc,
+	caller loom.Address, contract *loom.Address, input []byte, value *loom.BigUInt,
+	gasCap uint64,
+) (uint64, error) {
+	return 0, errors.New("EVM not enabled")
+}
+
+func TraceTxs(
+	state loomchain.State, createABM AccountBalanceManagerFactoryFunc, txs []TraceTx, traceFrom int,
+	cfg TraceConfig,
+) ([]interface{}, error) {
+	return nil, errors.New("EVM not enabled")
+}
@@ -0,0 +1,33 @@
+package evm
+
+import (
+	"github.com/loomnetwork/go-loom"
+)
+
+// CallTracerName is the name of the tracer that produces a tree of all the calls made by a tx,
+// the output matches that of the go-ethereum callTracer.
+const CallTracerName = "callTracer"
+
+// TraceConfig specifies what information should be captured while tracing a tx.
+type TraceConfig struct {
+	DisableStorage bool `json:"disableStorage"`
+	DisableMemory  bool `json:"disableMemory"`
+	DisableStack   bool `json:"disableStack"`
+	// Maximum number of struct logs to capture, zero means unlimited.
+	Limit int `json:"limit"`
+	// Name of the tracer to use, if empty struct logs will be captured.
+	Tracer string `json:"tracer"`
+}
+
+// TraceTx describes an EVM contract call or deployment to be replayed during tracing.
+type TraceTx struct {
+	Caller loom.Address
+	// Address of the contract to call, or nil if the tx deploys a new contract.
+	Contract *loom.Address
+	// Call input, or contract creation code.
+	Input []byte
+	Value *loom.BigUInt
+	// Set if the tx failed when the block was executed, the tx is still replayed so that it can be
+	// traced, but any changes it makes to the state are discarded.
+	Failed bool
+}
@@ -0,0 +1,174 @@
+// +build evm
+
+package evm
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/loomnetwork/loomchain"
+	"github.com/pkg/errors"
+)
+
+// ExecutionResult is the default output of a tx trace, matches the go-ethereum output of
+// debug_traceTransaction when no tracer is specified.
+type ExecutionResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs"`
+}
+
+// StructLogRes is the JSON representation of an opcode executed by the EVM.
+type StructLogRes struct {
+	Pc      uint64             `json:"pc"`
+	Op      string             `json:"op"`
+	Gas     uint64             `json:"gas"`
+	GasCost uint64             `json:"gasCost"`
+	Depth   int                `json:"depth"`
+	Error   string             `json:"error,omitempty"`
+	Stack   *[]string          `json:"stack,omitempty"`
+	Memory  *[]string          `json:"memory,omitempty"`
+	Storage *map[string]string `json:"storage,omitempty"`
+}
+
+// TraceTxs executes the given txs in order against the given state, and returns the traces of
+// all the txs starting from index traceFrom. Changes made by txs that execute successfully are
+// written to the state so that each tx sees the same EVM state it saw when it was originally
+// executed, so the state should be a throwaway state (see loomchain.NewThrowawayState).
+func TraceTxs(
+	state loomchain.State, createABM AccountBalanceManagerFactoryFunc, txs []TraceTx, traceFrom int,
+	cfg TraceConfig,
+) ([]interface{}, error) {
+	if traceFrom < 0 || traceFrom >= len(txs) {
+		return nil, errors.New("no txs to trace")
+	}
+	if cfg.Tracer != "" && cfg.Tracer != CallTracerName {
+		return nil, errors.Errorf("unsupported tracer %s", cfg.Tracer)
+	}
+
+	var abm AccountBalanceManager
+	if createABM != nil {
+		abm = createABM(false)
+	}
+
+	results := make([]interface{}, 0, len(txs)-traceFrom)
+	for i, tx := range txs {
+		levm, err := NewLoomEvm(state, abm, nil, false)
+		if err != nil {
+			return nil, err
+		}
+
+		var result interface{}
+		var txErr error
+		switch {
+		case i < traceFrom:
+			_, _, txErr = executeTraceTx(levm, tx)
+
+		case cfg.Tracer == CallTracerName:
+			tracer := newCallTracer()
+			levm.vmConfig = vm.Config{Debug: true, Tracer: tracer}
+			_, _, txErr = executeTraceTx(levm, tx)
+			if result, err = tracer.result(); err != nil {
+				return nil, errors.Wrapf(err, "failed to trace tx %d", i)
+			}
+
+		default:
+			logger := vm.NewStructLogger(&vm.LogConfig{
+				DisableMemory:  cfg.DisableMemory,
+				DisableStack:   cfg.DisableStack,
+				DisableStorage: cfg.DisableStorage,
+				Limit:          cfg.Limit,
+			})
+			levm.vmConfig = vm.Config{Debug: true, Tracer: logger}
+			var ret []byte
+			var usedGas uint64
+			ret, usedGas, txErr = executeTraceTx(levm, tx)
+			result = &ExecutionResult{
+				Gas:         usedGas,
+				Failed:      txErr != nil || tx.Failed,
+				ReturnValue: fmt.Sprintf("%x", ret),
+				StructLogs:  formatStructLogs(logger.StructLogs()),
+			}
+		}
+
+		if i >= traceFrom {
+			results = append(results, result)
+		}
+		// Failed txs don't modify the state
+		if txErr == nil && !tx.Failed {
+			if _, err := levm.Commit(); err != nil {
+				return nil, errors.Wrapf(err, "failed to commit tx %d", i)
+			}
+		}
+	}
+	return results, nil
+}
+
+// executeTraceTx executes the given tx and returns the output and the amount of gas used.
+func executeTraceTx(levm *LoomEvm, tx TraceTx) ([]byte, uint64, error) {
+	val := common.Big0
+	if tx.Value != nil && tx.Value.Int != nil {
+		val = tx.Value.Int
+	}
+	var ret []byte
+	var leftOverGas uint64
+	var err error
+	if tx.Contract == nil {
+		ret, leftOverGas, err = levm.createWithGas(tx.Caller, tx.Input, val, levm.gasLimit)
+	} else {
+		ret, leftOverGas, err = levm.callWithGas(tx.Caller, *tx.Contract, tx.Input, val, levm.gasLimit)
+	}
+	return ret, levm.gasLimit - leftOverGas, err
+}
+
+// formatStructLogs converts the struct logs captured by the EVM to a format that's compatible with
+// the output of go-ethereum.
+func formatStructLogs(logs []vm.StructLog) []StructLogRes {
+	formatted := make([]StructLogRes, len(logs))
+	for i, log := range logs {
+		formatted[i] = StructLogRes{
+			Pc:      log.Pc,
+			Op:      log.Op.String(),
+			Gas:     log.Gas,
+			GasCost: log.GasCost,
+			Depth:   log.Depth,
+		}
+		if log.Err != nil {
+			formatted[i].Error = log.Err.Error()
+		}
+		if log.Stack != nil {
+			stack := make([]string, len(log.Stack))
+			for j, value := range log.Stack {
+				stack[j] = fmt.Sprintf("%x", math.PaddedBigBytes(value, 32))
+			}
+			formatted[i].Stack = &stack
+		}
+		if log.Memory != nil {
+			memory := make([]string, 0, (len(log.Memory)+31)/32)
+			for j := 0; j+32 <= len(log.Memory); j += 32 {
+				memory = append(memory, fmt.Sprintf("%x", log.Memory[j:j+32]))
+			}
+			formatted[i].Memory = &memory
+		}
+		if log.Storage != nil {
+			storage := make(map[string]string)
+			for key, value := range log.Storage {
+				storage[fmt.Sprintf("%x", key)] = fmt.Sprintf("%x", value)
+			}
+			formatted[i].Storage = &storage
+		}
+	}
+	return formatted
+}
+
+func encodeBig(v *big.Int) string {
+	if v == nil {
+		return "0x0"
+	}
+	return hexutil.EncodeBig(v)
+}
@@ -30,6 +30,8 @@ const (
 
 	// Enables support for mapping DAppChain accounts to Binance accounts
 	AddressMapperVersion1_1 = "addrmapper:v1.1"
+	// Enables removal of mappings via the AddressMapper.RemoveMapping method
+	AddressMapperVersion1_2 = "addrmapper:v1.2"
 
 	// Enables processing of txs via MultiChainSignatureTxMiddleware, there's a feature flag per
 	// allowed chain ID, e.g. auth:sigtx:default, auth:sigtx:eth
@@ -61,6 +63,12 @@ const (
 	DPOSVersion3_9 = "dpos:v3.9"
 	// Makes it possible for the oracle to call Redelegate & UnregisterCandidate
 	DPOSVersion3_10 = "dpos:v3.10"
+	// Makes the validator power cap, minimum self-stake & per-operator power cap configurable by the oracle
+	DPOSVersion3_11 = "dpos:v3.11"
+	// Enables delegators to opt into having their rewards compounded at the end of each election cycle
+	DPOSVersion3_12 = "dpos:v3.12"
+	// Enables the slash ledger that records the faults & slashed delegations of each slash
+	DPOSVersion3_13 = "dpos:v3.13"
 
 	// Enables rewards to be distributed even when a delegator owns less than 0.01% of the validator's stake
 	// Also makes whitelists give bonuses correctly if whitelist locktime tier is set to be 0-3 (else defaults to 5%)
@@ -123,9 +131,23 @@ const (
 	// Enables checking of minimum required build number on node startup.
 	ChainCfgVersion1_4 = "chaincfg:v1.4"
 
+	// Enables setting of the minimum gas price of Ethereum txs via the ChainConfig contract.
+	ChainCfgVersion1_5 = "chaincfg:v1.5"
+
+	// Enables scheduling of Go contract upgrades via the ChainConfig contract, and loading of the
+	// contract code versions recorded in the contract registry.
+	ContractUpgradeFeature = "registry:contract-upgrade"
+
+	// Enables submission of & voting on proposals in the Governance contract, and execution of the
+	// proposals that pass at the end of each block.
+	GovernanceFeature = "governance:v1"
+
 	// Enables the EthTxHandler for processing signed RLP endoed Ethereum txs.
 	EthTxFeature = "tx:eth"
 
+	// Enables routing of Ethereum txs & eth_call requests to Go contracts exposed via the ABI bridge.
+	EthTxABIBridgeFeature = "tx:eth-abi-bridge"
+
 	// Forces the MultiWriterAppStore to write EVM state only to evm.db, otherwise it'll write EVM
 	// state to both evm.db & app.db.
 	EvmDBFeature = "db:evm"
@@ -0,0 +1,249 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	kitlog "github.com/go-kit/kit/log"
+	kitlevel "github.com/go-kit/kit/log/level"
+	tlog "github.com/tendermint/tendermint/libs/log"
+)
+
+// ModuleKey is the key used to tag log entries with the name of the module that emitted them.
+const ModuleKey = "module"
+
+// defaultModule is the pseudo-module name used to get & set the level of log entries that aren't
+// tagged with a module.
+const defaultModule = "*"
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+	levelNone
+)
+
+var levelNames = []string{"debug", "info", "warn", "error", "none"}
+
+func (l logLevel) String() string {
+	return levelNames[l]
+}
+
+func parseLevel(name string) (logLevel, error) {
+	for i, n := range levelNames {
+		if strings.EqualFold(name, n) {
+			return logLevel(i), nil
+		}
+	}
+	return levelNone, fmt.Errorf("invalid log level %s", name)
+}
+
+// ModuleLevel is the log level of a module.
+type ModuleLevel struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+}
+
+// ParseModuleLevels parses a comma separated list of module:level pairs, e.g. "store:debug,rpc:error".
+func ParseModuleLevels(s string) ([]ModuleLevel, error) {
+	var levels []ModuleLevel
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid module log level %s, expected module:level", item)
+		}
+		if _, err := parseLevel(parts[1]); err != nil {
+			return nil, err
+		}
+		levels = append(levels, ModuleLevel{Module: parts[0], Level: parts[1]})
+	}
+	return levels, nil
+}
+
+// moduleLevels keeps track of the log level of each module, the levels can be changed at any time.
+type moduleLevels struct {
+	mutex        sync.RWMutex
+	defaultLevel logLevel
+	modules      map[string]logLevel
+}
+
+func newModuleLevels(defaultLevel logLevel) *moduleLevels {
+	return &moduleLevels{
+		defaultLevel: defaultLevel,
+		modules:      make(map[string]logLevel),
+	}
+}
+
+func (m *moduleLevels) set(module string, lvl logLevel) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if module == defaultModule {
+		m.defaultLevel = lvl
+	} else {
+		m.modules[module] = lvl
+	}
+}
+
+func (m *moduleLevels) replace(defaultLevel logLevel, modules map[string]logLevel) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.defaultLevel = defaultLevel
+	m.modules = modules
+}
+
+// allowed checks if an entry at the given level should be logged for the given module. Entries
+// that aren't tagged with a module, or tagged with a module that doesn't have a level, are only
+// subject to the default level if useDefault is true.
+func (m *moduleLevels) allowed(module string, lvl logLevel, useDefault bool) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if moduleLvl, ok := m.modules[module]; ok && module != "" {
+		return lvl >= moduleLvl
+	}
+	return !useDefault || lvl >= m.defaultLevel
+}
+
+func (m *moduleLevels) list() []ModuleLevel {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	levels := make([]ModuleLevel, 0, len(m.modules)+1)
+	levels = append(levels, ModuleLevel{Module: defaultModule, Level: m.defaultLevel.String()})
+	for module, lvl := range m.modules {
+		levels = append(levels, ModuleLevel{Module: module, Level: lvl.String()})
+	}
+	sort.Slice(levels[1:], func(i, j int) bool {
+		return levels[i+1].Module < levels[j+1].Module
+	})
+	return levels
+}
+
+var levels = newModuleLevels(levelInfo)
+
+// SetModuleLevel changes the log level of a module, the module name "*" changes the level of all
+// the log entries that aren't tagged with a module that has its own level.
+func SetModuleLevel(module, level string) error {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	if module == "" {
+		return fmt.Errorf("module name not specified")
+	}
+	levels.set(module, lvl)
+	return nil
+}
+
+// SetModuleLevels replaces the log levels of all the modules with the given ones, modules that
+// aren't in the list will no longer have their own level. The default level is only changed if
+// the "*" module is in the list. Nothing is changed if any of the levels is invalid.
+func SetModuleLevels(moduleLevels []ModuleLevel) error {
+	levels.mutex.RLock()
+	defaultLevel := levels.defaultLevel
+	levels.mutex.RUnlock()
+
+	modules := make(map[string]logLevel, len(moduleLevels))
+	for _, ml := range moduleLevels {
+		lvl, err := parseLevel(ml.Level)
+		if err != nil {
+			return err
+		}
+		switch ml.Module {
+		case "":
+			return fmt.Errorf("module name not specified")
+		case defaultModule:
+			defaultLevel = lvl
+		default:
+			modules[ml.Module] = lvl
+		}
+	}
+	levels.replace(defaultLevel, modules)
+	return nil
+}
+
+// ModuleLevels returns the current log level of all the modules that have their own level,
+// preceded by the default level.
+func ModuleLevels() []ModuleLevel {
+	return levels.list()
+}
+
+// levelFilter is a kit logger that drops log entries that are below the level of the module the
+// entries are tagged with.
+type levelFilter struct {
+	next   kitlog.Logger
+	levels *moduleLevels
+}
+
+func (f *levelFilter) Log(keyvals ...interface{}) error {
+	var module string
+	lvl := levelInfo
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		switch keyvals[i] {
+		case kitlevel.Key():
+			if v, ok := keyvals[i+1].(kitlevel.Value); ok {
+				if parsed, err := parseLevel(v.String()); err == nil {
+					lvl = parsed
+				}
+			}
+		case ModuleKey:
+			module = fmt.Sprint(keyvals[i+1])
+		}
+	}
+	if !f.levels.allowed(module, lvl, true) {
+		return nil
+	}
+	return f.next.Log(keyvals...)
+}
+
+// tmLevelFilter is a Tendermint logger that drops log entries that are below the level of the
+// module the logger is tagged with. Loggers that aren't tagged with a module that has its own
+// level aren't filtered, Tendermint loggers are already filtered by BlockchainLogLevel.
+type tmLevelFilter struct {
+	next   tlog.Logger
+	module string
+	levels *moduleLevels
+}
+
+func (f *tmLevelFilter) Debug(msg string, keyvals ...interface{}) {
+	if f.levels.allowed(f.module, levelDebug, false) {
+		f.next.Debug(msg, keyvals...)
+	}
+}
+
+func (f *tmLevelFilter) Info(msg string, keyvals ...interface{}) {
+	if f.levels.allowed(f.module, levelInfo, false) {
+		f.next.Info(msg, keyvals...)
+	}
+}
+
+func (f *tmLevelFilter) Error(msg string, keyvals ...interface{}) {
+	if f.levels.allowed(f.module, levelError, false) {
+		f.next.Error(msg, keyvals...)
+	}
+}
+
+func (f *tmLevelFilter) With(keyvals ...interface{}) tlog.Logger {
+	module := f.module
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == ModuleKey {
+			module = fmt.Sprint(keyvals[i+1])
+		}
+	}
+	return &tmLevelFilter{
+		next:   f.next.With(keyvals...),
+		module: module,
+		levels: f.levels,
+	}
+}
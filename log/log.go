@@ -2,7 +2,10 @@ package log
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"strings"
 	"sync"
 
 	kitlog "github.com/go-kit/kit/log"
@@ -27,25 +30,99 @@ var (
 
 var onceSetup sync.Once
 
-func setupRootLogger(w io.Writer) {
-	rootLoggerFunc := func(w io.Writer) TMLogger {
-		return NewTMLogger(NewSyncWriter(w))
+// Config contains the log settings that aren't covered by the log level & destination.
+type Config struct {
+	// Log entry format, either "fmt" or "json"
+	Format string
+	// Comma separated list of module:level pairs, e.g. "store:debug,rpc:error", the level of a
+	// module overrides LoomLogLevel (and BlockchainLogLevel for Tendermint modules).
+	ModuleLevels string
+	// Max size (in megabytes) the log file can grow to before it's rotated, zero disables rotation.
+	// Only applies when logging to a file.
+	MaxFileSizeMegs int
+	// Max number of rotated log files to keep.
+	MaxBackups int
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Format:          "fmt",
+		ModuleLevels:    "",
+		MaxFileSizeMegs: 0,
+		MaxBackups:      10,
+	}
+}
+
+func setupRootLogger(w io.Writer, format string) {
+	var logger TMLogger
+	if format == "json" {
+		logger = tlog.NewTMJSONLogger(NewSyncWriter(w))
+	} else {
+		logger = NewTMLogger(NewSyncWriter(w))
 	}
-	Root = rootLoggerFunc(w)
+	Root = &tmLevelFilter{next: logger, levels: levels}
 }
 
-func setupLoomLogger(logLevel string, w io.Writer) {
+func setupLoomLogger(format string, w io.Writer) {
 	tlogTr := func(w io.Writer) kitlog.Logger {
-		return tlog.NewTMFmtLogger(w)
+		var logger kitlog.Logger
+		if format == "json" {
+			logger = kitlog.With(kitlog.NewJSONLogger(w), "ts", kitlog.DefaultTimestampUTC)
+		} else {
+			logger = tlog.NewTMFmtLogger(w)
+		}
+		return &levelFilter{next: logger, levels: levels}
 	}
-	Default = loom.MakeLoomLogger(logLevel, w, tlogTr)
+	// The level filter applied by the loom logger is always left wide open, entries are filtered
+	// by the module level filter instead so the levels can be changed at runtime.
+	Default = loom.MakeLoomLogger("debug", w, tlogTr)
+}
+
+// newLogWriter returns a writer for the given log destination, if the destination is a file and
+// a max file size is specified then the file will be rotated once it reaches that size.
+func newLogWriter(loomLogLevel, dest string, cfg *Config) (io.Writer, error) {
+	if cfg.MaxFileSizeMegs > 0 && strings.HasPrefix(dest, "file://") && dest != "file://-" {
+		return newRotatingFileWriter(
+			strings.TrimPrefix(dest, "file://"), int64(cfg.MaxFileSizeMegs)*1024*1024, cfg.MaxBackups,
+		)
+	}
+	return loom.MakeFileLoggerWriter(loomLogLevel, dest), nil
 }
 
 func Setup(loomLogLevel, dest string) {
+	SetupWithConfig(loomLogLevel, dest, DefaultConfig())
+}
+
+// SetupWithConfig sets up the root & default loggers, this should only be called once, any
+// subsequent calls will be ignored.
+func SetupWithConfig(loomLogLevel, dest string, cfg *Config) {
 	onceSetup.Do(func() {
-		w := loom.MakeFileLoggerWriter(loomLogLevel, dest)
-		setupRootLogger(w)
-		setupLoomLogger(loomLogLevel, w)
+		if cfg == nil {
+			cfg = DefaultConfig()
+		}
+		var setupErrs []error
+		if err := SetModuleLevel(defaultModule, loomLogLevel); err != nil {
+			setupErrs = append(setupErrs, err)
+		}
+		moduleLevels, err := ParseModuleLevels(cfg.ModuleLevels)
+		if err != nil {
+			setupErrs = append(setupErrs, err)
+		}
+		for _, ml := range moduleLevels {
+			if err := SetModuleLevel(ml.Module, ml.Level); err != nil {
+				setupErrs = append(setupErrs, err)
+			}
+		}
+		w, err := newLogWriter(loomLogLevel, dest, cfg)
+		if err != nil {
+			setupErrs = append(setupErrs, err)
+			w = loom.MakeFileLoggerWriter(loomLogLevel, dest)
+		}
+		setupRootLogger(w, cfg.Format)
+		setupLoomLogger(cfg.Format, w)
+		for _, err := range setupErrs {
+			Error("Invalid log config", "err", err)
+		}
 	})
 }
 
@@ -69,6 +146,38 @@ func Warn(msg string, keyvals ...interface{}) {
 	Default.Warn(msg, keyvals...)
 }
 
+// ModuleLogger logs messages via the default logger, tagging each entry with the name of the
+// module that emitted it, so the level of each module can be set separately.
+type ModuleLogger struct {
+	keyvals []interface{}
+}
+
+// Module returns a logger for the given module.
+func Module(name string) *ModuleLogger {
+	return &ModuleLogger{keyvals: []interface{}{ModuleKey, name}}
+}
+
+// With returns a new logger that adds the given key/value pairs to each entry.
+func (l *ModuleLogger) With(keyvals ...interface{}) *ModuleLogger {
+	return &ModuleLogger{keyvals: append(append([]interface{}{}, l.keyvals...), keyvals...)}
+}
+
+func (l *ModuleLogger) Info(msg string, keyvals ...interface{}) {
+	Default.Info(msg, append(append([]interface{}{}, l.keyvals...), keyvals...)...)
+}
+
+func (l *ModuleLogger) Debug(msg string, keyvals ...interface{}) {
+	Default.Debug(msg, append(append([]interface{}{}, l.keyvals...), keyvals...)...)
+}
+
+func (l *ModuleLogger) Error(msg string, keyvals ...interface{}) {
+	Default.Error(msg, append(append([]interface{}{}, l.keyvals...), keyvals...)...)
+}
+
+func (l *ModuleLogger) Warn(msg string, keyvals ...interface{}) {
+	Default.Warn(msg, append(append([]interface{}{}, l.keyvals...), keyvals...)...)
+}
+
 type contextKey string
 
 func (c contextKey) String() string {
@@ -79,15 +188,67 @@ var (
 	contextKeyLog = contextKey("log")
 )
 
-func SetContext(ctx context.Context, log loom.Logger) context.Context {
+// SetContext attaches a logger to the context, the logger can be retrieved with Log().
+func SetContext(ctx context.Context, log TMLogger) context.Context {
 	return context.WithValue(ctx, contextKeyLog, log)
 }
 
-func Log(ctx context.Context) tlog.Logger {
-	logger, _ := ctx.Value(contextKeyLog).(tlog.Logger)
+// Log returns the logger attached to the context, or the root logger if the context doesn't have
+// a logger attached to it.
+func Log(ctx context.Context) TMLogger {
+	logger, _ := ctx.Value(contextKeyLog).(TMLogger)
 	if logger == nil {
 		return Root
 	}
 
 	return logger
 }
+
+// LoomLogger returns a loom logger that writes to the logger attached to the context, so entries
+// logged via the loom logger carry the same tags (e.g. the tx hash) as the entries logged via
+// Log(ctx). If the context doesn't have a logger attached to it the default logger is returned.
+func LoomLogger(ctx context.Context) *loom.Logger {
+	logger, _ := ctx.Value(contextKeyLog).(TMLogger)
+	if logger == nil {
+		return Default
+	}
+	return loom.MakeLoomLogger("debug", ioutil.Discard, func(w io.Writer) kitlog.Logger {
+		return &tmLoggerAdapter{next: logger}
+	})
+}
+
+// tmLoggerAdapter is a kit logger that forwards the entries written by a loom logger to a Tendermint
+// logger.
+type tmLoggerAdapter struct {
+	next TMLogger
+}
+
+func (a *tmLoggerAdapter) Log(keyvals ...interface{}) error {
+	lvl := levelInfo
+	var msg string
+	fields := make([]interface{}, 0, len(keyvals))
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		switch keyvals[i] {
+		case kitlevel.Key():
+			if v, ok := keyvals[i+1].(kitlevel.Value); ok {
+				if parsed, err := parseLevel(v.String()); err == nil {
+					lvl = parsed
+				}
+			}
+		case "_msg", "msg":
+			msg = fmt.Sprint(keyvals[i+1])
+		default:
+			fields = append(fields, keyvals[i], keyvals[i+1])
+		}
+	}
+	switch lvl {
+	case levelDebug:
+		a.next.Debug(msg, fields...)
+	case levelInfo:
+		a.next.Info(msg, fields...)
+	default:
+		// Tendermint loggers don't have a warn level
+		a.next.Error(msg, fields...)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package log
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kitlog "github.com/go-kit/kit/log"
+	kitlevel "github.com/go-kit/kit/log/level"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModuleLevels(t *testing.T) {
+	levels, err := ParseModuleLevels("store:debug, rpc:error,")
+	require.NoError(t, err)
+	require.Equal(t, []ModuleLevel{
+		{Module: "store", Level: "debug"},
+		{Module: "rpc", Level: "error"},
+	}, levels)
+
+	levels, err = ParseModuleLevels("")
+	require.NoError(t, err)
+	require.Len(t, levels, 0)
+
+	_, err = ParseModuleLevels("store")
+	require.Error(t, err)
+	_, err = ParseModuleLevels("store:verbose")
+	require.Error(t, err)
+	_, err = ParseModuleLevels(":debug")
+	require.Error(t, err)
+}
+
+type capturingLogger struct {
+	entries [][]interface{}
+}
+
+func (l *capturingLogger) Log(keyvals ...interface{}) error {
+	l.entries = append(l.entries, keyvals)
+	return nil
+}
+
+func TestLevelFilter(t *testing.T) {
+	levels := newModuleLevels(levelInfo)
+	out := &capturingLogger{}
+	logger := &levelFilter{next: out, levels: levels}
+
+	require.NoError(t, kitlevel.Debug(logger).Log("msg", "a"))
+	require.NoError(t, kitlevel.Info(logger).Log("msg", "b"))
+	require.NoError(t, kitlevel.Debug(kitlog.With(logger, ModuleKey, "store")).Log("msg", "c"))
+	require.Len(t, out.entries, 1)
+
+	levels.set("store", levelDebug)
+	levels.set("rpc", levelError)
+	require.NoError(t, kitlevel.Debug(kitlog.With(logger, ModuleKey, "store")).Log("msg", "d"))
+	require.NoError(t, kitlevel.Warn(kitlog.With(logger, ModuleKey, "rpc")).Log("msg", "e"))
+	require.NoError(t, kitlevel.Debug(logger).Log("msg", "f"))
+	require.Len(t, out.entries, 2)
+
+	levels.set(defaultModule, levelNone)
+	require.NoError(t, kitlevel.Error(logger).Log("msg", "g"))
+	require.Len(t, out.entries, 2)
+
+	require.Equal(t, []ModuleLevel{
+		{Module: "*", Level: "none"},
+		{Module: "rpc", Level: "error"},
+		{Module: "store", Level: "debug"},
+	}, levels.list())
+}
+
+func TestRotatingFileWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-rotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "loom.log")
+	w, err := newRotatingFileWriter(filename, 10, 2)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		_, err := w.Write([]byte(fmt.Sprintf("entry %d\n", i)))
+		require.NoError(t, err)
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "entry 3\n", string(data))
+	data, err = ioutil.ReadFile(filename + ".1")
+	require.NoError(t, err)
+	require.Equal(t, "entry 2\n", string(data))
+	data, err = ioutil.ReadFile(filename + ".2")
+	require.NoError(t, err)
+	require.Equal(t, "entry 1\n", string(data))
+	_, err = os.Stat(filename + ".3")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSetModuleLevels(t *testing.T) {
+	defer levels.replace(levelInfo, make(map[string]logLevel))
+
+	require.NoError(t, SetModuleLevel("store", "debug"))
+	require.NoError(t, SetModuleLevels([]ModuleLevel{
+		{Module: "*", Level: "warn"},
+		{Module: "rpc", Level: "error"},
+	}))
+	require.Equal(t, []ModuleLevel{
+		{Module: "*", Level: "warn"},
+		{Module: "rpc", Level: "error"},
+	}, ModuleLevels())
+
+	require.Error(t, SetModuleLevels([]ModuleLevel{{Module: "store", Level: "loud"}}))
+	require.Len(t, ModuleLevels(), 2)
+}
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// rotatingFileWriter writes to a file that's rotated once it reaches the max size, the rotated
+// files are named <filename>.1, <filename>.2, ... with <filename>.1 being the most recent one.
+type rotatingFileWriter struct {
+	mutex      sync.Mutex
+	filename   string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newRotatingFileWriter(filename string, maxSize int64, maxBackups int) (*rotatingFileWriter, error) {
+	w := &rotatingFileWriter{
+		filename:   filename,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingFileWriter) open() error {
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open log file %s", w.filename)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to stat log file %s", w.filename)
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+func (w *rotatingFileWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the existing log files along by one, discarding the oldest one if the max number
+// of backups has been reached, and starts a new log file.
+func (w *rotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close log file %s", w.filename)
+	}
+	if w.maxBackups > 0 {
+		for i := w.maxBackups - 1; i > 0; i-- {
+			src := fmt.Sprintf("%s.%d", w.filename, i)
+			if _, err := os.Stat(src); err == nil {
+				if err := os.Rename(src, fmt.Sprintf("%s.%d", w.filename, i+1)); err != nil {
+					return errors.Wrapf(err, "failed to rotate log file %s", src)
+				}
+			}
+		}
+		if err := os.Rename(w.filename, w.filename+".1"); err != nil {
+			return errors.Wrapf(err, "failed to rotate log file %s", w.filename)
+		}
+	} else if err := os.Remove(w.filename); err != nil {
+		return errors.Wrapf(err, "failed to remove log file %s", w.filename)
+	}
+	return w.open()
+}
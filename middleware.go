@@ -1,18 +1,43 @@
 package loomchain
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"github.com/go-kit/kit/metrics"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/loomnetwork/loomchain/log"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	ttypes "github.com/tendermint/tendermint/types"
 )
 
+type contextKey string
+
+func (c contextKey) String() string {
+	return "loomchain " + string(c)
+}
+
+var contextKeyTxBytes = contextKey("txBytes")
+
+// WithTxBytes returns a copy of the given context that carries the raw bytes of the tx that's
+// being processed, so that middleware further down the chain can access them.
+func WithTxBytes(ctx context.Context, txBytes []byte) context.Context {
+	return context.WithValue(ctx, contextKeyTxBytes, txBytes)
+}
+
+// TxBytesFromContext returns the raw bytes of the tx that's being processed, or nil if the context
+// doesn't carry them.
+func TxBytesFromContext(ctx context.Context) []byte {
+	txBytes, _ := ctx.Value(contextKeyTxBytes).([]byte)
+	return txBytes
+}
+
 type TxMiddleware interface {
 	ProcessTx(state State, txBytes []byte, next TxHandlerFunc, isCheckTx bool) (TxHandlerResult, error)
 }
@@ -25,6 +50,37 @@ func (f TxMiddlewareFunc) ProcessTx(
 	return f(state, txBytes, next, isCheckTx)
 }
 
+// SwappableTxMiddleware forwards txs to a middleware that can be replaced while the node is
+// running, if no middleware is set txs are passed straight to the next handler.
+type SwappableTxMiddleware struct {
+	mutex      sync.RWMutex
+	middleware TxMiddleware
+}
+
+func NewSwappableTxMiddleware(middleware TxMiddleware) *SwappableTxMiddleware {
+	return &SwappableTxMiddleware{middleware: middleware}
+}
+
+// Swap replaces the current middleware with the given one, which may be nil.
+func (m *SwappableTxMiddleware) Swap(middleware TxMiddleware) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.middleware = middleware
+}
+
+func (m *SwappableTxMiddleware) ProcessTx(
+	state State, txBytes []byte, next TxHandlerFunc, isCheckTx bool,
+) (TxHandlerResult, error) {
+	m.mutex.RLock()
+	middleware := m.middleware
+	m.mutex.RUnlock()
+
+	if middleware == nil {
+		return next(state, txBytes, isCheckTx)
+	}
+	return middleware.ProcessTx(state, txBytes, next, isCheckTx)
+}
+
 type PostCommitHandler func(state State, txBytes []byte, res TxHandlerResult, isCheckTx bool) error
 
 type PostCommitMiddleware interface {
@@ -101,7 +157,7 @@ var RecoveryTxMiddleware = TxMiddlewareFunc(func(
 ) (res TxHandlerResult, err error) {
 	defer func() {
 		if rval := recover(); rval != nil {
-			logger := log.Default
+			logger := log.Log(state.Context())
 			logger.Error("Panic in TX Handler", "rvalue", rval)
 			println(debug.Stack())
 			err = rvalError(rval)
@@ -111,13 +167,49 @@ var RecoveryTxMiddleware = TxMiddlewareFunc(func(
 	return next(state, txBytes, isCheckTx)
 })
 
+// LogTxMiddleware attaches a logger to the context of each tx, the logger tags all the entries it
+// logs with the hash of the tx, so all the entries logged while processing a tx can be correlated.
+// Middleware & handlers further down the chain can obtain the logger via log.Log(state.Context()).
 var LogTxMiddleware = TxMiddlewareFunc(func(
 	state State,
 	txBytes []byte,
 	next TxHandlerFunc,
 	isCheckTx bool,
 ) (TxHandlerResult, error) {
-	// TODO: set some tx specific logging info
+	logger := log.Root.With(
+		log.ModuleKey, "tx",
+		"tx_hash", hex.EncodeToString(ttypes.Tx(txBytes).Hash()),
+		"check_tx", isCheckTx,
+	)
+	r, err := next(state.WithContext(log.SetContext(state.Context(), logger)), txBytes, isCheckTx)
+	if err != nil {
+		logger.Debug("Tx failed", "err", err)
+	}
+	return r, err
+})
+
+// ErrReplicaNode is returned when a tx is submitted to a read-only replica node.
+var ErrReplicaNode = errors.New("replica node doesn't accept txs")
+
+// ErrTxQueued is returned in CheckTx when a tx with a future nonce has been queued instead of being
+// added to the mempool, the tx will be resubmitted once the txs preceding it have been received.
+var ErrTxQueued = errors.New("tx queued until the txs preceding it are received")
+
+// CodeTypeTxQueued is the CheckTx response code for txs that were queued, it's only used to tell
+// the RPC layer that the tx has been accepted by the node even though it's not in the mempool yet.
+const CodeTypeTxQueued uint32 = 2
+
+// ReplicaTxMiddleware rejects all txs in CheckTx, read-only replica nodes only process the txs
+// in blocks committed by the validators, so they don't need to admit any txs to their mempool.
+var ReplicaTxMiddleware = TxMiddlewareFunc(func(
+	state State,
+	txBytes []byte,
+	next TxHandlerFunc,
+	isCheckTx bool,
+) (TxHandlerResult, error) {
+	if isCheckTx {
+		return TxHandlerResult{}, ErrReplicaNode
+	}
 	return next(state, txBytes, isCheckTx)
 })
 
@@ -128,7 +220,7 @@ var LogPostCommitMiddleware = PostCommitMiddlewareFunc(func(
 	next PostCommitHandler,
 	isCheckTx bool,
 ) error {
-	log.Default.Info("Tx processed", "result", res, "payload", base64.StdEncoding.EncodeToString(txBytes))
+	log.Log(state.Context()).Info("Tx processed", "result", res, "payload", base64.StdEncoding.EncodeToString(txBytes))
 	return next(state, txBytes, res, isCheckTx)
 })
 
@@ -1,10 +1,18 @@
 package loomchain
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	common "github.com/tendermint/tendermint/libs/common"
+	ttypes "github.com/tendermint/tendermint/types"
+
+	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/store"
 )
 
 type appHandler struct {
@@ -60,3 +68,68 @@ func TestMiddlewareTxHandler(t *testing.T) {
 	r, _ := mwHandler.ProcessTx(nil, allBytes, false)
 	require.Equal(t, r.Tags, []common.KVPair{appTag, mw2Tag, mw1Tag})
 }
+
+func TestReplicaTxMiddleware(t *testing.T) {
+	mwHandler := MiddlewareTxHandler(
+		[]TxMiddleware{ReplicaTxMiddleware},
+		&appHandler{t: t},
+		[]PostCommitMiddleware{},
+	)
+	_, err := mwHandler.ProcessTx(nil, []byte("AppData"), true)
+	require.Equal(t, ErrReplicaNode, err)
+
+	// txs in committed blocks must still be processed
+	r, err := mwHandler.ProcessTx(nil, []byte("AppData"), false)
+	require.NoError(t, err)
+	require.Equal(t, []common.KVPair{appTag}, r.Tags)
+}
+
+func TestSwappableTxMiddleware(t *testing.T) {
+	swappable := NewSwappableTxMiddleware(nil)
+	mwHandler := MiddlewareTxHandler(
+		[]TxMiddleware{swappable},
+		&appHandler{t: t},
+		[]PostCommitMiddleware{},
+	)
+	r, err := mwHandler.ProcessTx(nil, []byte("AppData"), true)
+	require.NoError(t, err)
+	require.Equal(t, []common.KVPair{appTag}, r.Tags)
+
+	swappable.Swap(ReplicaTxMiddleware)
+	_, err = mwHandler.ProcessTx(nil, []byte("AppData"), true)
+	require.Equal(t, ErrReplicaNode, err)
+
+	swappable.Swap(nil)
+	_, err = mwHandler.ProcessTx(nil, []byte("AppData"), true)
+	require.NoError(t, err)
+}
+
+// Test that entries logged by the tx handler are tagged with the hash of the tx being processed.
+func TestLogTxMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	rootLogger := log.Root
+	log.Root = log.NewTMLogger(log.NewSyncWriter(&buf))
+	defer func() { log.Root = rootLogger }()
+
+	txBytes := []byte("AppData")
+	mwHandler := MiddlewareTxHandler(
+		[]TxMiddleware{LogTxMiddleware},
+		TxHandlerFunc(func(state State, txBytes []byte, isCheckTx bool) (TxHandlerResult, error) {
+			log.Log(state.Context()).Info("Handler log")
+			log.LoomLogger(state.Context()).Info("Contract log")
+			return TxHandlerResult{}, nil
+		}),
+		[]PostCommitMiddleware{},
+	)
+	state := NewStoreState(context.Background(), store.NewMemStore(), abci.Header{}, nil, nil)
+	_, err := mwHandler.ProcessTx(state, txBytes, false)
+	require.NoError(t, err)
+
+	txHashTag := "tx_hash=" + hex.EncodeToString(ttypes.Tx(txBytes).Hash())
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	require.Contains(t, string(lines[0]), "Handler log")
+	require.Contains(t, string(lines[0]), txHashTag)
+	require.Contains(t, string(lines[1]), "Contract log")
+	require.Contains(t, string(lines[1]), txHashTag)
+}
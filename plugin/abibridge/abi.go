@@ -0,0 +1,515 @@
+// +build evm
+
+package abibridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/loomnetwork/go-loom"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	contextType       = reflect.TypeOf((*contract.Context)(nil)).Elem()
+	staticContextType = reflect.TypeOf((*contract.StaticContext)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	addressType       = reflect.TypeOf(&types.Address{})
+	bigUIntType       = reflect.TypeOf(&types.BigUInt{})
+	bytesType         = reflect.TypeOf([]byte(nil))
+
+	// names field descriptors use to refer to the address & amount types
+	addressTypeName = "." + proto.MessageName(&types.Address{})
+	bigUIntTypeName = "." + proto.MessageName(&types.BigUInt{})
+)
+
+// abiArgument is the JSON representation of a function or event argument in a Solidity ABI.
+type abiArgument struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// abiEntry is the JSON representation of a function or event in a Solidity ABI.
+type abiEntry struct {
+	Type            string        `json:"type"`
+	Name            string        `json:"name"`
+	Inputs          []abiArgument `json:"inputs"`
+	Outputs         []abiArgument `json:"outputs,omitempty"`
+	Constant        bool          `json:"constant,omitempty"`
+	StateMutability string        `json:"stateMutability,omitempty"`
+}
+
+func abiSignature(name string, args []abiArgument) string {
+	argTypes := make([]string, len(args))
+	for i, arg := range args {
+		argTypes[i] = arg.Type
+	}
+	return name + "(" + strings.Join(argTypes, ",") + ")"
+}
+
+// messageCodec converts protobuf messages to & from lists of ABI values, each field of a message
+// maps to a single ABI argument, the arguments are ordered by field number.
+type messageCodec struct {
+	typ    reflect.Type // pointer to the message struct
+	fields []int        // indices of the struct fields that map to ABI arguments
+	args   []abiArgument
+}
+
+func newMessageCodec(typ reflect.Type) (*messageCodec, error) {
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("%v is not a protobuf message", typ)
+	}
+	msg, ok := reflect.New(typ.Elem()).Interface().(descriptor.Message)
+	if !ok {
+		return nil, errors.Errorf("%v is not a protobuf message with a descriptor", typ)
+	}
+	_, md := descriptor.ForMessage(msg)
+
+	// The descriptor specifies the fields of the message, the struct field each one maps to is
+	// looked up by the original field name.
+	st := typ.Elem()
+	structFields := map[string]int{}
+	for i, prop := range proto.GetProperties(st).Prop {
+		if prop.OrigName != "" {
+			structFields[prop.OrigName] = i
+		}
+	}
+	fields := append([]*descriptor.FieldDescriptorProto(nil), md.GetField()...)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].GetNumber() < fields[j].GetNumber() })
+
+	codec := &messageCodec{typ: typ}
+	for _, fd := range fields {
+		if fd.OneofIndex != nil {
+			return nil, errors.Errorf("%v.%s: oneof fields are not supported", st, fd.GetName())
+		}
+		abiType, ok := abiTypeOf(fd)
+		if !ok {
+			return nil, errors.Errorf("%v.%s: unsupported field type %v", st, fd.GetName(), fd.GetType())
+		}
+		index, ok := structFields[fd.GetName()]
+		if !ok {
+			return nil, errors.Errorf("%v.%s: no struct field", st, fd.GetName())
+		}
+		if !goTypeMatches(abiType, st.Field(index).Type) {
+			return nil, errors.Errorf(
+				"%v.%s: unsupported struct field type %v", st, fd.GetName(), st.Field(index).Type,
+			)
+		}
+		codec.fields = append(codec.fields, index)
+		codec.args = append(codec.args, abiArgument{Name: fd.GetName(), Type: abiType})
+	}
+	return codec, nil
+}
+
+// abiTypeOf returns the ABI type a message field maps to.
+func abiTypeOf(fd *descriptor.FieldDescriptorProto) (string, bool) {
+	if fd.IsRepeated() {
+		return "", false
+	}
+	switch fd.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		switch fd.GetTypeName() {
+		case addressTypeName:
+			return "address", true
+		case bigUIntTypeName:
+			return "uint256", true
+		}
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "bool", true
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return "string", true
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return "int32", true
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return "int64", true
+	case descriptor.FieldDescriptorProto_TYPE_UINT32, descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return "uint32", true
+	case descriptor.FieldDescriptorProto_TYPE_UINT64, descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return "uint64", true
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "bytes", true
+	}
+	return "", false
+}
+
+// goTypeMatches checks that values of the given ABI type can be converted to & from the given
+// struct field type, gogoproto options can change the Go type of a field.
+func goTypeMatches(abiType string, typ reflect.Type) bool {
+	switch abiType {
+	case "address":
+		return typ == addressType
+	case "uint256":
+		return typ == bigUIntType
+	case "bytes":
+		return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+	case "bool":
+		return typ.Kind() == reflect.Bool
+	case "string":
+		return typ.Kind() == reflect.String
+	case "int32":
+		return typ.Kind() == reflect.Int32
+	case "int64":
+		return typ.Kind() == reflect.Int64
+	case "uint32":
+		return typ.Kind() == reflect.Uint32
+	case "uint64":
+		return typ.Kind() == reflect.Uint64
+	}
+	return false
+}
+
+func (c *messageCodec) newMessage() proto.Message {
+	return reflect.New(c.typ.Elem()).Interface().(proto.Message)
+}
+
+// toValues converts the fields of the given message to ABI values.
+func (c *messageCodec) toValues(msg proto.Message) []interface{} {
+	v := reflect.ValueOf(msg).Elem()
+	values := make([]interface{}, len(c.fields))
+	for i, idx := range c.fields {
+		values[i] = toABIValue(v.Field(idx))
+	}
+	return values
+}
+
+// fromValues creates a new message from the given ABI values, any addresses in the values are
+// assumed to belong to the given chain.
+func (c *messageCodec) fromValues(chainID string, values []interface{}) (proto.Message, error) {
+	if len(values) != len(c.fields) {
+		return nil, errors.Errorf("expected %d values, got %d", len(c.fields), len(values))
+	}
+	msg := reflect.New(c.typ.Elem())
+	for i, idx := range c.fields {
+		field := msg.Elem().Field(idx)
+		v, err := fromABIValue(chainID, values[i], field.Type())
+		if err != nil {
+			return nil, errors.Wrapf(err, "argument %s", c.args[i].Name)
+		}
+		field.Set(v)
+	}
+	return msg.Interface().(proto.Message), nil
+}
+
+func toABIValue(field reflect.Value) interface{} {
+	switch field.Type() {
+	case addressType:
+		if field.IsNil() {
+			return common.Address{}
+		}
+		return common.BytesToAddress(field.Interface().(*types.Address).Local)
+	case bigUIntType:
+		if field.IsNil() {
+			return new(big.Int)
+		}
+		amount := field.Interface().(*types.BigUInt)
+		if amount.Value.Int == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(amount.Value.Int)
+	}
+	switch field.Kind() {
+	case reflect.Bool:
+		return field.Bool()
+	case reflect.String:
+		return field.String()
+	case reflect.Int32:
+		return int32(field.Int())
+	case reflect.Int64:
+		return field.Int()
+	case reflect.Uint32:
+		return uint32(field.Uint())
+	case reflect.Uint64:
+		return field.Uint()
+	default:
+		return field.Convert(bytesType).Interface()
+	}
+}
+
+func fromABIValue(chainID string, value interface{}, typ reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Value{}, errors.New("missing value")
+	}
+	switch typ {
+	case addressType:
+		if addr, ok := value.(common.Address); ok {
+			return reflect.ValueOf(&types.Address{ChainId: chainID, Local: addr.Bytes()}), nil
+		}
+	case bigUIntType:
+		if amount, ok := value.(*big.Int); ok {
+			return reflect.ValueOf(&types.BigUInt{Value: *loom.NewBigUInt(amount)}), nil
+		}
+	default:
+		if v := reflect.ValueOf(value); v.Type().ConvertibleTo(typ) {
+			return v.Convert(typ), nil
+		}
+	}
+	return reflect.Value{}, errors.Errorf("can't convert %T to %v", value, typ)
+}
+
+// abiMethod maps a function in the generated ABI to a Go contract method.
+type abiMethod struct {
+	goName   string
+	abiName  string
+	readOnly bool
+	input    *messageCodec
+	// Nil if the Go method doesn't return a response, in which case the ABI function returns true
+	// on success (which is what ERC20 functions do).
+	output  *messageCodec
+	inputs  abi.Arguments
+	outputs abi.Arguments
+}
+
+// newABIMethod returns an error if the given method isn't a contract method, or if its request or
+// response can't be represented in the ABI.
+func newABIMethod(m reflect.Method) (*abiMethod, error) {
+	typ := m.Type // the first input is the receiver
+	if typ.NumIn() != 3 {
+		return nil, errors.New("not a contract method")
+	}
+	method := &abiMethod{goName: m.Name, abiName: lowerFirst(m.Name)}
+	switch typ.In(1) {
+	case contextType:
+	case staticContextType:
+		method.readOnly = true
+	default:
+		return nil, errors.New("not a contract method")
+	}
+
+	var err error
+	if method.input, err = newMessageCodec(typ.In(2)); err != nil {
+		return nil, err
+	}
+	switch {
+	case typ.NumOut() == 1 && typ.Out(0) == errorType:
+	case typ.NumOut() == 2 && typ.Out(1) == errorType:
+		if method.output, err = newMessageCodec(typ.Out(0)); err != nil {
+			return nil, err
+		}
+		if len(method.output.fields) == 0 {
+			method.output = nil
+		}
+	default:
+		return nil, errors.New("not a contract method")
+	}
+	return method, nil
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+func (m *abiMethod) entry() abiEntry {
+	entry := abiEntry{
+		Type:            "function",
+		Name:            m.abiName,
+		Inputs:          m.input.args,
+		Constant:        m.readOnly,
+		StateMutability: "nonpayable",
+	}
+	if m.readOnly {
+		entry.StateMutability = "view"
+	}
+	if m.output != nil {
+		entry.Outputs = m.output.args
+	} else {
+		entry.Outputs = []abiArgument{{Name: "", Type: "bool"}}
+	}
+	if entry.Inputs == nil {
+		entry.Inputs = []abiArgument{}
+	}
+	return entry
+}
+
+func (m *abiMethod) selector() string {
+	return string(crypto.Keccak256([]byte(abiSignature(m.abiName, m.input.args)))[:4])
+}
+
+// decodeInput converts the ABI encoded arguments (without the function selector) to a request
+// message.
+func (m *abiMethod) decodeInput(chainID string, data []byte) (proto.Message, error) {
+	var values []interface{}
+	if len(m.inputs) > 0 {
+		var err error
+		if values, err = m.inputs.UnpackValues(data); err != nil {
+			return nil, errors.Wrap(err, "failed to decode ABI arguments")
+		}
+	}
+	return m.input.fromValues(chainID, values)
+}
+
+// encodeOutput converts the encoded response message returned by the Go contract method to ABI
+// encoded return values.
+func (m *abiMethod) encodeOutput(body []byte) ([]byte, error) {
+	if m.output == nil {
+		return m.outputs.Pack(true)
+	}
+	msg := m.output.newMessage()
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+	return m.outputs.Pack(m.output.toValues(msg)...)
+}
+
+// abiEvent maps an event in the generated ABI to an event emitted by a Go contract. Address
+// fields of the event are indexed (up to the max of three indexed arguments), the rest of the
+// fields are ABI encoded in the log data.
+type abiEvent struct {
+	abiName string
+	topic   common.Hash
+	codec   *messageCodec
+	indexed []bool
+	data    abi.Arguments
+}
+
+func newABIEvent(msg proto.Message) (*abiEvent, error) {
+	codec, err := newMessageCodec(reflect.TypeOf(msg))
+	if err != nil {
+		return nil, err
+	}
+	event := &abiEvent{
+		abiName: strings.TrimSuffix(codec.typ.Elem().Name(), "Event"),
+		codec:   codec,
+		indexed: make([]bool, len(codec.args)),
+	}
+	numIndexed := 0
+	for i, arg := range codec.args {
+		if arg.Type == "address" && numIndexed < 3 {
+			event.indexed[i] = true
+			numIndexed++
+		}
+	}
+	event.topic = crypto.Keccak256Hash([]byte(abiSignature(event.abiName, codec.args)))
+	return event, nil
+}
+
+func (e *abiEvent) entry() abiEntry {
+	entry := abiEntry{Type: "event", Name: e.abiName, Inputs: []abiArgument{}}
+	for i, arg := range e.codec.args {
+		arg.Indexed = e.indexed[i]
+		entry.Inputs = append(entry.Inputs, arg)
+	}
+	return entry
+}
+
+// toLog converts an encoded event emitted by a Go contract to an EVM log.
+func (e *abiEvent) toLog(contractAddr common.Address, body []byte) (*etypes.Log, error) {
+	msg := e.codec.newMessage()
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s event", e.abiName)
+	}
+	topics := []common.Hash{e.topic}
+	var data []interface{}
+	for i, value := range e.codec.toValues(msg) {
+		if e.indexed[i] {
+			topics = append(topics, common.BytesToHash(value.(common.Address).Bytes()))
+		} else {
+			data = append(data, value)
+		}
+	}
+	encodedData, err := e.data.Pack(data...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode %s event", e.abiName)
+	}
+	return &etypes.Log{
+		Address: contractAddr,
+		Topics:  topics,
+		Data:    encodedData,
+	}, nil
+}
+
+// contractABI is the ABI generated for a Go contract.
+type contractABI struct {
+	json    string
+	methods map[string]*abiMethod // keyed by function selector
+	events  map[string]*abiEvent  // keyed by the topic the Go contract emits the event under
+}
+
+// newContractABI generates an ABI for the given contract. Only methods with requests & responses
+// that can be represented in the ABI are included, the rest are skipped.
+func newContractABI(spec ContractSpec) (*contractABI, error) {
+	c := &contractABI{
+		methods: map[string]*abiMethod{},
+		events:  map[string]*abiEvent{},
+	}
+	var entries []abiEntry
+
+	typ := reflect.TypeOf(spec.Contract)
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if m.Name == "Init" || m.Name == "Meta" {
+			continue
+		}
+		method, err := newABIMethod(m)
+		if err != nil {
+			continue
+		}
+		c.methods[method.selector()] = method
+		entries = append(entries, method.entry())
+	}
+	if len(c.methods) == 0 {
+		return nil, errors.New("contract has no methods that can be represented in an ABI")
+	}
+
+	eventNames := map[string]bool{}
+	topics := make([]string, 0, len(spec.Events))
+	for topic := range spec.Events {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	for _, topic := range topics {
+		event, err := newABIEvent(spec.Events[topic])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid event %s", topic)
+		}
+		if eventNames[event.abiName] {
+			return nil, errors.Errorf("duplicate event %s", event.abiName)
+		}
+		eventNames[event.abiName] = true
+		c.events[topic] = event
+		entries = append(entries, event.entry())
+	}
+
+	abiJSON, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	c.json = string(abiJSON)
+
+	parsed, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse generated ABI")
+	}
+	for _, method := range c.methods {
+		method.inputs = parsed.Methods[method.abiName].Inputs
+		method.outputs = parsed.Methods[method.abiName].Outputs
+	}
+	for _, event := range c.events {
+		for _, arg := range parsed.Events[event.abiName].Inputs {
+			if !arg.Indexed {
+				event.data = append(event.data, arg)
+			}
+		}
+	}
+	return c, nil
+}
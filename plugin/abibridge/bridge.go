@@ -0,0 +1,215 @@
+// +build evm
+
+// Package abibridge exposes selected Go contracts to Ethereum tooling. A Solidity ABI is generated
+// for each contract from its methods, ABI encoded calls to the contract are translated to regular
+// Go contract calls, and the results & events are encoded back into ABI data & EVM logs.
+package abibridge
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom"
+	lp "github.com/loomnetwork/go-loom/plugin"
+	ptypes "github.com/loomnetwork/go-loom/plugin/types"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/plugin"
+	"github.com/loomnetwork/loomchain/registry"
+)
+
+// Bridge translates ABI encoded calls to Go contracts.
+type Bridge struct {
+	contracts map[string]*contractABI
+}
+
+// NewBridge generates ABIs for the given contracts.
+func NewBridge(specs ...ContractSpec) (*Bridge, error) {
+	b := &Bridge{
+		contracts: map[string]*contractABI{},
+	}
+	for _, spec := range specs {
+		c, err := newContractABI(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate ABI for %s contract", spec.Name)
+		}
+		b.contracts[spec.Name] = c
+	}
+	return b, nil
+}
+
+// ContractABI returns the JSON ABI of the named contract.
+func (b *Bridge) ContractABI(name string) (string, error) {
+	c, ok := b.contracts[name]
+	if !ok {
+		return "", errors.Errorf("contract %s is not exposed via the ABI bridge", name)
+	}
+	return c.json, nil
+}
+
+// ContractName returns the name of the contract deployed at the given address, or an empty string
+// if the contract isn't exposed via the bridge.
+func (b *Bridge) ContractName(reg registry.Registry, addr loom.Address) string {
+	rec, err := reg.GetRecord(addr)
+	if err != nil {
+		return ""
+	}
+	if _, ok := b.contracts[rec.Name]; !ok {
+		return ""
+	}
+	return rec.Name
+}
+
+// Call executes the contract method matching the given ABI encoded input, and returns the ABI
+// encoded result, along with any events emitted by the contract converted to EVM logs.
+func (b *Bridge) Call(
+	pvm *plugin.PluginVM, caller, addr loom.Address, input []byte,
+) ([]byte, []*etypes.Log, error) {
+	c, method, err := b.lookupMethod(pvm, addr, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	recorder := &eventRecorder{EventHandler: pvm.EventHandler}
+	vm := *pvm
+	vm.EventHandler = recorder
+	output, err := callMethod(&vm, method, caller, addr, input, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs, err := c.eventLogs(addr, recorder.events)
+	if err != nil {
+		return nil, nil, err
+	}
+	return output, logs, nil
+}
+
+// StaticCall executes the read-only contract method matching the given ABI encoded input, and
+// returns the ABI encoded result.
+func (b *Bridge) StaticCall(pvm *plugin.PluginVM, caller, addr loom.Address, input []byte) ([]byte, error) {
+	_, method, err := b.lookupMethod(pvm, addr, input)
+	if err != nil {
+		return nil, err
+	}
+	if !method.readOnly {
+		return nil, errors.Errorf("%s is not a view function", method.abiName)
+	}
+	return callMethod(pvm, method, caller, addr, input, true)
+}
+
+// Query executes the contract method matching the given ABI encoded input on behalf of eth_call.
+// Methods that modify state are executed against a throwaway copy of the state, so the result of
+// a tx can be previewed without persisting any changes.
+func (b *Bridge) Query(pvm *plugin.PluginVM, caller, addr loom.Address, input []byte) ([]byte, error) {
+	_, method, err := b.lookupMethod(pvm, addr, input)
+	if err != nil {
+		return nil, err
+	}
+	if method.readOnly {
+		return callMethod(pvm, method, caller, addr, input, true)
+	}
+	vm := *pvm
+	vm.State = loomchain.NewThrowawayState(pvm.State.Context(), pvm.State)
+	vm.EventHandler = &eventRecorder{}
+	return callMethod(&vm, method, caller, addr, input, false)
+}
+
+func (b *Bridge) lookupMethod(
+	pvm *plugin.PluginVM, addr loom.Address, input []byte,
+) (*contractABI, *abiMethod, error) {
+	name := b.ContractName(pvm.Registry, addr)
+	if name == "" {
+		return nil, nil, errors.Errorf("contract %s is not exposed via the ABI bridge", addr.String())
+	}
+	if len(input) < 4 {
+		return nil, nil, errors.New("input is too short to contain a function selector")
+	}
+	c := b.contracts[name]
+	method, ok := c.methods[string(input[:4])]
+	if !ok {
+		return nil, nil, errors.Errorf("function 0x%x not found in %s contract ABI", input[:4], name)
+	}
+	return c, method, nil
+}
+
+func callMethod(
+	pvm *plugin.PluginVM, method *abiMethod, caller, addr loom.Address, input []byte, readOnly bool,
+) ([]byte, error) {
+	req, err := method.decodeInput(addr.ChainID, input[4:])
+	if err != nil {
+		return nil, err
+	}
+	args, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := proto.Marshal(&lp.ContractMethodCall{
+		Method: method.goName,
+		Args:   args,
+	})
+	if err != nil {
+		return nil, err
+	}
+	callInput, err := proto.Marshal(&lp.Request{
+		ContentType: lp.EncodingType_PROTOBUF3,
+		Accept:      lp.EncodingType_PROTOBUF3,
+		Body:        body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var respBytes []byte
+	if readOnly {
+		respBytes, err = pvm.StaticCall(caller, addr, callInput)
+	} else {
+		respBytes, err = pvm.Call(caller, addr, callInput, loom.NewBigUIntFromInt(0))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var resp lp.Response
+	if err := proto.Unmarshal(respBytes, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal contract response")
+	}
+	return method.encodeOutput(resp.Body)
+}
+
+// eventLogs converts the events emitted by the contract at the given address to EVM logs, events
+// emitted by other contracts, or under topics without a matching ABI event, are skipped.
+func (c *contractABI) eventLogs(addr loom.Address, events []*ptypes.EventData) ([]*etypes.Log, error) {
+	var logs []*etypes.Log
+	for _, e := range events {
+		if e.Address == nil || loom.UnmarshalAddressPB(e.Address).Compare(addr) != 0 {
+			continue
+		}
+		for _, topic := range e.Topics {
+			event, ok := c.events[topic]
+			if !ok {
+				continue
+			}
+			log, err := event.toLog(common.BytesToAddress(addr.Local), e.EncodedBody)
+			if err != nil {
+				return nil, err
+			}
+			logs = append(logs, log)
+			break
+		}
+	}
+	return logs, nil
+}
+
+// eventRecorder records the events posted by Go contracts, and forwards them to the wrapped event
+// handler (if any).
+type eventRecorder struct {
+	loomchain.EventHandler
+	events []*ptypes.EventData
+}
+
+func (r *eventRecorder) Post(height uint64, e *ptypes.EventData) error {
+	r.events = append(r.events, e)
+	if r.EventHandler == nil {
+		return nil
+	}
+	return r.EventHandler.Post(height, e)
+}
@@ -0,0 +1,31 @@
+// +build !evm
+
+package abibridge
+
+import (
+	"github.com/loomnetwork/go-loom"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/plugin"
+	"github.com/loomnetwork/loomchain/registry"
+)
+
+var errNotSupported = errors.New("ABI bridge is not supported in non-EVM builds")
+
+type Bridge struct{}
+
+func NewBridge(specs ...ContractSpec) (*Bridge, error) {
+	return &Bridge{}, nil
+}
+
+func (b *Bridge) ContractABI(name string) (string, error) {
+	return "", errNotSupported
+}
+
+func (b *Bridge) ContractName(reg registry.Registry, addr loom.Address) string {
+	return ""
+}
+
+func (b *Bridge) Query(pvm *plugin.PluginVM, caller, addr loom.Address, input []byte) ([]byte, error) {
+	return nil, errNotSupported
+}
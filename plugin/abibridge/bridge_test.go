@@ -0,0 +1,145 @@
+// +build evm
+
+package abibridge
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom"
+	lp "github.com/loomnetwork/go-loom/plugin"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/builtin/plugins/coin"
+	"github.com/loomnetwork/loomchain/plugin"
+	registry "github.com/loomnetwork/loomchain/registry/factory"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+var (
+	addr1 = loom.MustParseAddress("default:0xb16a379ec18d4093666f8f38b11a3071c920207d")
+	addr2 = loom.MustParseAddress("default:0xfa4c7920accfd66b86f5fd0e69682a79f762d49e")
+)
+
+func newCoinBridge(t *testing.T) *Bridge {
+	bridge, err := NewBridge(ContractSpec{
+		Name:     "coin",
+		Contract: &coin.Coin{},
+		Events: map[string]proto.Message{
+			coin.TransferEventTopic: &coin.TransferEvent{},
+			coin.ApprovalEventTopic: &coin.ApprovalEvent{},
+		},
+	})
+	require.NoError(t, err)
+	return bridge
+}
+
+func TestCoinABI(t *testing.T) {
+	bridge := newCoinBridge(t)
+	abiJSON, err := bridge.ContractABI("coin")
+	require.NoError(t, err)
+	coinABI, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+
+	// The generated ABI should be compatible with ERC20
+	input, err := coinABI.Pack("transfer", common.Address{}, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, "a9059cbb", hex.EncodeToString(input[:4]))
+	input, err = coinABI.Pack("balanceOf", common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, "70a08231", hex.EncodeToString(input[:4]))
+	require.True(t, coinABI.Methods["balanceOf"].Const)
+	require.False(t, coinABI.Methods["transfer"].Const)
+
+	_, err = bridge.ContractABI("dposV3")
+	require.Error(t, err)
+}
+
+func TestCoinCalls(t *testing.T) {
+	block := abci.Header{
+		ChainID: "default",
+		Height:  int64(34),
+		Time:    time.Unix(123456789, 0),
+	}
+	state := loomchain.NewStoreState(context.Background(), store.NewMemStore(), block, nil, nil)
+	createRegistry, err := registry.NewRegistryFactory(registry.LatestRegistryVersion)
+	require.NoError(t, err)
+	reg := createRegistry(state)
+	pvm := plugin.NewPluginVM(plugin.NewStaticLoader(coin.Contract), state, reg, nil, nil, nil, nil, nil)
+
+	initBody, err := proto.Marshal(&coin.InitRequest{
+		Accounts: []*coin.InitialAccount{{Owner: addr1.MarshalPB(), Balance: 10}},
+	})
+	require.NoError(t, err)
+	initInput, err := proto.Marshal(&lp.Request{
+		ContentType: lp.EncodingType_PROTOBUF3,
+		Accept:      lp.EncodingType_PROTOBUF3,
+		Body:        initBody,
+	})
+	require.NoError(t, err)
+	code, err := proto.Marshal(&lp.Code{Name: "coin", Input: initInput})
+	require.NoError(t, err)
+	_, coinAddr, err := pvm.Create(addr1, code, loom.NewBigUIntFromInt(0))
+	require.NoError(t, err)
+	require.NoError(t, reg.Register("coin", coinAddr, addr1))
+
+	bridge := newCoinBridge(t)
+	require.Equal(t, "coin", bridge.ContractName(reg, coinAddr))
+	require.Equal(t, "", bridge.ContractName(reg, addr2))
+	abiJSON, err := bridge.ContractABI("coin")
+	require.NoError(t, err)
+	coinABI, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+
+	balanceOf := func(owner loom.Address) *big.Int {
+		input, err := coinABI.Pack("balanceOf", common.BytesToAddress(owner.Local))
+		require.NoError(t, err)
+		output, err := bridge.StaticCall(pvm, addr1, coinAddr, input)
+		require.NoError(t, err)
+		var balance *big.Int
+		require.NoError(t, coinABI.Unpack(&balance, "balanceOf", output))
+		return balance
+	}
+	initialBalance := new(big.Int).Mul(big.NewInt(10), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	require.Equal(t, initialBalance, balanceOf(addr1))
+
+	amount := big.NewInt(100)
+	input, err := coinABI.Pack("transfer", common.BytesToAddress(addr2.Local), amount)
+	require.NoError(t, err)
+
+	// eth_call shouldn't modify the state
+	output, err := bridge.Query(pvm, addr1, coinAddr, input)
+	require.NoError(t, err)
+	var success bool
+	require.NoError(t, coinABI.Unpack(&success, "transfer", output))
+	require.True(t, success)
+	require.Equal(t, int64(0), balanceOf(addr2).Int64())
+
+	output, logs, err := bridge.Call(pvm, addr1, coinAddr, input)
+	require.NoError(t, err)
+	require.NoError(t, coinABI.Unpack(&success, "transfer", output))
+	require.True(t, success)
+	require.Equal(t, amount, balanceOf(addr2))
+
+	require.Len(t, logs, 1)
+	require.Equal(t, common.BytesToAddress(coinAddr.Local), logs[0].Address)
+	require.Equal(t, []common.Hash{
+		common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		common.BytesToHash(addr1.Local),
+		common.BytesToHash(addr2.Local),
+	}, logs[0].Topics)
+	require.Equal(t, common.LeftPadBytes(amount.Bytes(), 32), logs[0].Data)
+
+	// view functions can't be used to modify state
+	_, err = bridge.StaticCall(pvm, addr1, coinAddr, input)
+	require.Error(t, err)
+}
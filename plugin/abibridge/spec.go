@@ -0,0 +1,16 @@
+package abibridge
+
+import (
+	"github.com/gogo/protobuf/proto"
+)
+
+// ContractSpec specifies a Go contract that should be exposed via the ABI bridge.
+type ContractSpec struct {
+	// Name the contract is registered under.
+	Name string
+	// Contract instance, the ABI is generated from the exported methods of the contract.
+	Contract interface{}
+	// Events emitted by the contract that should be converted to EVM logs, keyed by the topic the
+	// contract emits them under.
+	Events map[string]proto.Message
+}
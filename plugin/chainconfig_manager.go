@@ -19,9 +19,11 @@ var (
 
 // ChainConfigManager implements loomchain.ChainConfigManager interface
 type ChainConfigManager struct {
-	ctx   contract.Context
-	state loomchain.State
-	build uint64
+	ctx      contract.Context
+	state    loomchain.State
+	registry regcommon.Registry
+	loader   Loader
+	build    uint64
 }
 
 // NewChainConfigManager attempts to create an instance of ChainConfigManager.
@@ -41,9 +43,11 @@ func NewChainConfigManager(pvm *PluginVM, state loomchain.State) (*ChainConfigMa
 		build = 0
 	}
 	return &ChainConfigManager{
-		ctx:   ctx,
-		state: state,
-		build: build,
+		ctx:      ctx,
+		state:    state,
+		registry: pvm.Registry,
+		loader:   pvm.Loader,
+		build:    build,
 	}, nil
 }
 
@@ -101,3 +105,48 @@ func (c *ChainConfigManager) UpdateConfig() (int, error) {
 	}
 	return len(settings), nil
 }
+
+// ApplyContractUpgrades schedules the contract upgrades that have been approved in the ChainConfig
+// contract in the contract registry. Upgrades that can't be scheduled, e.g. because their block
+// height has already been reached, or because the plugin they upgrade to can't be loaded, are
+// logged and discarded.
+func (c *ChainConfigManager) ApplyContractUpgrades() error {
+	if !c.state.FeatureEnabled(features.ContractUpgradeFeature, false) {
+		return nil
+	}
+
+	proposals, err := chainconfig.HarvestContractUpgrades(c.ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, proposal := range proposals {
+		contractAddr := loom.UnmarshalAddressPB(proposal.ContractAddress)
+		err := scheduleContractUpgrade(c.loader, c.registry, contractAddr, &regcommon.ContractUpgrade{
+			PluginName:  proposal.PluginName,
+			BlockHeight: proposal.BlockHeight,
+		})
+		if err != nil {
+			c.ctx.Logger().Error(
+				"failed to schedule contract upgrade",
+				"contract", contractAddr.String(),
+				"plugin", proposal.PluginName,
+				"height", proposal.BlockHeight,
+				"err", err,
+			)
+		}
+	}
+	return nil
+}
+
+// scheduleContractUpgrade schedules an upgrade of the contract at the given address in the contract
+// registry. Upgrades to plugins the loader can't load are rejected, since the contract would be left
+// without any code to run once the upgrade takes effect.
+func scheduleContractUpgrade(
+	loader Loader, registry regcommon.Registry, contractAddr loom.Address, upgrade *regcommon.ContractUpgrade,
+) error {
+	if _, err := loader.LoadContract(upgrade.PluginName, upgrade.BlockHeight); err != nil {
+		return errors.Wrapf(err, "failed to load plugin %s", upgrade.PluginName)
+	}
+	return registry.ScheduleUpgrade(contractAddr, upgrade)
+}
@@ -0,0 +1,149 @@
+package plugin
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom"
+	lp "github.com/loomnetwork/go-loom/plugin"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
+	"github.com/loomnetwork/loomchain/builtin/plugins/governance"
+	"github.com/loomnetwork/loomchain/features"
+	regcommon "github.com/loomnetwork/loomchain/registry"
+)
+
+var (
+	// ErrGovernanceContractNotFound indicates that the Governance contract hasn't been deployed yet.
+	ErrGovernanceContractNotFound = errors.New("[GovernanceManager] Governance contract not found")
+)
+
+// GovernanceManager implements loomchain.GovernanceManager interface
+type GovernanceManager struct {
+	ctx          contract.Context
+	pvm          *PluginVM
+	state        loomchain.State
+	contractAddr loom.Address
+}
+
+// NewGovernanceManager attempts to create an instance of GovernanceManager.
+func NewGovernanceManager(pvm *PluginVM, state loomchain.State) (*GovernanceManager, error) {
+	caller := loom.RootAddress(pvm.State.Block().ChainID)
+	contractAddr, err := pvm.Registry.Resolve("governance")
+	if err != nil {
+		if err == regcommon.ErrNotFound {
+			return nil, ErrGovernanceContractNotFound
+		}
+		return nil, err
+	}
+	readOnly := false
+	ctx := contract.WrapPluginContext(pvm.CreateContractContext(caller, contractAddr, readOnly))
+	return &GovernanceManager{
+		ctx:          ctx,
+		pvm:          pvm,
+		state:        state,
+		contractAddr: contractAddr,
+	}, nil
+}
+
+// HarvestProposals tallies the votes on the proposals whose voting period has ended, and returns
+// the IDs of the ones that passed and should be executed.
+func (m *GovernanceManager) HarvestProposals() ([]uint64, error) {
+	if !m.state.FeatureEnabled(features.GovernanceFeature, false) {
+		return nil, nil
+	}
+
+	proposals, err := governance.HarvestProposals(m.ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint64, len(proposals))
+	for i, proposal := range proposals {
+		ids[i] = proposal.Id
+	}
+	return ids, nil
+}
+
+// SetProposalExecuted records the outcome of the execution of a proposal in the Governance contract.
+func (m *GovernanceManager) SetProposalExecuted(id uint64, execErr error) error {
+	if execErr != nil {
+		m.ctx.Logger().Error("failed to execute governance proposal", "id", id, "err", execErr)
+	} else {
+		m.ctx.Logger().Info("executed governance proposal", "id", id)
+	}
+	return governance.SetProposalExecuted(m.ctx, id, execErr)
+}
+
+// ExecuteProposal executes the action specified in a proposal that passed. The caller is
+// responsible for discarding any changes made to the state if the execution fails.
+func (m *GovernanceManager) ExecuteProposal(id uint64) error {
+	proposal, err := governance.LoadProposal(m.ctx, id)
+	if err != nil {
+		return err
+	}
+	if !proposal.Passed || proposal.Executed {
+		return errors.Errorf("proposal %d can't be executed", id)
+	}
+
+	switch {
+	case proposal.ParamChange != nil:
+		return m.changeParam(proposal.ParamChange)
+
+	case proposal.FeatureActivation != nil:
+		return m.activateFeature(proposal.FeatureActivation.FeatureName)
+
+	case proposal.ContractUpgrade != nil:
+		if !m.state.FeatureEnabled(features.ContractUpgradeFeature, false) {
+			return errors.New("contract upgrades are not enabled")
+		}
+		upgrade := proposal.ContractUpgrade
+		contractAddr, err := m.pvm.Registry.Resolve(upgrade.ContractName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve address of contract %s", upgrade.ContractName)
+		}
+		return scheduleContractUpgrade(m.pvm.Loader, m.pvm.Registry, contractAddr, &regcommon.ContractUpgrade{
+			PluginName:  upgrade.PluginName,
+			BlockHeight: upgrade.BlockHeight,
+		})
+	}
+	return errors.New("proposal has no action")
+}
+
+// changeParam calls the contract method specified in the proposal, the Governance contract is used
+// as the caller so the target contract can check that the call originated from a proposal.
+func (m *GovernanceManager) changeParam(change *governance.ParamChange) error {
+	contractAddr, err := m.pvm.Registry.Resolve(change.ContractName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve address of contract %s", change.ContractName)
+	}
+	body, err := proto.Marshal(&lp.ContractMethodCall{
+		Method: change.Method,
+		Args:   change.Args,
+	})
+	if err != nil {
+		return err
+	}
+	input, err := proto.Marshal(&lp.Request{
+		ContentType: lp.EncodingType_PROTOBUF3,
+		Accept:      lp.EncodingType_PROTOBUF3,
+		Body:        body,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = m.pvm.Call(m.contractAddr, contractAddr, input, loom.NewBigUIntFromInt(0))
+	return err
+}
+
+// activateFeature activates a feature via the ChainConfig contract, so the feature is activated
+// the same way as features the validators voted for.
+func (m *GovernanceManager) activateFeature(name string) error {
+	chainConfigAddr, err := m.pvm.Registry.Resolve("chainconfig")
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve address of ChainConfig contract")
+	}
+	readOnly := false
+	ctx := contract.WrapPluginContext(m.pvm.CreateContractContext(m.contractAddr, chainConfigAddr, readOnly))
+	return chainconfig.ActivateFeature(ctx, name, uint64(m.state.Block().Height))
+}
@@ -0,0 +1,176 @@
+// +build evm
+
+package plugin
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom"
+	loom_plugin "github.com/loomnetwork/go-loom/plugin"
+	contract "github.com/loomnetwork/go-loom/plugin/contractpb"
+	"github.com/loomnetwork/go-loom/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
+	"github.com/loomnetwork/loomchain/builtin/plugins/coin"
+	"github.com/loomnetwork/loomchain/builtin/plugins/dposv3"
+	"github.com/loomnetwork/loomchain/builtin/plugins/governance"
+	"github.com/loomnetwork/loomchain/features"
+	registry "github.com/loomnetwork/loomchain/registry/factory"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+func TestGovernanceManagerExecuteProposals(t *testing.T) {
+	candidatePubKey, err := hex.DecodeString("3866f776276246e4f9998aa90632931d89b0d3a5930e804e02299533f55b39e1")
+	require.NoError(t, err)
+	candidateAddr := loom.Address{ChainID: "chain", Local: loom.LocalAddressFromPublicKey(candidatePubKey)}
+	oracleAddr := vmAddr1
+	voterAddr := vmAddr2
+	owner := loom.RootAddress("chain")
+
+	kvStore := store.NewMemStore()
+	createRegistry, err := registry.NewRegistryFactory(registry.LatestRegistryVersion)
+	require.NoError(t, err)
+	loader := NewStaticLoader(coin.Contract, dposv3.Contract, governance.Contract, chainconfig.Contract)
+	newVM := func(blockTime int64) *PluginVM {
+		state := loomchain.NewStoreState(context.Background(), kvStore, abci.Header{
+			ChainID: "chain",
+			Height:  10,
+			Time:    time.Unix(blockTime, 0),
+		}, nil, nil)
+		return NewPluginVM(loader, state, createRegistry(state), &fakeEventHandler{}, nil, nil, nil, nil)
+	}
+
+	startTime := int64(123456789)
+	vm := newVM(startTime)
+	vm.State.SetFeature(features.GovernanceFeature, true)
+
+	coinAddr, err := deployGoContractWithInit(vm, "coin:1.0.0", 0, owner, &coin.InitRequest{
+		Accounts: []*coin.InitialAccount{{Owner: voterAddr.MarshalPB(), Balance: 1}},
+	})
+	require.NoError(t, err)
+	dposAddr, err := deployGoContractWithInit(vm, "dposV3:3.0.0", 1, owner, &dposv3.InitRequest{
+		Params: &dposv3.Params{
+			ValidatorCount:      21,
+			CoinContractAddress: coinAddr.MarshalPB(),
+			OracleAddress:       oracleAddr.MarshalPB(),
+		},
+	})
+	require.NoError(t, err)
+	govAddr, err := deployGoContractWithInit(vm, "governance:1.0.0", 2, owner, &governance.InitRequest{
+		Params: &governance.Params{
+			VotingPeriod:     100,
+			MinProposerStake: &types.BigUInt{Value: *loom.NewBigUIntFromInt(1000)},
+		},
+	})
+	require.NoError(t, err)
+	chainConfigAddr, err := deployGoContractWithInit(vm, "chainconfig:1.0.0", 3, owner, &chainconfig.InitRequest{
+		Owner: owner.MarshalPB(),
+		Features: []*chainconfig.Feature{
+			{Name: "test:v1", Status: chainconfig.FeaturePending},
+		},
+	})
+	require.NoError(t, err)
+
+	// Give the voter all the stake delegated to the only validator
+	amount := &types.BigUInt{Value: *loom.NewBigUIntFromInt(1000)}
+	require.NoError(t, callGoContractMethod(vm, oracleAddr, dposAddr, "WhitelistCandidate",
+		&dposv3.WhitelistCandidateRequest{
+			CandidateAddress: candidateAddr.MarshalPB(),
+			Amount:           &types.BigUInt{Value: *loom.NewBigUIntFromInt(1)},
+		}))
+	require.NoError(t, callGoContractMethod(vm, candidateAddr, dposAddr, "RegisterCandidate",
+		&dposv3.RegisterCandidateRequest{PubKey: candidatePubKey}))
+	require.NoError(t, callGoContractMethod(vm, voterAddr, coinAddr, "Approve",
+		&coin.ApproveRequest{Spender: dposAddr.MarshalPB(), Amount: amount}))
+	require.NoError(t, callGoContractMethod(vm, voterAddr, dposAddr, "Delegate",
+		&dposv3.DelegateRequest{ValidatorAddress: candidateAddr.MarshalPB(), Amount: amount}))
+	require.NoError(t, dposv3.Elect(contract.WrapPluginContext(vm.CreateContractContext(owner, dposAddr, false))))
+
+	// Only the oracle can change the params without going through the Governance contract
+	maxYearlyReward := &dposv3.SetMaxYearlyRewardRequest{
+		MaxYearlyReward: &types.BigUInt{Value: *loom.NewBigUIntFromInt(1234)},
+	}
+	require.Error(t, callGoContractMethod(vm, voterAddr, dposAddr, "SetMaxYearlyReward", maxYearlyReward))
+	args, err := proto.Marshal(maxYearlyReward)
+	require.NoError(t, err)
+
+	proposals := []*governance.SubmitProposalRequest{
+		{ParamChange: &governance.ParamChange{ContractName: "dposV3", Method: "SetMaxYearlyReward", Args: args}},
+		{FeatureActivation: &governance.FeatureActivation{FeatureName: "test:v1"}},
+		// fails to execute because the feature hasn't been added to the ChainConfig contract
+		{FeatureActivation: &governance.FeatureActivation{FeatureName: "unknown:v1"}},
+	}
+	for i, proposal := range proposals {
+		require.NoError(t, callGoContractMethod(vm, voterAddr, govAddr, "SubmitProposal", proposal))
+		require.NoError(t, callGoContractMethod(vm, voterAddr, govAddr, "Vote",
+			&governance.VoteRequest{ProposalId: uint64(i + 1), Approve: true}))
+	}
+
+	vm = newVM(startTime + 100)
+	manager, err := NewGovernanceManager(vm, vm.State)
+	require.NoError(t, err)
+	ids, err := manager.HarvestProposals()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3}, ids)
+	for _, id := range ids {
+		require.NoError(t, manager.SetProposalExecuted(id, manager.ExecuteProposal(id)))
+	}
+
+	dposState, err := dposv3.LoadState(contract.WrapPluginContext(vm.CreateContractContext(owner, dposAddr, true)))
+	require.NoError(t, err)
+	require.Equal(t, int64(1234), dposState.Params.MaxYearlyReward.Value.Int64())
+
+	chainConfigCtx := contract.WrapPluginContext(vm.CreateContractContext(owner, chainConfigAddr, true))
+	featureResp, err := (&chainconfig.ChainConfig{}).GetFeature(chainConfigCtx, &chainconfig.GetFeatureRequest{
+		Name: "test:v1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, chainconfig.FeatureWaiting, featureResp.Feature.Status)
+	require.False(t, vm.State.FeatureEnabled("unknown:v1", false))
+
+	govCtx := contract.WrapPluginContext(vm.CreateContractContext(owner, govAddr, true))
+	for _, id := range ids {
+		proposal, err := governance.LoadProposal(govCtx, id)
+		require.NoError(t, err)
+		require.Equal(t, id != 3, proposal.Executed)
+		require.Equal(t, id == 3, proposal.ExecutionError != "")
+	}
+}
+
+func deployGoContractWithInit(
+	vm *PluginVM, contractID string, contractNum uint64, owner loom.Address, initReq proto.Message,
+) (loom.Address, error) {
+	body, err := proto.Marshal(initReq)
+	if err != nil {
+		return loom.Address{}, err
+	}
+	init, err := proto.Marshal(&Request{
+		ContentType: loom_plugin.EncodingType_PROTOBUF3,
+		Accept:      loom_plugin.EncodingType_PROTOBUF3,
+		Body:        body,
+	})
+	if err != nil {
+		return loom.Address{}, err
+	}
+	code, err := proto.Marshal(&PluginCode{
+		Name:  contractID,
+		Input: init,
+	})
+	if err != nil {
+		return loom.Address{}, err
+	}
+	_, contractAddr, err := vm.Create(CreateAddress(owner, contractNum), code, loom.NewBigUIntFromInt(0))
+	if err != nil {
+		return loom.Address{}, err
+	}
+	name := strings.Split(contractID, ":")[0]
+	return contractAddr, vm.Registry.Register(name, contractAddr, owner)
+}
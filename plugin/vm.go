@@ -18,7 +18,9 @@ import (
 	"github.com/loomnetwork/loomchain"
 	"github.com/loomnetwork/loomchain/auth"
 	levm "github.com/loomnetwork/loomchain/evm"
+	"github.com/loomnetwork/loomchain/features"
 	"github.com/loomnetwork/loomchain/registry"
+	"github.com/loomnetwork/loomchain/store"
 	"github.com/loomnetwork/loomchain/vm"
 	"github.com/pkg/errors"
 )
@@ -100,12 +102,17 @@ func (vm *PluginVM) run(
 		return nil, err
 	}
 
-	contract, err := vm.Loader.LoadContract(pluginCode.Name, vm.State.Block().Height)
+	isInit := len(input) == 0
+	var contract lp.Contract
+	if isInit {
+		contract, err = vm.Loader.LoadContract(pluginCode.Name, vm.State.Block().Height)
+	} else {
+		contract, err = vm.loadUpgradedContract(addr, pluginCode.Name)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	isInit := len(input) == 0
 	if isInit {
 		input = pluginCode.Input
 	}
@@ -144,6 +151,42 @@ func (vm *PluginVM) run(
 	return proto.Marshal(res)
 }
 
+// loadUpgradedContract loads the code that should be run for the contract at the given address at
+// the current block height, taking into account any upgrades scheduled for the contract in the
+// registry. Upgrades to plugins the loader can't find are skipped, so that an upgrade to a plugin
+// that doesn't exist can't brick the contract.
+func (vm *PluginVM) loadUpgradedContract(addr loom.Address, pluginName string) (lp.Contract, error) {
+	blockHeight := vm.State.Block().Height
+	if vm.Registry == nil || !vm.State.FeatureEnabled(features.ContractUpgradeFeature, false) {
+		return vm.Loader.LoadContract(pluginName, blockHeight)
+	}
+	upgrades, err := vm.Registry.GetUpgrades(addr)
+	if err != nil {
+		if err == registry.ErrNotImplemented {
+			return vm.Loader.LoadContract(pluginName, blockHeight)
+		}
+		return nil, errors.Wrapf(err, "failed to load upgrades of contract %s", addr.String())
+	}
+	for i := len(upgrades) - 1; i >= 0; i-- {
+		if blockHeight < upgrades[i].BlockHeight {
+			continue
+		}
+		contract, err := vm.Loader.LoadContract(upgrades[i].PluginName, blockHeight)
+		if err != ErrPluginNotFound {
+			return contract, err
+		}
+		if vm.logger != nil {
+			vm.logger.Error(
+				"Skipping contract upgrade, plugin not found",
+				"contract", addr.String(),
+				"plugin", upgrades[i].PluginName,
+				"height", upgrades[i].BlockHeight,
+			)
+		}
+	}
+	return vm.Loader.LoadContract(pluginName, blockHeight)
+}
+
 func CreateAddress(parent loom.Address, nonce uint64) loom.Address {
 	var nonceBuf bytes.Buffer
 	err := binary.Write(&nonceBuf, binary.BigEndian, nonce)
@@ -300,6 +343,12 @@ func (c *contractContext) ContractAddress() loom.Address {
 	return c.address
 }
 
+// RangeFrom implements pagination.RangeFrom, which lets the list methods of the built-in
+// contracts read a page of keys without loading all the keys with the same prefix.
+func (c *contractContext) RangeFrom(prefix, start []byte, limit int) lp.RangeData {
+	return store.RangeFrom(c.State, prefix, start, limit)
+}
+
 func (c *contractContext) Now() time.Time {
 	return time.Unix(c.State.Block().Time, 0)
 }
@@ -22,11 +22,14 @@ import (
 	"github.com/loomnetwork/loomchain/eth/subs"
 	"github.com/loomnetwork/loomchain/events"
 	levm "github.com/loomnetwork/loomchain/evm"
+	"github.com/loomnetwork/loomchain/features"
 	rcommon "github.com/loomnetwork/loomchain/receipts/common"
 	"github.com/loomnetwork/loomchain/receipts/handler"
+	lregistry "github.com/loomnetwork/loomchain/registry"
 	registry "github.com/loomnetwork/loomchain/registry/factory"
 	"github.com/loomnetwork/loomchain/store"
 	lvm "github.com/loomnetwork/loomchain/vm"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -372,3 +375,73 @@ func staticCallEVMContractMethod(ctx contract.StaticContext, contractAddr loom.A
 	}
 	return contractABI.Unpack(result, method, output)
 }
+
+func TestPluginVMLoadUpgradedContract(t *testing.T) {
+	memStore := store.NewMemStore()
+	loader := NewStaticLoader(&VMTestContract{Name: "coin"}, &VMTestContract{Name: "coinv2"})
+	newVM := func(height int64, regVer registry.RegistryVersion) *PluginVM {
+		block := abci.Header{ChainID: "chain", Height: height, Time: time.Now()}
+		state := loomchain.NewStoreState(context.Background(), memStore, block, nil, nil)
+		createRegistry, err := registry.NewRegistryFactory(regVer)
+		require.NoError(t, err)
+		return NewPluginVM(loader, state, createRegistry(state), &fakeEventHandler{}, nil, nil, nil, nil)
+	}
+	requireContract := func(vm *PluginVM, addr loom.Address, expectedName string) {
+		contract, err := vm.loadUpgradedContract(addr, "coin:0.0.1")
+		require.NoError(t, err)
+		meta, err := contract.Meta()
+		require.NoError(t, err)
+		require.Equal(t, expectedName, meta.Name)
+	}
+
+	vm := newVM(10, registry.LatestRegistryVersion)
+	require.NoError(t, vm.Registry.Register("coin", vmAddr1, vmAddr2))
+	require.NoError(t, vm.Registry.ScheduleUpgrade(
+		vmAddr1, &lregistry.ContractUpgrade{PluginName: "coinv2:0.0.1", BlockHeight: 20},
+	))
+	require.NoError(t, vm.Registry.ScheduleUpgrade(
+		vmAddr1, &lregistry.ContractUpgrade{PluginName: "coinv3:0.0.1", BlockHeight: 30},
+	))
+
+	// scheduled upgrades are ignored until the feature is enabled
+	requireContract(newVM(20, registry.LatestRegistryVersion), vmAddr1, "coin")
+
+	vm.State.SetFeature(features.ContractUpgradeFeature, true)
+	requireContract(newVM(19, registry.LatestRegistryVersion), vmAddr1, "coin")
+	requireContract(newVM(20, registry.LatestRegistryVersion), vmAddr1, "coinv2")
+
+	// upgrades to plugins that can't be loaded are skipped
+	requireContract(newVM(30, registry.LatestRegistryVersion), vmAddr1, "coinv2")
+
+	// contracts without any upgrades are loaded as is
+	requireContract(newVM(20, registry.LatestRegistryVersion), vmAddr2, "coin")
+
+	// older registry versions don't support upgrades
+	requireContract(newVM(20, registry.RegistryV1), vmAddr1, "coin")
+}
+
+func TestScheduleContractUpgrade(t *testing.T) {
+	block := abci.Header{ChainID: "chain", Height: 10, Time: time.Now()}
+	state := loomchain.NewStoreState(context.Background(), store.NewMemStore(), block, nil, nil)
+	createRegistry, err := registry.NewRegistryFactory(registry.LatestRegistryVersion)
+	require.NoError(t, err)
+	reg := createRegistry(state)
+	require.NoError(t, reg.Register("coin", vmAddr1, vmAddr2))
+	loader := NewStaticLoader(&VMTestContract{Name: "coin"}, &VMTestContract{Name: "coinv2"})
+
+	// upgrades to plugins that can't be loaded shouldn't be scheduled
+	err = scheduleContractUpgrade(
+		loader, reg, vmAddr1, &lregistry.ContractUpgrade{PluginName: "coinv3:0.0.1", BlockHeight: 20},
+	)
+	require.Equal(t, ErrPluginNotFound, errors.Cause(err))
+	upgrades, err := reg.GetUpgrades(vmAddr1)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 0)
+
+	require.NoError(t, scheduleContractUpgrade(
+		loader, reg, vmAddr1, &lregistry.ContractUpgrade{PluginName: "coinv2:0.0.1", BlockHeight: 20},
+	))
+	upgrades, err = reg.GetUpgrades(vmAddr1)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 1)
+}
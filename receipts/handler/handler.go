@@ -137,6 +137,9 @@ func (r *ReceiptHandler) CommitBlock(height int64) error {
 	defer r.mutex.Unlock()
 
 	err := r.leveldbReceipts.CommitBlock(r.receiptsCache, uint64(height))
+	if err == nil {
+		err = r.evmAuxStore.IndexBlockLogs(uint64(height), r.receiptsCache)
+	}
 	r.txHashList = [][]byte{}
 	r.receiptsCache = []*types.EvmTxReceipt{}
 	return err
@@ -22,6 +22,8 @@ var (
 	headKey          = []byte("leveldb:head")
 	tailKey          = []byte("leveldb:tail")
 	currentDbSizeKey = []byte("leveldb:size")
+
+	logger = log.Module("receipts")
 )
 
 func WriteReceipt(
@@ -159,7 +161,7 @@ func (lr *LevelDbReceipts) CommitBlock(receipts []*types.EvmTxReceipt, height ui
 			tailReceiptItem.NextTxHash = txReceipt.TxHash
 			protoTail, err := proto.Marshal(&tailReceiptItem)
 			if err != nil {
-				log.Error(fmt.Sprintf("commit block receipts: marshal receipt item: %s", err.Error()))
+				logger.Error(fmt.Sprintf("commit block receipts: marshal receipt item: %s", err.Error()))
 				continue
 			}
 			updating, err := lr.tran.Has(tailHash, nil)
@@ -168,7 +170,7 @@ func (lr *LevelDbReceipts) CommitBlock(receipts []*types.EvmTxReceipt, height ui
 			}
 
 			if err := lr.tran.Put(tailHash, protoTail, nil); err != nil {
-				log.Error(fmt.Sprintf("commit block receipts: put receipt in db: %s", err.Error()))
+				logger.Error(fmt.Sprintf("commit block receipts: put receipt in db: %s", err.Error()))
 				continue
 			} else if !updating {
 				size++
@@ -189,14 +191,14 @@ func (lr *LevelDbReceipts) CommitBlock(receipts []*types.EvmTxReceipt, height ui
 	if len(tailHash) > 0 {
 		protoTail, err := proto.Marshal(&tailReceiptItem)
 		if err != nil {
-			log.Error(fmt.Sprintf("commit block receipts: marshal receipt item: %s", err.Error()))
+			logger.Error(fmt.Sprintf("commit block receipts: marshal receipt item: %s", err.Error()))
 		} else {
 			updating, err := lr.tran.Has(tailHash, nil)
 			if err != nil {
 				return errors.Wrap(err, "cannot find tail hash")
 			}
 			if err := lr.tran.Put(tailHash, protoTail, nil); err != nil {
-				log.Error(fmt.Sprintf("commit block receipts: putting receipt in db: %s", err.Error()))
+				logger.Error(fmt.Sprintf("commit block receipts: putting receipt in db: %s", err.Error()))
 			} else if !updating {
 				size++
 			}
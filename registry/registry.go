@@ -23,4 +23,21 @@ type Registry interface {
 	Resolve(contractName string) (loom.Address, error)
 	// GetRecord looks up the meta data previously stored for the given contract
 	GetRecord(contractAddr loom.Address) (*Record, error)
+	// ScheduleUpgrade schedules a switch to a different version of the code of an existing
+	// contract, the new code will be loaded from the specified block height onwards.
+	ScheduleUpgrade(contractAddr loom.Address, upgrade *ContractUpgrade) error
+	// GetUpgrades returns all the upgrades scheduled for the given contract, in the order they
+	// take effect.
+	GetUpgrades(contractAddr loom.Address) ([]*ContractUpgrade, error)
+}
+
+// PluginNameAt returns the name of the contract code that should be loaded at the given block
+// height, or the original name if none of the given upgrades are in effect at that height.
+func PluginNameAt(upgrades []*ContractUpgrade, originalName string, blockHeight int64) string {
+	for i := len(upgrades) - 1; i >= 0; i-- {
+		if blockHeight >= upgrades[i].BlockHeight {
+			return upgrades[i].PluginName
+		}
+	}
+	return originalName
 }
@@ -32,7 +32,7 @@ func (m *Record) Reset()         { *m = Record{} }
 func (m *Record) String() string { return proto.CompactTextString(m) }
 func (*Record) ProtoMessage()    {}
 func (*Record) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registry_8f33b09e6c22f9ce, []int{0}
+	return fileDescriptor_registry_ca4dcab280c00e6f, []int{0}
 }
 func (m *Record) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Record.Unmarshal(m, b)
@@ -73,25 +73,116 @@ func (m *Record) GetOwner() *types.Address {
 	return nil
 }
 
+type ContractUpgrade struct {
+	PluginName           string   `protobuf:"bytes,1,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContractUpgrade) Reset()         { *m = ContractUpgrade{} }
+func (m *ContractUpgrade) String() string { return proto.CompactTextString(m) }
+func (*ContractUpgrade) ProtoMessage()    {}
+func (*ContractUpgrade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registry_ca4dcab280c00e6f, []int{1}
+}
+func (m *ContractUpgrade) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractUpgrade.Unmarshal(m, b)
+}
+func (m *ContractUpgrade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractUpgrade.Marshal(b, m, deterministic)
+}
+func (dst *ContractUpgrade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractUpgrade.Merge(dst, src)
+}
+func (m *ContractUpgrade) XXX_Size() int {
+	return xxx_messageInfo_ContractUpgrade.Size(m)
+}
+func (m *ContractUpgrade) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractUpgrade.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractUpgrade proto.InternalMessageInfo
+
+func (m *ContractUpgrade) GetPluginName() string {
+	if m != nil {
+		return m.PluginName
+	}
+	return ""
+}
+
+func (m *ContractUpgrade) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type ContractUpgradeHistory struct {
+	Upgrades             []*ContractUpgrade `protobuf:"bytes,1,rep,name=upgrades" json:"upgrades,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ContractUpgradeHistory) Reset()         { *m = ContractUpgradeHistory{} }
+func (m *ContractUpgradeHistory) String() string { return proto.CompactTextString(m) }
+func (*ContractUpgradeHistory) ProtoMessage()    {}
+func (*ContractUpgradeHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registry_ca4dcab280c00e6f, []int{2}
+}
+func (m *ContractUpgradeHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractUpgradeHistory.Unmarshal(m, b)
+}
+func (m *ContractUpgradeHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractUpgradeHistory.Marshal(b, m, deterministic)
+}
+func (dst *ContractUpgradeHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractUpgradeHistory.Merge(dst, src)
+}
+func (m *ContractUpgradeHistory) XXX_Size() int {
+	return xxx_messageInfo_ContractUpgradeHistory.Size(m)
+}
+func (m *ContractUpgradeHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractUpgradeHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractUpgradeHistory proto.InternalMessageInfo
+
+func (m *ContractUpgradeHistory) GetUpgrades() []*ContractUpgrade {
+	if m != nil {
+		return m.Upgrades
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Record)(nil), "Record")
+	proto.RegisterType((*ContractUpgrade)(nil), "ContractUpgrade")
+	proto.RegisterType((*ContractUpgradeHistory)(nil), "ContractUpgradeHistory")
 }
 
 func init() {
-	proto.RegisterFile("github.com/loomnetwork/loomchain/registry/registry.proto", fileDescriptor_registry_8f33b09e6c22f9ce)
-}
-
-var fileDescriptor_registry_8f33b09e6c22f9ce = []byte{
-	// 163 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xb2, 0x48, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0xc9, 0xcf, 0xcf, 0xcd, 0x4b, 0x2d, 0x29, 0xcf,
-	0x2f, 0xca, 0x06, 0xb3, 0x93, 0x33, 0x12, 0x33, 0xf3, 0xf4, 0x8b, 0x52, 0xd3, 0x33, 0x8b, 0x4b,
-	0x8a, 0x2a, 0xe1, 0x0c, 0xbd, 0x82, 0xa2, 0xfc, 0x92, 0x7c, 0x29, 0x03, 0x1c, 0x3a, 0xd3, 0xf3,
-	0x75, 0x41, 0x5c, 0xfd, 0x92, 0xca, 0x82, 0xd4, 0x62, 0x08, 0x09, 0xd1, 0xa1, 0x94, 0xc0, 0xc5,
-	0x16, 0x94, 0x9a, 0x9c, 0x5f, 0x94, 0x22, 0x24, 0xc4, 0xc5, 0x92, 0x97, 0x98, 0x9b, 0x2a, 0xc1,
-	0xa8, 0xc0, 0xa8, 0xc1, 0x19, 0x04, 0x66, 0x0b, 0x29, 0x71, 0xb1, 0x27, 0xa6, 0xa4, 0x14, 0xa5,
-	0x16, 0x17, 0x4b, 0x30, 0x29, 0x30, 0x6a, 0x70, 0x1b, 0x71, 0xe8, 0x39, 0x42, 0xf8, 0x41, 0x30,
-	0x09, 0x21, 0x39, 0x2e, 0xd6, 0xfc, 0xf2, 0xbc, 0xd4, 0x22, 0x09, 0x66, 0x34, 0x15, 0x10, 0xe1,
-	0x24, 0x36, 0xb0, 0x45, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x38, 0x51, 0x62, 0x0c, 0xd6,
-	0x00, 0x00, 0x00,
+	proto.RegisterFile("github.com/loomnetwork/loomchain/registry/registry.proto", fileDescriptor_registry_ca4dcab280c00e6f)
+}
+
+var fileDescriptor_registry_ca4dcab280c00e6f = []byte{
+	// 249 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0xc1, 0x4b, 0xc3, 0x30,
+	0x18, 0xc5, 0xa9, 0xd5, 0x39, 0xbf, 0x0a, 0x4a, 0x0e, 0x52, 0x3c, 0x68, 0xed, 0xa9, 0x07, 0x6d,
+	0x65, 0x5e, 0xbc, 0x8a, 0x20, 0x3b, 0x79, 0x28, 0xec, 0x3c, 0xdb, 0x34, 0xa4, 0x61, 0x6d, 0xbe,
+	0xf2, 0x25, 0x65, 0xf4, 0xbf, 0x97, 0x25, 0x38, 0xa4, 0xb0, 0x4b, 0x78, 0xef, 0x97, 0xf7, 0xf2,
+	0x20, 0xf0, 0x2e, 0x95, 0x6d, 0xc7, 0x3a, 0xe7, 0xd8, 0x17, 0x1d, 0x62, 0xaf, 0x85, 0xdd, 0x23,
+	0xed, 0x9c, 0xe6, 0x6d, 0xa5, 0x74, 0x41, 0x42, 0x2a, 0x63, 0x69, 0x3a, 0x8a, 0x7c, 0x20, 0xb4,
+	0x78, 0xff, 0x7a, 0xa2, 0x29, 0xf1, 0xe5, 0x60, 0x0b, 0x3b, 0x0d, 0xc2, 0xf8, 0xd3, 0x37, 0xd2,
+	0x1f, 0x58, 0x94, 0x82, 0x23, 0x35, 0x8c, 0xc1, 0xb9, 0xae, 0x7a, 0x11, 0x07, 0x49, 0x90, 0x5d,
+	0x95, 0x4e, 0xb3, 0x14, 0x2e, 0xab, 0xa6, 0x21, 0x61, 0x4c, 0x7c, 0x96, 0x04, 0x59, 0xb4, 0x5a,
+	0xe6, 0x1f, 0xde, 0x97, 0x7f, 0x17, 0xec, 0x01, 0x2e, 0x70, 0xaf, 0x05, 0xc5, 0xe1, 0x2c, 0xe1,
+	0x71, 0xba, 0x81, 0x9b, 0x4f, 0xd4, 0x96, 0x2a, 0x6e, 0x37, 0x83, 0xa4, 0xaa, 0x11, 0xec, 0x11,
+	0xa2, 0xa1, 0x1b, 0xa5, 0xd2, 0xdb, 0x7f, 0x8b, 0xe0, 0xd1, 0xf7, 0x61, 0xf7, 0x09, 0xae, 0xeb,
+	0x0e, 0xf9, 0x6e, 0xdb, 0x0a, 0x25, 0x5b, 0xeb, 0xc6, 0xc3, 0x32, 0x72, 0x6c, 0xed, 0x50, 0xfa,
+	0x05, 0x77, 0xb3, 0x67, 0xd7, 0xca, 0x58, 0xa4, 0x89, 0x3d, 0xc3, 0x72, 0xf4, 0xc4, 0xc4, 0x41,
+	0x12, 0x66, 0xd1, 0xea, 0x36, 0x9f, 0x45, 0xcb, 0x63, 0xa2, 0x5e, 0xb8, 0x7f, 0x78, 0xfb, 0x1d,
+	0x00, 0xdd, 0x43, 0x2d, 0x79, 0x75, 0x01, 0x00, 0x00,
 }
@@ -80,6 +80,14 @@ func (r *StateRegistry) GetRecord(contractAddr loom.Address) (*common.Record, er
 	return nil, common.ErrNotImplemented
 }
 
+func (r *StateRegistry) ScheduleUpgrade(contractAddr loom.Address, upgrade *common.ContractUpgrade) error {
+	return common.ErrNotImplemented
+}
+
+func (r *StateRegistry) GetUpgrades(contractAddr loom.Address) ([]*common.ContractUpgrade, error) {
+	return nil, common.ErrNotImplemented
+}
+
 func validateName(name string) error {
 	if len(name) < minNameLen {
 		return errors.New("name length too short")
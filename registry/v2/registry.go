@@ -3,6 +3,7 @@ package registry
 import (
 	"errors"
 	"regexp"
+	"strings"
 
 	proto "github.com/gogo/protobuf/proto"
 	loom "github.com/loomnetwork/go-loom"
@@ -21,8 +22,9 @@ var (
 	validNameRE = regexp.MustCompile("^[a-zA-Z0-9\\.\\-]+$")
 
 	// Store Keys
-	contractAddrKeyPrefix   = []byte("reg_caddr")
-	contractRecordKeyPrefix = []byte("reg_crec")
+	contractAddrKeyPrefix    = []byte("reg_caddr")
+	contractRecordKeyPrefix  = []byte("reg_crec")
+	contractUpgradeKeyPrefix = []byte("reg_cupg")
 )
 
 func contractAddrKey(contractName string) []byte {
@@ -33,6 +35,10 @@ func contractRecordKey(contractAddr loom.Address) []byte {
 	return util.PrefixKey(contractRecordKeyPrefix, contractAddr.Bytes())
 }
 
+func contractUpgradeKey(contractAddr loom.Address) []byte {
+	return util.PrefixKey(contractUpgradeKeyPrefix, contractAddr.Bytes())
+}
+
 // StateRegistry stores contract meta data for named & unnamed contracts, and allows lookup by
 // contract name or contract address.
 type StateRegistry struct {
@@ -104,6 +110,59 @@ func (r *StateRegistry) GetRecord(contractAddr loom.Address) (*common.Record, er
 	return &record, nil
 }
 
+// ScheduleUpgrade appends the given upgrade to the upgrade history of a contract. Upgrades can't be
+// scheduled for past blocks, and must be scheduled in the order they take effect, so the history
+// of a contract can only be extended, never rewritten.
+func (r *StateRegistry) ScheduleUpgrade(contractAddr loom.Address, upgrade *common.ContractUpgrade) error {
+	if _, err := r.GetRecord(contractAddr); err != nil {
+		return err
+	}
+	if err := validatePluginName(upgrade.PluginName); err != nil {
+		return err
+	}
+	if upgrade.BlockHeight < r.State.Block().Height {
+		return errors.New("upgrade can't be scheduled for a past block")
+	}
+
+	upgrades, err := r.GetUpgrades(contractAddr)
+	if err != nil {
+		return err
+	}
+	if len(upgrades) > 0 && upgrade.BlockHeight <= upgrades[len(upgrades)-1].BlockHeight {
+		return errors.New("upgrade must take effect after all previously scheduled upgrades")
+	}
+
+	histBytes, err := proto.Marshal(&common.ContractUpgradeHistory{
+		Upgrades: append(upgrades, upgrade),
+	})
+	if err != nil {
+		return err
+	}
+	r.State.Set(contractUpgradeKey(contractAddr), histBytes)
+	return nil
+}
+
+func (r *StateRegistry) GetUpgrades(contractAddr loom.Address) ([]*common.ContractUpgrade, error) {
+	data := r.State.Get(contractUpgradeKey(contractAddr))
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var history common.ContractUpgradeHistory
+	if err := proto.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+	return history.Upgrades, nil
+}
+
+// validatePluginName checks the given name is in the name:version format expected by the loaders.
+func validatePluginName(pluginName string) error {
+	parts := strings.SplitN(pluginName, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.New("invalid plugin name format")
+	}
+	return validateName(parts[0])
+}
+
 func validateName(name string) error {
 	if len(name) < minNameLen {
 		return errors.New("name length too short")
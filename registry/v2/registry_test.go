@@ -1,9 +1,17 @@
 package registry
 
 import (
+	"context"
 	"testing"
 
+	loom "github.com/loomnetwork/go-loom"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/loomnetwork/loomchain"
+	common "github.com/loomnetwork/loomchain/registry"
+	"github.com/loomnetwork/loomchain/store"
 )
 
 func TestValidateName(t *testing.T) {
@@ -12,3 +20,35 @@ func TestValidateName(t *testing.T) {
 
 	assert.NotNil(t, validateName("foo@bar"))
 }
+
+func TestScheduleUpgrade(t *testing.T) {
+	state := loomchain.NewStoreState(
+		context.Background(), store.NewMemStore(), abci.Header{ChainID: "default", Height: 10}, nil, nil,
+	)
+	reg := &StateRegistry{State: state}
+	contractAddr := loom.MustParseAddress("default:0xb16a379ec18d4093666f8f38b11a3071c920207d")
+	ownerAddr := loom.MustParseAddress("default:0xfa4c7920accfd66b86f5fd0e69682a79f762d49e")
+
+	upgrade := &common.ContractUpgrade{PluginName: "coin:2.0.0", BlockHeight: 20}
+	require.Equal(t, common.ErrNotFound, reg.ScheduleUpgrade(contractAddr, upgrade))
+	require.NoError(t, reg.Register("coin", contractAddr, ownerAddr))
+
+	upgrades, err := reg.GetUpgrades(contractAddr)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 0)
+
+	require.Error(t, reg.ScheduleUpgrade(contractAddr, &common.ContractUpgrade{PluginName: "coin", BlockHeight: 20}))
+	require.Error(t, reg.ScheduleUpgrade(contractAddr, &common.ContractUpgrade{PluginName: "coin:2.0.0", BlockHeight: 9}))
+	require.NoError(t, reg.ScheduleUpgrade(contractAddr, upgrade))
+	// upgrades must be scheduled in the order they take effect
+	require.Error(t, reg.ScheduleUpgrade(contractAddr, &common.ContractUpgrade{PluginName: "coin:3.0.0", BlockHeight: 20}))
+	require.NoError(t, reg.ScheduleUpgrade(contractAddr, &common.ContractUpgrade{PluginName: "coin:3.0.0", BlockHeight: 30}))
+
+	upgrades, err = reg.GetUpgrades(contractAddr)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 2)
+	require.Equal(t, "coin:1.0.0", common.PluginNameAt(upgrades, "coin:1.0.0", 19))
+	require.Equal(t, "coin:2.0.0", common.PluginNameAt(upgrades, "coin:1.0.0", 20))
+	require.Equal(t, "coin:2.0.0", common.PluginNameAt(upgrades, "coin:1.0.0", 29))
+	require.Equal(t, "coin:3.0.0", common.PluginNameAt(upgrades, "coin:1.0.0", 30))
+}
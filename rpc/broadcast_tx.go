@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/loomnetwork/loomchain"
+)
+
+const (
+	// How long broadcast_tx_commit waits for a queued tx to be included in a block, matches the
+	// default Tendermint timeout for broadcast_tx_commit.
+	queuedTxCommitTimeout = 10 * time.Second
+	// How often the tx index is checked while waiting for a queued tx to be included in a block.
+	queuedTxPollInterval = 500 * time.Millisecond
+)
+
+// The broadcast functions below submit txs to the local mempool. Txs with future nonces that were
+// queued by the node instead of being added to the mempool are reported as accepted, since they'll
+// be added to the mempool once the txs preceding them are received.
+
+func broadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	r, err := rpccore.BroadcastTxAsync(tx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Code == loomchain.CodeTypeTxQueued {
+		r.Code = abci.CodeTypeOK
+	}
+	return r, nil
+}
+
+func broadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	r, err := rpccore.BroadcastTxSync(tx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Code == loomchain.CodeTypeTxQueued {
+		r.Code = abci.CodeTypeOK
+	}
+	return r, nil
+}
+
+// broadcastTxCommit waits for the tx to be included in a block, if the tx was queued it waits
+// until the queued tx is released and committed.
+func broadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	r, err := rpccore.BroadcastTxCommit(tx)
+	if err != nil {
+		return nil, err
+	}
+	if r.CheckTx.Code != loomchain.CodeTypeTxQueued {
+		return r, nil
+	}
+	r.CheckTx.Code = abci.CodeTypeOK
+	res, err := waitForTx(tx.Hash(), queuedTxCommitTimeout)
+	if err != nil {
+		return nil, err
+	}
+	r.DeliverTx = res.TxResult
+	r.Height = res.Height
+	return r, nil
+}
+
+// waitForTx polls the tx index until a tx with the given hash has been indexed, or the timeout
+// expires.
+func waitForTx(hash []byte, timeout time.Duration) (*ctypes.ResultTx, error) {
+	ticker := time.NewTicker(queuedTxPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+	for {
+		select {
+		case <-ticker.C:
+			if res, err := rpccore.Tx(hash, false); err == nil {
+				return res, nil
+			}
+		case <-deadline:
+			return nil, errors.New("timed out waiting for queued tx to be included in a block")
+		}
+	}
+}
@@ -6,8 +6,9 @@ package rpc
 
 import (
 	"encoding/json"
-	"io"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -17,15 +18,6 @@ import (
 )
 
 const (
-	// Time allowed to write a message to the peer.
-	writeWait = 10000 * time.Second
-
-	// Time allowed to read the next pong message from the peer.
-	pongWait = 60 * time.Second
-
-	// Send pings to peer with this period. Must be less than pongWait.
-	pingPeriod = (pongWait * 9) / 10
-
 	// Maximum message size allowed from peer.
 	maxMessageSize = 16384
 )
@@ -44,11 +36,17 @@ var (
 type Client struct {
 	hub *Hub
 
-	// The websocket connection.
-	conn *websocket.Conn
+	// The websocket connection, outbound messages are queued on the connection and written by its
+	// own writer goroutine.
+	conn *eth.WSConn
 
-	// Buffered channel of outbound messages.
-	send chan []byte
+	// ID assigned to the request that opened the connection, the messages received on the
+	// connection are assigned IDs derived from it.
+	id string
+
+	// Rate limiter of the client that opened the connection, nil if the connection isn't subject
+	// to any rate limits.
+	limiter *clientLimiter
 }
 
 // readPump pumps messages from the websocket connection.
@@ -57,20 +55,24 @@ type Client struct {
 // ensures that there is at most one reader on a connection by executing all
 // reads from this goroutine.
 func (c *Client) readPump(funcMap map[string]eth.RPCFunc, logger log.TMLogger) {
+	if c.id == "" {
+		c.id = newRequestID()
+	}
+	connLogger := logger.With("request_id", c.id)
 	defer func() {
 		if r := recover(); r != nil {
-			logger.Error("WebSocket read panicked", "err", r)
+			connLogger.Error("WebSocket read panicked", "err", r)
 		}
 		c.hub.unregister <- c
-		if err := c.conn.Close(); err != nil {
-			logger.Error("Failed to close WebSocket (read pump)", "err", err)
-		}
+		c.conn.Close()
 	}()
-	c.conn.SetReadLimit(maxMessageSize)
-	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
-	c.conn.SetPongHandler(func(string) error { _ = c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
-	for {
-		_, message, err := c.conn.ReadMessage()
+	conn := c.conn.Conn()
+	pongWait := time.Duration(c.hub.wsCfg.PongTimeout) * time.Second
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error { _ = conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+	for msgCount := 1; ; msgCount++ {
+		_, message, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(
 				err,
@@ -78,17 +80,25 @@ func (c *Client) readPump(funcMap map[string]eth.RPCFunc, logger log.TMLogger) {
 				websocket.CloseAbnormalClosure,
 				websocket.CloseNoStatusReceived,
 			) {
-				logger.Error("Failed to read from closed WebSocket", "err", err)
+				connLogger.Error("Failed to read from closed WebSocket", "err", err)
 			} else {
-				logger.Debug("Failed to read WebSocket", "err", err)
+				connLogger.Debug("Failed to read WebSocket", "err", err)
 			}
 			return
 		}
 
-		outBytes, ethError := handleMessage(message, funcMap, c.conn)
+		// Each message is a separate request, so it's tagged with its own ID.
+		msgLogger := logger.With("request_id", fmt.Sprintf("%s.%d", c.id, msgCount))
+		start := time.Now()
+		outBytes, ethError := handleMessage(message, funcMap, c.conn, c.limiter, msgLogger)
+		_, methods := parseMessageMethods(message)
+		msgLogger.Debug("RPC websocket request handled",
+			"method", strings.Join(methods, ","),
+			"took", time.Since(start),
+		)
 
 		if ethError != nil {
-			logger.Error("Failed to handle WebSocket message (read pump)", "err", ethError.Error())
+			msgLogger.Error("Failed to handle WebSocket message (read pump)", "err", ethError.Error())
 			resp := eth.JsonRpcErrorResponse{
 				Version: "2.0",
 				Error:   *ethError,
@@ -99,67 +109,9 @@ func (c *Client) readPump(funcMap map[string]eth.RPCFunc, logger log.TMLogger) {
 			}
 		}
 
-		c.send <- outBytes
-	}
-}
-
-// writePump pumps messages to the websocket connection.
-//
-// A goroutine running writePump is started for each connection. The
-// application ensures that there is at most one writer to a connection by
-// executing all writes from this goroutine.
-func (c *Client) writePump(logger log.TMLogger) {
-	ticker := time.NewTicker(pingPeriod)
-	defer func() {
-		if r := recover(); r != nil {
-			logger.Error("WebSocket write panicked", "err", r)
-		}
-		ticker.Stop()
-		if err := c.conn.Close(); err != nil {
-			logger.Error("Failed to close WebSocket (write pump)", "err", err)
-		}
-
-	}()
-	for {
-		select {
-		case message, ok := <-c.send:
-			if !ok {
-				if err := c.conn.WriteMessage(websocket.CloseMessage, []byte{}); err != nil {
-					if err != websocket.ErrCloseSent {
-						logger.Error("Failed to write close message to WebSocket", "err", err)
-					}
-				}
-				return
-			}
-			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
-				logger.Error("Failed to set write deadline on WebSocket", "err", err)
-			}
-
-			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
-				if err != io.ErrClosedPipe {
-					logger.Error("Failed to write message to WebSocket", "err", err)
-				} else {
-					logger.Debug("Failed to write message to closed WebSocket", "err", err)
-				}
-				return
-			}
-
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				msg := <-c.send
-				if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
-					if err != io.ErrClosedPipe {
-						logger.Error("Failed to write message to WebSocket", "err", err)
-					} else {
-						logger.Debug("Failed to write message to closed WebSocket", "err", err)
-					}
-					return
-				}
-			}
-		case <-ticker.C:
-			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				logger.Error("Failed to write ping message to WebSocket", "err", err)
+		if err := c.conn.Send(outBytes); err != nil {
+			msgLogger.Debug("Failed to queue WebSocket response", "err", err)
+			if err == eth.ErrWSConnClosed {
 				return
 			}
 		}
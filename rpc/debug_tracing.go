@@ -0,0 +1,300 @@
+package rpc
+
+import (
+	"context"
+
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/auth"
+	ltypes "github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/vm"
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	ttypes "github.com/tendermint/tendermint/types"
+
+	"github.com/loomnetwork/loomchain"
+	levm "github.com/loomnetwork/loomchain/evm"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+)
+
+// TxTraceResult is the trace of a single tx in a block.
+type TxTraceResult struct {
+	TxHash eth.Data    `json:"txHash"`
+	Result interface{} `json:"result"`
+}
+
+// DebugTraceTransaction replays the block containing the given tx on top of the state the block was
+// originally executed against, and returns the trace of the tx.
+// Only EVM txs can be traced, and only the EVM txs in the block that precede the traced tx are
+// replayed, so the trace may diverge from the original execution if the tx depends on the changes
+// made by any non-EVM txs in the same block.
+func (s *QueryServer) DebugTraceTransaction(hash eth.Data, cfg levm.TraceConfig) (interface{}, error) {
+	txHash, err := eth.DecDataToBytes(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var height int64
+	var txIndex int
+	if txReceipt, err := s.ReceiptHandlerProvider.Reader().GetReceipt(txHash); err == nil {
+		height = int64(txReceipt.BlockNumber)
+		txIndex = int(txReceipt.TransactionIndex)
+	} else {
+		txResult, err := s.BlockStore.GetTxResult(txHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find tx with hash %v", hash)
+		}
+		height = txResult.Height
+		txIndex = int(txResult.Index)
+	}
+
+	blockResult, err := s.BlockStore.GetBlockByHeight(&height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load block %d", height)
+	}
+	if len(blockResult.Block.Data.Txs) <= txIndex {
+		return nil, errors.Errorf(
+			"Transaction index %v out of bounds for transactions in block %v",
+			txIndex, len(blockResult.Block.Data.Txs),
+		)
+	}
+
+	txs, indices, err := s.getBlockTraceTxs(blockResult, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 || indices[len(indices)-1] != txIndex {
+		return nil, errors.Errorf("tx %v is not an EVM tx", hash)
+	}
+
+	results, err := s.traceBlockTxs(blockResult, txs, len(txs)-1, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// DebugTraceBlockByNumber replays all the EVM txs in the given block on top of the state the block
+// was originally executed against, and returns the trace of each tx.
+func (s *QueryServer) DebugTraceBlockByNumber(
+	block eth.BlockHeight, cfg levm.TraceConfig,
+) ([]*TxTraceResult, error) {
+	snapshot := s.StateProvider.ReadOnlyState()
+	height, err := eth.DecBlockHeight(snapshot.Block().Height, block)
+	snapshot.Release()
+	if err != nil {
+		return nil, err
+	}
+
+	h := int64(height)
+	blockResult, err := s.BlockStore.GetBlockByHeight(&h)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load block %d", h)
+	}
+
+	txs, indices, err := s.getBlockTraceTxs(blockResult, len(blockResult.Block.Data.Txs)-1)
+	if err != nil {
+		return nil, err
+	}
+	if len(txs) == 0 {
+		return []*TxTraceResult{}, nil
+	}
+
+	results, err := s.traceBlockTxs(blockResult, txs, 0, cfg)
+	if err != nil {
+		return nil, err
+	}
+	traces := make([]*TxTraceResult, len(results))
+	for i, result := range results {
+		traces[i] = &TxTraceResult{
+			TxHash: eth.EncBytes(blockResult.Block.Data.Txs[indices[i]].Hash()),
+			Result: result,
+		}
+	}
+	return traces, nil
+}
+
+// DebugTraceCall executes the given call on top of the state at the given height, and returns the
+// trace of the call. None of the changes made by the call are persisted.
+func (s *QueryServer) DebugTraceCall(
+	query eth.JsonTxCallObject, block eth.BlockHeight, cfg levm.TraceConfig,
+) (interface{}, error) {
+	state, err := s.readOnlyStateAtBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	defer state.Release()
+
+	var tx levm.TraceTx
+	if len(query.From) > 0 {
+		tx.Caller, err = s.getEthAccount(state, query.From)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx.Caller = loom.RootAddress(s.ChainID)
+	}
+	if len(query.To) > 0 {
+		contract, err := eth.DecDataToAddress(s.ChainID, query.To)
+		if err != nil {
+			return nil, err
+		}
+		tx.Contract = &contract
+	}
+	if len(query.Data) > 2 {
+		tx.Input, err = eth.DecDataToBytes(query.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(query.Value) > 0 {
+		v, err := eth.DecQuantityToBigInt(query.Value)
+		if err != nil {
+			return nil, err
+		}
+		tx.Value = loom.NewBigUInt(v)
+	}
+
+	results, err := s.traceTxs(state, []levm.TraceTx{tx}, 0, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// traceBlockTxs executes the given txs on top of the state the given block was executed against.
+func (s *QueryServer) traceBlockTxs(
+	blockResult *ctypes.ResultBlock, txs []levm.TraceTx, traceFrom int, cfg levm.TraceConfig,
+) ([]interface{}, error) {
+	height := blockResult.Block.Header.Height
+	if height <= 1 {
+		return nil, errors.New("txs in the first block can't be traced")
+	}
+	state, err := s.readOnlyStateAt(height-1, blockResult)
+	if err != nil {
+		return nil, err
+	}
+	defer state.Release()
+	return s.traceTxs(state, txs, traceFrom, cfg)
+}
+
+func (s *QueryServer) traceTxs(
+	snapshot loomchain.State, txs []levm.TraceTx, traceFrom int, cfg levm.TraceConfig,
+) ([]interface{}, error) {
+	state := loomchain.NewThrowawayState(context.Background(), snapshot)
+	createABM, err := s.createABMFactory(state)
+	if err != nil {
+		return nil, err
+	}
+	return levm.TraceTxs(state, createABM, txs, traceFrom, cfg)
+}
+
+// getBlockTraceTxs decodes the EVM txs in the given block, up to and including the tx at the given
+// index. Returns the decoded txs, and the indices of the txs within the block.
+func (s *QueryServer) getBlockTraceTxs(
+	blockResult *ctypes.ResultBlock, lastIndex int,
+) ([]levm.TraceTx, []int, error) {
+	height := blockResult.Block.Header.Height
+	// Txs that failed are replayed so they can be traced, but since they didn't modify the state
+	// when the block was executed any changes they make during the replay are discarded. If the
+	// results are unavailable the failed EVM calls will fail again when replayed anyway.
+	var deliverTxs []*abci.ResponseDeliverTx
+	if blockResults, err := s.BlockStore.GetBlockResults(&height); err == nil && blockResults != nil {
+		deliverTxs = blockResults.Results.DeliverTx
+	}
+
+	var txs []levm.TraceTx
+	var indices []int
+	for i, tx := range blockResult.Block.Data.Txs {
+		if i > lastIndex {
+			break
+		}
+		traceTx, err := decodeTraceTx(tx)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to decode tx %d in block %d", i, height)
+		}
+		if traceTx != nil {
+			traceTx.Failed = i < len(deliverTxs) && deliverTxs[i] != nil &&
+				deliverTxs[i].Code != abci.CodeTypeOK
+			txs = append(txs, *traceTx)
+			indices = append(indices, i)
+		}
+	}
+	return txs, indices, nil
+}
+
+// decodeTraceTx extracts the EVM call or deployment from the given tx, returns nil if the tx
+// doesn't execute any EVM code.
+func decodeTraceTx(tx ttypes.Tx) (*levm.TraceTx, error) {
+	var signedTx auth.SignedTx
+	if err := proto.Unmarshal(tx, &signedTx); err != nil {
+		return nil, err
+	}
+	var nonceTx auth.NonceTx
+	if err := proto.Unmarshal(signedTx.Inner, &nonceTx); err != nil {
+		return nil, err
+	}
+	var txTx ltypes.Transaction
+	if err := proto.Unmarshal(nonceTx.Inner, &txTx); err != nil {
+		return nil, err
+	}
+
+	var msg vm.MessageTx
+	switch ltypes.TxID(txTx.Id) {
+	case ltypes.TxID_DEPLOY, ltypes.TxID_CALL, ltypes.TxID_ETHEREUM:
+		if err := proto.Unmarshal(txTx.Data, &msg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	traceTx := &levm.TraceTx{
+		Caller: loom.UnmarshalAddressPB(msg.From),
+	}
+	switch ltypes.TxID(txTx.Id) {
+	case ltypes.TxID_DEPLOY:
+		var deployTx vm.DeployTx
+		if err := proto.Unmarshal(msg.Data, &deployTx); err != nil {
+			return nil, err
+		}
+		if deployTx.VmType != vm.VMType_EVM {
+			return nil, nil
+		}
+		traceTx.Input = deployTx.Code
+		if deployTx.Value != nil {
+			traceTx.Value = &deployTx.Value.Value
+		}
+
+	case ltypes.TxID_CALL:
+		var callTx vm.CallTx
+		if err := proto.Unmarshal(msg.Data, &callTx); err != nil {
+			return nil, err
+		}
+		if callTx.VmType != vm.VMType_EVM {
+			return nil, nil
+		}
+		contract := loom.UnmarshalAddressPB(msg.To)
+		traceTx.Contract = &contract
+		traceTx.Input = callTx.Input
+		if callTx.Value != nil {
+			traceTx.Value = &callTx.Value.Value
+		}
+
+	case ltypes.TxID_ETHEREUM:
+		var ethTx etypes.Transaction
+		if err := rlp.DecodeBytes(msg.Data, &ethTx); err != nil {
+			return nil, err
+		}
+		if ethTx.To() != nil {
+			contract := loom.UnmarshalAddressPB(msg.To)
+			traceTx.Contract = &contract
+		}
+		traceTx.Input = ethTx.Data()
+		traceTx.Value = loom.NewBigUInt(ethTx.Value())
+	}
+	return traceTx, nil
+}
@@ -3,12 +3,78 @@ package eth
 // Web3Config contains settings that control the operation of the Web3 JSON-RPC method exposed
 // via the /eth endpoint.
 type Web3Config struct {
-	// GetLogsMaxBlockRange specifies the maximum number of blocks eth_getLogs will query per request
+	// GetLogsMaxBlockRange specifies the maximum number of blocks eth_getLogs, getevmlogs, and
+	// contractevents will query per request
 	GetLogsMaxBlockRange uint64
+	// GasPrice controls the gas price reported by eth_gasPrice
+	GasPrice *GasPriceConfig
+	// WebSocket controls how messages (including eth_subscribe notifications) are written to
+	// websocket clients
+	WebSocket *WebSocketConfig
+	// FilterStore controls where the state of eth_newFilter, eth_newBlockFilter, and
+	// eth_newPendingTransactionFilter filters is kept
+	FilterStore *FilterStoreConfig
+}
+
+// GasPriceConfig contains settings that control how the gas price returned by eth_gasPrice is
+// determined. All prices are specified in wei.
+type GasPriceConfig struct {
+	// Oracle specifies how the gas price is determined, valid values are:
+	// "fixed" - always return FixedPrice.
+	// "median" - return the median gas price of txs in the last NumBlocks blocks, if no such txs
+	//            are found FixedPrice is returned instead.
+	Oracle string
+	// FixedPrice is the price returned by the fixed oracle, and the fallback for the median oracle.
+	FixedPrice uint64
+	// MinPrice is the lowest gas price that will ever be returned by eth_gasPrice. The minimum gas
+	// price set in the ChainConfig contract takes precedence if it's higher.
+	MinPrice uint64
+	// NumBlocks specifies how many of the most recent blocks the median oracle should sample.
+	NumBlocks uint64
+}
+
+func DefaultGasPriceConfig() *GasPriceConfig {
+	return &GasPriceConfig{
+		Oracle:     "fixed",
+		FixedPrice: 0,
+		MinPrice:   0,
+		NumBlocks:  20,
+	}
 }
 
 func DefaultWeb3Config() *Web3Config {
 	return &Web3Config{
 		GetLogsMaxBlockRange: 20,
+		GasPrice:             DefaultGasPriceConfig(),
+		WebSocket:            DefaultWebSocketConfig(),
+		FilterStore:          DefaultFilterStoreConfig(),
+	}
+}
+
+// FilterStoreConfig contains settings for the store that keeps track of the filters created via
+// the Web3 JSON-RPC methods.
+type FilterStoreConfig struct {
+	// If true filters are persisted to a local DB, so they survive node restarts, otherwise filters
+	// are only kept in memory. The DB can't be shared with other nodes.
+	Persistent      bool
+	DBBackend       string
+	DBName          string
+	CacheSizeMegs   int
+	WriteBufferMegs int
+	// If set filters are stored in the Redis server at this URI instead, so they can be shared by
+	// all the nodes that connect to the same Redis server. Takes precedence over Persistent.
+	RedisURI string
+	// Prefix for all the keys stored in Redis, allows multiple chains to share a Redis server.
+	RedisKeyPrefix string
+}
+
+func DefaultFilterStoreConfig() *FilterStoreConfig {
+	return &FilterStoreConfig{
+		Persistent:      false,
+		DBBackend:       "goleveldb",
+		DBName:          "eth_filters",
+		CacheSizeMegs:   16,
+		WriteBufferMegs: 4,
+		RedisKeyPrefix:  "eth_filters:",
 	}
 }
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-
-	"github.com/gorilla/websocket"
 )
 
 type HttpRPCFunc struct {
@@ -70,7 +68,7 @@ func (m *HttpRPCFunc) GetResponse(result json.RawMessage, ID *json.RawMessage) (
 	}, nil
 }
 
-func (m *HttpRPCFunc) UnmarshalParamsAndCall(input JsonRpcRequest, _ *websocket.Conn) (resp json.RawMessage, jsonErr *Error) {
+func (m *HttpRPCFunc) UnmarshalParamsAndCall(input JsonRpcRequest, _ *WSConn) (resp json.RawMessage, jsonErr *Error) {
 	inValues, jsonErr := m.getInputValues(input)
 	if jsonErr != nil {
 		return resp, jsonErr
@@ -82,6 +80,10 @@ func (m *HttpRPCFunc) call(inValues []reflect.Value) (resp json.RawMessage, json
 	outValues := m.method.Call(inValues)
 
 	if outValues[1].Interface() != nil {
+		// Methods can return a JSON-RPC error directly if they need to control the error code & data
+		if jsonErr, ok := outValues[1].Interface().(*Error); ok {
+			return resp, jsonErr
+		}
 		return resp, NewError(EcServer, fmt.Sprintf("loom error: %v", outValues[1].Interface()), "")
 	}
 
@@ -31,6 +31,9 @@ const (
 	ZeroedData256Bytes Data     = "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
 
 	StatusTxSuccess = "0x1"
+
+	// Version of the eth protocol (eth/63) returned by eth_protocolVersion.
+	ProtocolVersion = "0x3f"
 )
 
 type JsonLog struct {
@@ -107,6 +110,13 @@ type JsonTxCallObject struct {
 	Nonce    Quantity `json:"nonce,omitempty"`
 }
 
+// JsonSyncStatus is returned by eth_syncing while the node is catching up.
+type JsonSyncStatus struct {
+	StartingBlock Quantity `json:"startingBlock"`
+	CurrentBlock  Quantity `json:"currentBlock"`
+	HighestBlock  Quantity `json:"highestBlock"`
+}
+
 type JsonFilter struct {
 	FromBlock BlockHeight   `json:"fromBlock,omitempty"`
 	ToBlock   BlockHeight   `json:"toBlock,omitempty"`
@@ -400,6 +410,17 @@ func DecQuantityToUint(value Quantity) (uint64, error) {
 	return strconv.ParseUint(string(value), 0, 64)
 }
 
+func DecQuantityToBigInt(value Quantity) (*big.Int, error) {
+	if len(value) <= 2 || value[0:2] != "0x" {
+		return nil, errors.Errorf("invalid quantity format: %v", value)
+	}
+	v, ok := new(big.Int).SetString(string(value[2:]), 16)
+	if !ok {
+		return nil, errors.Errorf("invalid quantity format: %v", value)
+	}
+	return v, nil
+}
+
 func DecDataToBytes(value Data) ([]byte, error) {
 	if len(value) <= 2 || value[0:2] != "0x" {
 		return []byte{}, errors.Errorf("invalid data format: %v", value)
@@ -3,12 +3,10 @@ package eth
 import (
 	"encoding/json"
 	"fmt"
-
-	"github.com/gorilla/websocket"
 )
 
 type RPCFunc interface {
-	UnmarshalParamsAndCall(JsonRpcRequest, *websocket.Conn) (json.RawMessage, *Error)
+	UnmarshalParamsAndCall(JsonRpcRequest, *WSConn) (json.RawMessage, *Error)
 	GetResponse(result json.RawMessage, ID *json.RawMessage) (*JsonRpcResponse, *Error)
 }
 
@@ -45,6 +43,10 @@ const (
 	EcInvalidParams  ErrorCode = -32602 // Invalid method parameter(s).
 	EcInternal       ErrorCode = -32603 // Internal JSON-RPC error.
 	EcServer         ErrorCode = -32000 // Reserved for implementation-defined server-errors.
+	// Server error code defined by EIP-1474 to indicate a request exceeded a limit.
+	EcLimitExceeded ErrorCode = -32005
+	// Non-standard error code used by go-ethereum to indicate a call was reverted by the EVM.
+	EcExecutionReverted ErrorCode = 3
 )
 
 type Error struct {
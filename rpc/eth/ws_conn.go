@@ -0,0 +1,215 @@
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/log"
+)
+
+const (
+	// WSDropPolicy discards messages that don't fit into the send queue of a connection.
+	WSDropPolicy = "drop"
+	// WSDisconnectPolicy closes connections whose send queue overflows.
+	WSDisconnectPolicy = "disconnect"
+)
+
+var (
+	// ErrWSSendQueueFull is returned when a message is sent to a connection that has too many
+	// messages queued already.
+	ErrWSSendQueueFull = errors.New("websocket send queue is full")
+	// ErrWSConnClosed is returned when a message is sent to a connection that has been closed.
+	ErrWSConnClosed = errors.New("websocket connection is closed")
+
+	logger = log.Module("rpc")
+)
+
+// WebSocketConfig contains settings that control how messages are written to websocket clients
+// connected to the /eth endpoint.
+type WebSocketConfig struct {
+	// Maximum number of outbound messages that can be queued for a single connection.
+	SendQueueSize int
+	// Maximum time (in seconds) allowed to write a single message to a connection.
+	WriteTimeout int64
+	// How often (in seconds) connections should be pinged, must be less than PongTimeout.
+	PingInterval int64
+	// Connections that don't respond to a ping within this many seconds are closed.
+	PongTimeout int64
+	// Specifies what happens when the send queue of a connection overflows, valid values are:
+	// "drop" - the message is discarded.
+	// "disconnect" - the connection is closed.
+	OverflowPolicy string
+}
+
+func DefaultWebSocketConfig() *WebSocketConfig {
+	return &WebSocketConfig{
+		SendQueueSize:  256,
+		WriteTimeout:   10,
+		PingInterval:   54,
+		PongTimeout:    60,
+		OverflowPolicy: WSDisconnectPolicy,
+	}
+}
+
+// WithDefaults returns a copy of the config with any invalid settings replaced by the default
+// ones, a zero PingInterval or SendQueueSize would otherwise stall or disconnect every client.
+func (c *WebSocketConfig) WithDefaults() *WebSocketConfig {
+	defaults := DefaultWebSocketConfig()
+	if c == nil {
+		return defaults
+	}
+	cfg := *c
+	if cfg.SendQueueSize <= 0 {
+		cfg.SendQueueSize = defaults.SendQueueSize
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = defaults.WriteTimeout
+	}
+	if cfg.PingInterval <= 0 || cfg.PongTimeout <= 0 || cfg.PingInterval >= cfg.PongTimeout {
+		cfg.PingInterval = defaults.PingInterval
+		cfg.PongTimeout = defaults.PongTimeout
+	}
+	if cfg.OverflowPolicy != WSDropPolicy && cfg.OverflowPolicy != WSDisconnectPolicy {
+		cfg.OverflowPolicy = defaults.OverflowPolicy
+	}
+	return &cfg
+}
+
+// WSConn wraps a websocket connection with a bounded send queue that's drained by a dedicated
+// writer goroutine, the writer also keeps the connection alive with periodic pings. Messages can
+// be sent to the connection from any goroutine without blocking, so a slow or dead client can't
+// stall the sender.
+type WSConn struct {
+	conn      *websocket.Conn
+	cfg       *WebSocketConfig
+	sendCh    chan []byte
+	quitCh    chan struct{}
+	closeOnce sync.Once
+	mutex     sync.Mutex
+	onClose   []func()
+}
+
+// NewWSConn wraps the given connection and starts the writer goroutine. The caller is expected
+// to read from the connection, and to call Close when it's done with the connection.
+func NewWSConn(conn *websocket.Conn, cfg *WebSocketConfig) *WSConn {
+	c := &WSConn{
+		conn:   conn,
+		cfg:    cfg,
+		sendCh: make(chan []byte, cfg.SendQueueSize),
+		quitCh: make(chan struct{}),
+	}
+	go c.writePump()
+	return c
+}
+
+// Conn returns the underlying websocket connection, which should only be used for reading.
+func (c *WSConn) Conn() *websocket.Conn {
+	return c.conn
+}
+
+// Send queues a message to be written to the connection. If the send queue is full the message
+// is handled according to the configured overflow policy, and ErrWSSendQueueFull is returned.
+func (c *WSConn) Send(msg []byte) error {
+	select {
+	case <-c.quitCh:
+		return ErrWSConnClosed
+	default:
+	}
+
+	select {
+	case c.sendCh <- msg:
+		return nil
+	default:
+		if c.cfg.OverflowPolicy == WSDisconnectPolicy {
+			logger.Debug("Closing WebSocket with full send queue")
+			c.Close()
+		}
+		return ErrWSSendQueueFull
+	}
+}
+
+// OnClose registers a function that will be called when the connection is closed, or right away
+// if the connection is already closed. The function is called on its own goroutine, since the
+// connection may be closed by Send while the caller is holding locks the function needs.
+func (c *WSConn) OnClose(fn func()) {
+	c.mutex.Lock()
+	select {
+	case <-c.quitCh:
+		c.mutex.Unlock()
+		go fn()
+		return
+	default:
+	}
+	c.onClose = append(c.onClose, fn)
+	c.mutex.Unlock()
+}
+
+// Done returns a channel that's closed when the connection is closed.
+func (c *WSConn) Done() <-chan struct{} {
+	return c.quitCh
+}
+
+// Close stops the writer goroutine, which will then close the underlying connection. Any queued
+// messages that haven't been written yet are discarded.
+func (c *WSConn) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quitCh)
+
+		c.mutex.Lock()
+		onClose := c.onClose
+		c.onClose = nil
+		c.mutex.Unlock()
+
+		for _, fn := range onClose {
+			go fn()
+		}
+	})
+}
+
+func (c *WSConn) writePump() {
+	var ticker *time.Ticker
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("WebSocket write panicked", "err", r)
+		}
+		if ticker != nil {
+			ticker.Stop()
+		}
+		c.Close()
+		if err := c.conn.Close(); err != nil {
+			logger.Debug("Failed to close WebSocket", "err", err)
+		}
+	}()
+
+	ticker = time.NewTicker(time.Duration(c.cfg.PingInterval) * time.Second)
+	for {
+		select {
+		case msg := <-c.sendCh:
+			if err := c.write(websocket.TextMessage, msg); err != nil {
+				logger.Debug("Failed to write message to WebSocket", "err", err)
+				return
+			}
+		case <-ticker.C:
+			if err := c.write(websocket.PingMessage, nil); err != nil {
+				logger.Debug("Failed to write ping message to WebSocket", "err", err)
+				return
+			}
+		case <-c.quitCh:
+			if err := c.write(websocket.CloseMessage, []byte{}); err != nil && err != websocket.ErrCloseSent {
+				logger.Debug("Failed to write close message to WebSocket", "err", err)
+			}
+			return
+		}
+	}
+}
+
+func (c *WSConn) write(msgType int, data []byte) error {
+	deadline := time.Now().Add(time.Duration(c.cfg.WriteTimeout) * time.Second)
+	if err := c.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(msgType, data)
+}
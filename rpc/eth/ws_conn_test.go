@@ -0,0 +1,90 @@
+package eth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWSConn creates a connection without a writer goroutine, so nothing drains the send queue.
+func newTestWSConn(policy string) *WSConn {
+	return &WSConn{
+		cfg:    &WebSocketConfig{SendQueueSize: 2, OverflowPolicy: policy},
+		sendCh: make(chan []byte, 2),
+		quitCh: make(chan struct{}),
+	}
+}
+
+func TestWSConnDropPolicy(t *testing.T) {
+	c := newTestWSConn(WSDropPolicy)
+	require.NoError(t, c.Send([]byte("1")))
+	require.NoError(t, c.Send([]byte("2")))
+	require.Equal(t, ErrWSSendQueueFull, c.Send([]byte("3")))
+
+	// the connection should remain open, and the queued messages should be unaffected
+	select {
+	case <-c.Done():
+		t.Fatal("connection shouldn't be closed")
+	default:
+	}
+	require.Equal(t, []byte("1"), <-c.sendCh)
+	require.NoError(t, c.Send([]byte("4")))
+}
+
+func TestWSConnDisconnectPolicy(t *testing.T) {
+	c := newTestWSConn(WSDisconnectPolicy)
+	closed := make(chan struct{})
+	c.OnClose(func() { close(closed) })
+
+	require.NoError(t, c.Send([]byte("1")))
+	require.NoError(t, c.Send([]byte("2")))
+	require.Equal(t, ErrWSSendQueueFull, c.Send([]byte("3")))
+	require.Equal(t, ErrWSConnClosed, c.Send([]byte("4")))
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("OnClose callback wasn't called")
+	}
+
+	// callbacks registered after the connection is closed should be called too
+	closedAgain := make(chan struct{})
+	c.OnClose(func() { close(closedAgain) })
+	select {
+	case <-closedAgain:
+	case <-time.After(time.Second):
+		t.Fatal("OnClose callback wasn't called")
+	}
+}
+
+func TestWebSocketConfigWithDefaults(t *testing.T) {
+	defaults := DefaultWebSocketConfig()
+	require.Equal(t, defaults, (*WebSocketConfig)(nil).WithDefaults())
+	require.Equal(t, defaults, (&WebSocketConfig{}).WithDefaults())
+
+	// ping interval must be less than the pong timeout
+	cfg := (&WebSocketConfig{
+		SendQueueSize:  10,
+		WriteTimeout:   5,
+		PingInterval:   30,
+		PongTimeout:    30,
+		OverflowPolicy: WSDropPolicy,
+	}).WithDefaults()
+	require.Equal(t, &WebSocketConfig{
+		SendQueueSize:  10,
+		WriteTimeout:   5,
+		PingInterval:   defaults.PingInterval,
+		PongTimeout:    defaults.PongTimeout,
+		OverflowPolicy: WSDropPolicy,
+	}, cfg)
+
+	valid := &WebSocketConfig{
+		SendQueueSize:  10,
+		WriteTimeout:   5,
+		PingInterval:   20,
+		PongTimeout:    30,
+		OverflowPolicy: WSDisconnectPolicy,
+	}
+	require.Equal(t, valid, valid.WithDefaults())
+}
@@ -7,7 +7,6 @@ import (
 	etypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gogo/protobuf/proto"
-	"github.com/gorilla/websocket"
 	"github.com/loomnetwork/go-loom"
 	"github.com/loomnetwork/go-loom/common/evmcompat"
 	ltypes "github.com/loomnetwork/go-loom/types"
@@ -42,7 +41,7 @@ func NewSendRawTransactionRPCFunc(chainID string, broadcastTx func(tx types.Tx)
 
 // UnmarshalParamsAndCall implements RPCFunc
 func (t *SendRawTransactionPRCFunc) UnmarshalParamsAndCall(
-	input eth.JsonRpcRequest, conn *websocket.Conn,
+	input eth.JsonRpcRequest, conn *eth.WSConn,
 ) (json.RawMessage, *eth.Error) {
 	if len(input.Params) == 0 {
 		return nil, eth.NewError(eth.EcInvalidParams, "Parse params", "expected one or more parameters")
@@ -0,0 +1,221 @@
+package rpc
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gogo/protobuf/proto"
+	"github.com/loomnetwork/go-loom/auth"
+	"github.com/loomnetwork/go-loom/plugin/contractpb"
+	ltypes "github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/vm"
+	"github.com/pkg/errors"
+	ttypes "github.com/tendermint/tendermint/types"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
+	"github.com/loomnetwork/loomchain/features"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+// GasPriceProvider determines the gas price reported to clients via eth_gasPrice.
+type GasPriceProvider interface {
+	GasPrice() (*big.Int, error)
+}
+
+// ChainConfigContextFactory creates a read-only context for the ChainConfig contract.
+type ChainConfigContextFactory func(state loomchain.State) (contractpb.StaticContext, error)
+
+// NewGasPriceProvider creates the gas price provider specified by the given config. If a ChainConfig
+// context factory is provided the price will never fall below the minimum gas price set in the
+// ChainConfig contract.
+func NewGasPriceProvider(
+	cfg *eth.GasPriceConfig, blockStore store.BlockStore, stateProvider StateProvider,
+	createChainConfigCtx ChainConfigContextFactory,
+) (GasPriceProvider, error) {
+	if cfg == nil {
+		cfg = eth.DefaultGasPriceConfig()
+	}
+
+	fixedPrice := new(big.Int).SetUint64(cfg.FixedPrice)
+	var provider GasPriceProvider
+	switch strings.ToLower(cfg.Oracle) {
+	case "", "fixed":
+		provider = &fixedGasPriceProvider{price: fixedPrice}
+	case "median":
+		if cfg.NumBlocks == 0 {
+			return nil, errors.New("Web3.GasPrice.NumBlocks must be greater than zero")
+		}
+		provider = &medianGasPriceProvider{
+			blockStore:    blockStore,
+			stateProvider: stateProvider,
+			numBlocks:     int64(cfg.NumBlocks),
+			fallback:      fixedPrice,
+		}
+	default:
+		return nil, errors.Errorf("invalid value '%s' for Web3.GasPrice.Oracle config setting", cfg.Oracle)
+	}
+
+	if cfg.MinPrice > 0 {
+		provider = &minGasPriceProvider{
+			next:     provider,
+			minPrice: new(big.Int).SetUint64(cfg.MinPrice),
+		}
+	}
+	if createChainConfigCtx != nil {
+		provider = &onChainMinGasPriceProvider{
+			next:                 provider,
+			stateProvider:        stateProvider,
+			createChainConfigCtx: createChainConfigCtx,
+		}
+	}
+	return provider, nil
+}
+
+type fixedGasPriceProvider struct {
+	price *big.Int
+}
+
+func (p *fixedGasPriceProvider) GasPrice() (*big.Int, error) {
+	return new(big.Int).Set(p.price), nil
+}
+
+// minGasPriceProvider ensures the price returned by another provider never falls below a minimum.
+type minGasPriceProvider struct {
+	next     GasPriceProvider
+	minPrice *big.Int
+}
+
+func (p *minGasPriceProvider) GasPrice() (*big.Int, error) {
+	price, err := p.next.GasPrice()
+	if err != nil {
+		return nil, err
+	}
+	if price.Cmp(p.minPrice) < 0 {
+		return new(big.Int).Set(p.minPrice), nil
+	}
+	return price, nil
+}
+
+// onChainMinGasPriceProvider ensures the price returned by another provider never falls below the
+// minimum gas price set in the ChainConfig contract.
+type onChainMinGasPriceProvider struct {
+	next                 GasPriceProvider
+	stateProvider        StateProvider
+	createChainConfigCtx ChainConfigContextFactory
+}
+
+func (p *onChainMinGasPriceProvider) GasPrice() (*big.Int, error) {
+	price, err := p.next.GasPrice()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := p.stateProvider.ReadOnlyState()
+	defer snapshot.Release()
+
+	if !snapshot.FeatureEnabled(features.ChainCfgVersion1_5, false) {
+		return price, nil
+	}
+	ctx, err := p.createChainConfigCtx(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	minPrice, err := chainconfig.GetMinGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load min gas price")
+	}
+	if price.Cmp(minPrice) < 0 {
+		return minPrice, nil
+	}
+	return price, nil
+}
+
+// medianGasPriceProvider computes the median gas price of the Ethereum txs committed in the most
+// recent blocks. Native Loom txs don't specify a gas price so they're ignored. The result is
+// cached until a new block is committed.
+type medianGasPriceProvider struct {
+	blockStore    store.BlockStore
+	stateProvider StateProvider
+	numBlocks     int64
+	fallback      *big.Int
+
+	mutex        sync.Mutex
+	cachedHeight int64
+	cachedPrice  *big.Int
+}
+
+func (p *medianGasPriceProvider) GasPrice() (*big.Int, error) {
+	snapshot := p.stateProvider.ReadOnlyState()
+	latestHeight := snapshot.Block().Height
+	snapshot.Release()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.cachedPrice != nil && p.cachedHeight == latestHeight {
+		return new(big.Int).Set(p.cachedPrice), nil
+	}
+
+	var prices []*big.Int
+	for height := latestHeight; height > 0 && height > latestHeight-p.numBlocks; height-- {
+		h := height
+		blockResult, err := p.blockStore.GetBlockByHeight(&h)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load block %d", h)
+		}
+		if blockResult == nil || blockResult.Block == nil {
+			continue
+		}
+		for _, tx := range blockResult.Block.Data.Txs {
+			if price := ethTxGasPrice(tx); price != nil {
+				prices = append(prices, price)
+			}
+		}
+	}
+
+	price := p.fallback
+	if len(prices) > 0 {
+		sort.Slice(prices, func(i, j int) bool {
+			return prices[i].Cmp(prices[j]) < 0
+		})
+		price = prices[len(prices)/2]
+	}
+	p.cachedHeight = latestHeight
+	p.cachedPrice = price
+	return new(big.Int).Set(price), nil
+}
+
+// ethTxGasPrice returns the gas price of the given tx if it wraps a signed Ethereum tx,
+// or nil otherwise.
+func ethTxGasPrice(tx ttypes.Tx) *big.Int {
+	var signedTx auth.SignedTx
+	if err := proto.Unmarshal(tx, &signedTx); err != nil {
+		return nil
+	}
+	var nonceTx auth.NonceTx
+	if err := proto.Unmarshal(signedTx.Inner, &nonceTx); err != nil {
+		return nil
+	}
+	var txTx ltypes.Transaction
+	if err := proto.Unmarshal(nonceTx.Inner, &txTx); err != nil {
+		return nil
+	}
+	if ltypes.TxID(txTx.Id) != ltypes.TxID_ETHEREUM {
+		return nil
+	}
+	var msg vm.MessageTx
+	if err := proto.Unmarshal(txTx.Data, &msg); err != nil {
+		return nil
+	}
+	var ethTx etypes.Transaction
+	if err := rlp.DecodeBytes(msg.Data, &ethTx); err != nil {
+		return nil
+	}
+	return ethTx.GasPrice()
+}
@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gogo/protobuf/proto"
+	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/auth"
+	"github.com/loomnetwork/go-loom/plugin"
+	"github.com/loomnetwork/go-loom/plugin/contractpb"
+	ltypes "github.com/loomnetwork/go-loom/types"
+	"github.com/loomnetwork/go-loom/vm"
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
+	"github.com/loomnetwork/loomchain/features"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+	"github.com/loomnetwork/loomchain/store"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+type heightStateProvider struct {
+	height   int64
+	features []string
+}
+
+func (s *heightStateProvider) ReadOnlyState() loomchain.State {
+	state := loomchain.NewStoreState(nil, store.NewMemStore(), abci.Header{Height: s.height}, nil, nil)
+	for _, feature := range s.features {
+		state.SetFeature(feature, true)
+	}
+	return state
+}
+
+func mockEthTx(t *testing.T, gasPrice int64) []byte {
+	ethTx := etypes.NewTransaction(0, [20]byte{1}, big.NewInt(0), 0, big.NewInt(gasPrice), nil)
+	ethTxBytes, err := rlp.EncodeToBytes(ethTx)
+	require.NoError(t, err)
+	msgBytes, err := proto.Marshal(&vm.MessageTx{Data: ethTxBytes})
+	require.NoError(t, err)
+	txBytes, err := proto.Marshal(&ltypes.Transaction{Id: uint32(ltypes.TxID_ETHEREUM), Data: msgBytes})
+	require.NoError(t, err)
+	nonceTxBytes, err := proto.Marshal(&auth.NonceTx{Inner: txBytes, Sequence: 1})
+	require.NoError(t, err)
+	signedTxBytes, err := proto.Marshal(&auth.SignedTx{Inner: nonceTxBytes})
+	require.NoError(t, err)
+	return signedTxBytes
+}
+
+func TestFixedGasPrice(t *testing.T) {
+	cfg := eth.DefaultGasPriceConfig()
+	cfg.FixedPrice = 1000
+	provider, err := NewGasPriceProvider(cfg, store.NewMockBlockStore(), &heightStateProvider{height: 10}, nil)
+	require.NoError(t, err)
+	price, err := provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), price.Int64())
+
+	cfg.MinPrice = 5000
+	provider, err = NewGasPriceProvider(cfg, store.NewMockBlockStore(), &heightStateProvider{height: 10}, nil)
+	require.NoError(t, err)
+	price, err = provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), price.Int64())
+
+	cfg.Oracle = "magic"
+	_, err = NewGasPriceProvider(cfg, store.NewMockBlockStore(), &heightStateProvider{height: 10}, nil)
+	require.Error(t, err)
+}
+
+func TestMedianGasPrice(t *testing.T) {
+	blockStore := store.NewMockBlockStore()
+	blockStore.SetBlock(store.MockBlock(8, []byte("block8"), [][]byte{mockEthTx(t, 50)}))
+	blockStore.SetBlock(store.MockBlock(9, []byte("block9"), [][]byte{mockEthTx(t, 10), mockEthTx(t, 20)}))
+	blockStore.SetBlock(store.MockBlock(10, []byte("block10"), [][]byte{mockEthTx(t, 40), mockEthTx(t, 30)}))
+
+	cfg := &eth.GasPriceConfig{
+		Oracle:     "median",
+		FixedPrice: 7,
+		NumBlocks:  2,
+	}
+	sp := &heightStateProvider{height: 10}
+	provider, err := NewGasPriceProvider(cfg, blockStore, sp, nil)
+	require.NoError(t, err)
+	price, err := provider.GasPrice()
+	require.NoError(t, err)
+	// only blocks 9 & 10 should be sampled
+	require.Equal(t, int64(30), price.Int64())
+
+	// blocks without any Ethereum txs should produce the fallback price
+	sp.height = 7
+	price, err = provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), price.Int64())
+
+	cfg.MinPrice = 100
+	sp.height = 10
+	provider, err = NewGasPriceProvider(cfg, blockStore, sp, nil)
+	require.NoError(t, err)
+	price, err = provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(100), price.Int64())
+}
+
+func TestOnChainMinGasPrice(t *testing.T) {
+	owner := loom.MustParseAddress("default:0xb16a379ec18d4093666f8f38b11a3071c920207d")
+	contractAddr := loom.MustParseAddress("default:0x5cecd1f7261e1f4c684e297be3edf03b825e01c4")
+	pctx := plugin.CreateFakeContext(owner, contractAddr)
+	ctx := contractpb.WrapPluginContext(pctx)
+	chainConfig := &chainconfig.ChainConfig{}
+	require.NoError(t, chainConfig.Init(ctx, &chainconfig.InitRequest{Owner: owner.MarshalPB()}))
+
+	minGasPrice := &ltypes.BigUInt{Value: *loom.NewBigUIntFromInt(500)}
+	require.Equal(t, chainconfig.ErrFeatureNotEnabled,
+		chainConfig.SetMinGasPrice(ctx, &chainconfig.SetMinGasPriceRequest{MinGasPrice: minGasPrice}))
+	pctx.SetFeature(features.ChainCfgVersion1_5, true)
+	require.NoError(t, chainConfig.SetMinGasPrice(ctx, &chainconfig.SetMinGasPriceRequest{MinGasPrice: minGasPrice}))
+
+	nonOwnerCtx := contractpb.WrapPluginContext(pctx.WithSender(contractAddr))
+	require.Equal(t, chainconfig.ErrNotAuthorized,
+		chainConfig.SetMinGasPrice(nonOwnerCtx, &chainconfig.SetMinGasPriceRequest{MinGasPrice: minGasPrice}))
+
+	createChainConfigCtx := func(state loomchain.State) (contractpb.StaticContext, error) {
+		return ctx, nil
+	}
+	cfg := eth.DefaultGasPriceConfig()
+	cfg.FixedPrice = 100
+	sp := &heightStateProvider{height: 10}
+	provider, err := NewGasPriceProvider(cfg, store.NewMockBlockStore(), sp, createChainConfigCtx)
+	require.NoError(t, err)
+	// the on-chain minimum should be ignored until the feature is enabled
+	price, err := provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(100), price.Int64())
+
+	sp.features = []string{features.ChainCfgVersion1_5}
+	price, err = provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(500), price.Int64())
+
+	cfg.FixedPrice = 1000
+	provider, err = NewGasPriceProvider(cfg, store.NewMockBlockStore(), sp, createChainConfigCtx)
+	require.NoError(t, err)
+	price, err = provider.GasPrice()
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), price.Int64())
+}
@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+	"github.com/loomnetwork/loomchain/store"
+)
+
+// HistoricalStateProvider is implemented by state providers that can provide read-only snapshots
+// of the state as it was at previous heights.
+type HistoricalStateProvider interface {
+	// ReadOnlyStateAt returns the state as it was after the block at the given height was committed,
+	// the given header will be returned by State.Block().
+	ReadOnlyStateAt(height int64, header abci.Header) (loomchain.State, error)
+}
+
+// readOnlyStateAtBlock returns the state as it was after the given block was committed, the latest
+// state is returned if no block is specified, or if the block is "latest" or "pending".
+// The caller is responsible for releasing the returned state.
+func (s *QueryServer) readOnlyStateAtBlock(block eth.BlockHeight) (loomchain.State, error) {
+	snapshot := s.StateProvider.ReadOnlyState()
+	if block == "" {
+		return snapshot, nil
+	}
+	height, err := eth.DecBlockHeight(snapshot.Block().Height, block)
+	if err != nil {
+		snapshot.Release()
+		return nil, errors.Wrapf(err, "invalid block height %s", block)
+	}
+	// The pending block height is one past the latest, but there's no pending state to return
+	if int64(height) >= snapshot.Block().Height {
+		return snapshot, nil
+	}
+	snapshot.Release()
+
+	h := int64(height)
+	blockResult, err := s.BlockStore.GetBlockByHeight(&h)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load block %d", h)
+	}
+	return s.readOnlyStateAt(h, blockResult)
+}
+
+// readOnlyStateAt returns the state as it was at the given height, with the header of the given
+// block.
+func (s *QueryServer) readOnlyStateAt(height int64, blockResult *ctypes.ResultBlock) (loomchain.State, error) {
+	stateProvider, ok := s.StateProvider.(HistoricalStateProvider)
+	if !ok {
+		return nil, errors.New("historical state isn't available")
+	}
+	header := blockResult.Block.Header
+	state, err := stateProvider.ReadOnlyStateAt(height, abci.Header{
+		ChainID: header.ChainID,
+		Height:  header.Height,
+		Time:    header.Time,
+		NumTxs:  header.NumTxs,
+		LastBlockId: abci.BlockID{
+			Hash: header.LastBlockID.Hash,
+		},
+		ValidatorsHash: header.ValidatorsHash,
+		AppHash:        header.AppHash,
+	})
+	if err != nil {
+		if errors.Cause(err) == store.ErrStatePruned {
+			return nil, eth.NewErrorf(
+				eth.EcServer, "state pruned", "state at height %d is no longer available", height,
+			)
+		}
+		return nil, errors.Wrapf(err, "failed to load state at height %d", height)
+	}
+	return state, nil
+}
@@ -4,6 +4,10 @@
 
 package rpc
 
+import (
+	"github.com/loomnetwork/loomchain/rpc/eth"
+)
+
 // Hub maintains the set of active clients and broadcasts messages to the
 // clients.
 type Hub struct {
@@ -15,13 +19,17 @@ type Hub struct {
 
 	// Unregister requests from clients.
 	unregister chan *Client
+
+	// Settings applied to the websocket connection of each client.
+	wsCfg *eth.WebSocketConfig
 }
 
-func newHub() *Hub {
+func newHub(wsCfg *eth.WebSocketConfig) *Hub {
 	return &Hub{
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		clients:    make(map[*Client]bool),
+		wsCfg:      wsCfg,
 	}
 }
 
@@ -33,7 +41,7 @@ func (h *Hub) run() {
 		case client := <-h.unregister:
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
-				close(client.send)
+				client.conn.Close()
 			}
 		}
 	}
@@ -5,9 +5,10 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/metrics"
-	"github.com/gorilla/websocket"
 	"github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/loomchain/config"
+	levm "github.com/loomnetwork/loomchain/evm"
+	"github.com/loomnetwork/loomchain/registry"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/vm"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
@@ -125,6 +126,30 @@ func (m InstrumentingMiddleware) GetContractRecord(contractAddr string) (resp *t
 	return
 }
 
+func (m InstrumentingMiddleware) GetContractABI(contract string) (resp string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "GetContractABI", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.GetContractABI(contract)
+	return
+}
+
+func (m InstrumentingMiddleware) GetContractUpgrades(
+	contract string,
+) (resp *registry.ContractUpgradeHistory, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "GetContractUpgrades", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.GetContractUpgrades(contract)
+	return
+}
+
 func (m InstrumentingMiddleware) DPOSTotalStaked() (resp *DPOSTotalStakedResponse, err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "DposTotalStaked", "error", fmt.Sprint(err != nil)}
@@ -493,7 +518,7 @@ func (m InstrumentingMiddleware) EthNewFilter(filter eth.JsonFilter) (resp eth.Q
 }
 
 func (m InstrumentingMiddleware) EthSubscribe(
-	conn *websocket.Conn, method eth.Data, filter eth.JsonFilter,
+	conn *eth.WSConn, method eth.Data, filter eth.JsonFilter,
 ) (resp eth.Data, err error) {
 	defer func(begin time.Time) {
 		lvs := []string{"method", "EthSubscribe", "error", fmt.Sprint(err != nil)}
@@ -582,6 +607,122 @@ func (m InstrumentingMiddleware) EthAccounts() (resp []eth.Data, err error) {
 	return
 }
 
+func (m InstrumentingMiddleware) EthChainId() (resp eth.Quantity, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "EthChainId", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.EthChainId()
+	return
+}
+
+func (m InstrumentingMiddleware) EthSyncing() (resp interface{}, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "EthSyncing", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.EthSyncing()
+	return
+}
+
+func (m InstrumentingMiddleware) EthProtocolVersion() (resp string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "EthProtocolVersion", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.EthProtocolVersion()
+	return
+}
+
+func (m InstrumentingMiddleware) NetListening() (resp bool, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "NetListening", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.NetListening()
+	return
+}
+
+func (m InstrumentingMiddleware) NetPeerCount() (resp eth.Quantity, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "NetPeerCount", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.NetPeerCount()
+	return
+}
+
+func (m InstrumentingMiddleware) Web3ClientVersion() (resp string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "Web3ClientVersion", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.Web3ClientVersion()
+	return
+}
+
+func (m InstrumentingMiddleware) Web3Sha3(data eth.Data) (resp eth.Data, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "Web3Sha3", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.Web3Sha3(data)
+	return
+}
+
+func (m InstrumentingMiddleware) DebugTraceTransaction(
+	hash eth.Data, cfg levm.TraceConfig,
+) (resp interface{}, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "DebugTraceTransaction", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.DebugTraceTransaction(hash, cfg)
+	return
+}
+
+func (m InstrumentingMiddleware) DebugTraceCall(
+	query eth.JsonTxCallObject, block eth.BlockHeight, cfg levm.TraceConfig,
+) (resp interface{}, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "DebugTraceCall", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.DebugTraceCall(query, block, cfg)
+	return
+}
+
+func (m InstrumentingMiddleware) DebugTraceBlockByNumber(
+	block eth.BlockHeight, cfg levm.TraceConfig,
+) (resp []*TxTraceResult, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"method", "DebugTraceBlockByNumber", "error", fmt.Sprint(err != nil)}
+		m.requestCount.With(lvs...).Add(1)
+		m.requestLatency.With(lvs...).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	resp, err = m.next.DebugTraceBlockByNumber(block, cfg)
+	return
+}
+
 func (m InstrumentingMiddleware) EthGetTransactionCount(
 	local eth.Data, block eth.BlockHeight,
 ) (resp eth.Quantity, err error) {
@@ -7,25 +7,34 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/gorilla/websocket"
-
 	"github.com/loomnetwork/loomchain/log"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 )
 
 func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]eth.RPCFunc, logger log.TMLogger, hub *Hub) {
 	mux.HandleFunc("/", func(writer http.ResponseWriter, reader *http.Request) {
+		requestID := RequestIDFromContext(reader.Context())
+		reqLogger := requestLogger(reader.Context(), logger)
+		limiter := clientLimiterFromContext(reader.Context())
 		if isWebSocketConnection(reader) {
-			conn, err := upgrader.Upgrade(writer, reader, nil)
+			var respHeader http.Header
+			if requestID != "" {
+				respHeader = http.Header{RequestIDHeader: []string{requestID}}
+			}
+			conn, err := upgrader.Upgrade(writer, reader, respHeader)
 			if err != nil {
-				logger.Error("JSON-RPC2 http request, message with no body received")
+				reqLogger.Error("JSON-RPC2 http request, message with no body received")
 				return
 			}
-			client := &Client{hub: hub, conn: conn, send: make(chan []byte, 256)}
+			client := &Client{
+				hub:     hub,
+				conn:    eth.NewWSConn(conn, hub.wsCfg),
+				id:      requestID,
+				limiter: limiter,
+			}
 			client.hub.register <- client
 
 			go client.readPump(funcMap, logger)
-			go client.writePump(logger)
 			return
 		}
 
@@ -38,7 +47,7 @@ func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]eth.RPCFunc, logger
 			return
 		}
 
-		outBytes, ethError := handleMessage(body, funcMap, nil)
+		outBytes, ethError := handleMessage(body, funcMap, nil, limiter, reqLogger)
 
 		if ethError != nil {
 			WriteResponse(writer, eth.JsonRpcErrorResponse{
@@ -52,12 +61,18 @@ func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]eth.RPCFunc, logger
 		writer.WriteHeader(http.StatusOK)
 		_, err = writer.Write(outBytes)
 		if err != nil {
-			logger.Error("JSON-RPC2 http request, writing response", "err", err)
+			reqLogger.Error("JSON-RPC2 http request, writing response", "err", err)
 		}
 	})
 }
 
-func handleMessage(body []byte, funcMap map[string]eth.RPCFunc, conn *websocket.Conn) ([]byte, *eth.Error) {
+// handleMessage calls the RPC function(s) requested in the given JSON-RPC message, errors returned
+// by the functions are logged via the given logger. If a limiter is specified calls that exceed
+// the client's rate limits are rejected, each call in a batch is checked separately.
+func handleMessage(
+	body []byte, funcMap map[string]eth.RPCFunc, conn *eth.WSConn, limiter *clientLimiter,
+	logger log.TMLogger,
+) ([]byte, *eth.Error) {
 	requestList, isBatch, reqListErr := getRequests(body)
 
 	if reqListErr != nil {
@@ -77,9 +92,19 @@ func handleMessage(body []byte, funcMap map[string]eth.RPCFunc, conn *websocket.
 			continue
 		}
 
+		if jsonErr := limiter.check(jsonRequest.Method, logger); jsonErr != nil {
+			outputList = append(outputList, eth.JsonRpcErrorResponse{
+				Version: "2.0",
+				ID:      jsonRequest.ID,
+				Error:   *jsonErr,
+			})
+			continue
+		}
+
 		rawResult, jsonErr := method.UnmarshalParamsAndCall(jsonRequest, conn)
 
 		if jsonErr != nil {
+			logger.Debug("JSON-RPC2 method call failed", "method", jsonRequest.Method, "err", jsonErr.Error())
 			outputList = append(outputList, eth.JsonRpcErrorResponse{
 				Version: "2.0",
 				ID:      jsonRequest.ID,
@@ -51,7 +51,17 @@ var (
 		{"net_version", "EthNetVersion", ``},
 		{"eth_getTransactionCount", "EthGetTransactionCount", ``},
 		{"eth_accounts", "EthAccounts", ``},
+		{"eth_chainId", "EthChainId", ``},
+		{"eth_syncing", "EthSyncing", ``},
+		{"eth_protocolVersion", "EthProtocolVersion", ``},
+		{"net_listening", "NetListening", ``},
+		{"net_peerCount", "NetPeerCount", ``},
+		{"web3_clientVersion", "Web3ClientVersion", ``},
+		{"web3_sha3", "Web3Sha3", `"0x68656c6c6f"`},
 		{"eth_getStorageAt", "EthGetStorageAt", ``},
+		{"debug_traceTransaction", "DebugTraceTransaction", ``},
+		{"debug_traceCall", "DebugTraceCall", ``},
+		{"debug_traceBlockByNumber", "DebugTraceBlockByNumber", ``},
 	}
 )
 
@@ -104,7 +114,7 @@ func testBatchHttpJsonHandler(t *testing.T) {
 }
 
 func testEthSubscribeEthUnSubscribe(t *testing.T) {
-	hub := newHub()
+	hub := newHub(eth.DefaultWebSocketConfig())
 	go hub.run()
 	loader := &queryableContractLoader{TMLogger: log.Root.With("module", "contract")}
 	eventDispatcher := events.NewLogEventDispatcher()
@@ -143,7 +153,7 @@ func testEthSubscribeEthUnSubscribe(t *testing.T) {
 }
 
 func testMultipleWebsocketConnections(t *testing.T) {
-	hub := newHub()
+	hub := newHub(eth.DefaultWebSocketConfig())
 	go hub.run()
 	qs := &MockQueryService{}
 	handler := MakeEthQueryServiceHandler(testlog, hub, createDefaultEthRoutes(qs, "default"))
@@ -180,7 +190,7 @@ func testMultipleWebsocketConnections(t *testing.T) {
 }
 
 func testSingleWebsocketConnections(t *testing.T) {
-	hub := newHub()
+	hub := newHub(eth.DefaultWebSocketConfig())
 	go hub.run()
 	qs := &MockQueryService{}
 	handler := MakeEthQueryServiceHandler(testlog, hub, createDefaultEthRoutes(qs, "default"))
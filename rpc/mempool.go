@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	ttypes "github.com/tendermint/tendermint/types"
+)
+
+// Tendermint won't return more than this many txs from the mempool in one go.
+const maxPendingTxs = 100
+
+// MempoolProvider provides access to the txs that are waiting to be included in a block.
+type MempoolProvider interface {
+	// PendingTxs returns the txs currently in the mempool, in the order in which they'll be
+	// included in blocks.
+	PendingTxs() ([]ttypes.Tx, error)
+}
+
+// TendermintMempoolProvider obtains the pending txs from the mempool of the Tendermint node the
+// app is running in. Only the first 100 txs in the mempool are returned.
+type TendermintMempoolProvider struct{}
+
+func NewTendermintMempoolProvider() *TendermintMempoolProvider {
+	return &TendermintMempoolProvider{}
+}
+
+func (p *TendermintMempoolProvider) PendingTxs() ([]ttypes.Tx, error) {
+	result, err := rpccore.UnconfirmedTxs(maxPendingTxs)
+	if err != nil {
+		return nil, err
+	}
+	return result.Txs, nil
+}
@@ -3,12 +3,13 @@ package rpc
 import (
 	"sync"
 
-	"github.com/gorilla/websocket"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
 
 	"github.com/loomnetwork/go-loom/plugin/types"
 
 	"github.com/loomnetwork/loomchain/config"
+	levm "github.com/loomnetwork/loomchain/evm"
+	"github.com/loomnetwork/loomchain/registry"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/vm"
 )
@@ -199,7 +200,7 @@ func (m *MockQueryService) EthNewFilter(filter eth.JsonFilter) (eth.Quantity, er
 }
 
 func (m *MockQueryService) EthSubscribe(
-	conn *websocket.Conn, method eth.Data, filter eth.JsonFilter,
+	conn *eth.WSConn, method eth.Data, filter eth.JsonFilter,
 ) (id eth.Data, err error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -256,6 +257,80 @@ func (m *MockQueryService) EthAccounts() ([]eth.Data, error) {
 	return nil, nil
 }
 
+func (m *MockQueryService) EthChainId() (eth.Quantity, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"EthChainId"}, m.MethodsCalled...)
+	return "", nil
+}
+
+func (m *MockQueryService) EthSyncing() (interface{}, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"EthSyncing"}, m.MethodsCalled...)
+	return nil, nil
+}
+
+func (m *MockQueryService) EthProtocolVersion() (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"EthProtocolVersion"}, m.MethodsCalled...)
+	return "", nil
+}
+
+func (m *MockQueryService) NetListening() (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"NetListening"}, m.MethodsCalled...)
+	return false, nil
+}
+
+func (m *MockQueryService) NetPeerCount() (eth.Quantity, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"NetPeerCount"}, m.MethodsCalled...)
+	return "", nil
+}
+
+func (m *MockQueryService) Web3ClientVersion() (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"Web3ClientVersion"}, m.MethodsCalled...)
+	return "", nil
+}
+
+func (m *MockQueryService) Web3Sha3(data eth.Data) (eth.Data, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"Web3Sha3"}, m.MethodsCalled...)
+	return "", nil
+}
+
+func (m *MockQueryService) DebugTraceTransaction(hash eth.Data, cfg levm.TraceConfig) (interface{}, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"DebugTraceTransaction"}, m.MethodsCalled...)
+	return nil, nil
+}
+
+func (m *MockQueryService) DebugTraceCall(
+	query eth.JsonTxCallObject, block eth.BlockHeight, cfg levm.TraceConfig,
+) (interface{}, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"DebugTraceCall"}, m.MethodsCalled...)
+	return nil, nil
+}
+
+func (m *MockQueryService) DebugTraceBlockByNumber(
+	block eth.BlockHeight, cfg levm.TraceConfig,
+) ([]*TxTraceResult, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.MethodsCalled = append([]string{"DebugTraceBlockByNumber"}, m.MethodsCalled...)
+	return nil, nil
+}
+
 func (m *MockQueryService) ContractEvents(
 	fromBlock uint64, toBlock uint64, contract string,
 ) (*types.ContractEventsResult, error) {
@@ -270,6 +345,16 @@ func (m *MockQueryService) GetContractRecord(addr string) (*types.ContractRecord
 	return nil, nil
 }
 
+func (m *MockQueryService) GetContractABI(contract string) (string, error) {
+	m.MethodsCalled = append([]string{"GetContractABI"}, m.MethodsCalled...)
+	return "", nil
+}
+
+func (m *MockQueryService) GetContractUpgrades(contract string) (*registry.ContractUpgradeHistory, error) {
+	m.MethodsCalled = append([]string{"GetContractUpgrades"}, m.MethodsCalled...)
+	return nil, nil
+}
+
 func (m *MockQueryService) DPOSTotalStaked() (*DPOSTotalStakedResponse, error) {
 	m.MethodsCalled = append([]string{"DposTotalStaked"}, m.MethodsCalled...)
 	return nil, nil
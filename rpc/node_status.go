@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+
+	"github.com/loomnetwork/loomchain/eth/subs"
+)
+
+// NodeStatusProvider provides info about the sync status & peers of the node.
+type NodeStatusProvider interface {
+	SyncStatus() (*subs.SyncStatus, error)
+	NetInfo() (listening bool, numPeers int, err error)
+}
+
+// TendermintNodeStatusProvider obtains the node status from the Tendermint node the app is
+// running in.
+type TendermintNodeStatusProvider struct {
+	mutex sync.Mutex
+	// Height at which the node started catching up, zero when the node isn't catching up.
+	startingBlock int64
+	// Highest block height seen while catching up.
+	highestBlock int64
+}
+
+func NewTendermintNodeStatusProvider() *TendermintNodeStatusProvider {
+	return &TendermintNodeStatusProvider{}
+}
+
+// SyncStatus returns the current sync status of the node. Tendermint doesn't expose the height
+// of the peers the node is syncing from, so the highest block is the highest height reported by
+// any peer in the consensus state, or the current height if that isn't available.
+func (p *TendermintNodeStatusProvider) SyncStatus() (*subs.SyncStatus, error) {
+	status, err := rpccore.Status()
+	if err != nil {
+		return nil, err
+	}
+	currentBlock := status.SyncInfo.LatestBlockHeight
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !status.SyncInfo.CatchingUp {
+		p.startingBlock = 0
+		p.highestBlock = 0
+		return &subs.SyncStatus{CurrentBlock: currentBlock, HighestBlock: currentBlock}, nil
+	}
+
+	if p.startingBlock == 0 {
+		p.startingBlock = currentBlock
+	}
+	if peerHeight := maxPeerHeight(); peerHeight > p.highestBlock {
+		p.highestBlock = peerHeight
+	}
+	if currentBlock > p.highestBlock {
+		p.highestBlock = currentBlock
+	}
+	return &subs.SyncStatus{
+		Syncing:       true,
+		StartingBlock: p.startingBlock,
+		CurrentBlock:  currentBlock,
+		HighestBlock:  p.highestBlock,
+	}, nil
+}
+
+func (p *TendermintNodeStatusProvider) NetInfo() (bool, int, error) {
+	info, err := rpccore.NetInfo()
+	if err != nil {
+		return false, 0, err
+	}
+	return info.Listening, info.NPeers, nil
+}
+
+// maxPeerHeight returns the highest block height any of the connected peers is at, or zero
+// if the peer heights aren't known.
+func maxPeerHeight() int64 {
+	state, err := rpccore.DumpConsensusState()
+	if err != nil {
+		return 0
+	}
+	var maxHeight int64
+	for _, peer := range state.Peers {
+		var peerState struct {
+			RoundState struct {
+				Height int64 `json:"height,string"`
+			} `json:"round_state"`
+		}
+		if err := json.Unmarshal(peer.PeerState, &peerState); err != nil {
+			continue
+		}
+		// Peers report the height they're trying to reach consensus on, which is one block ahead
+		// of the last committed block.
+		if height := peerState.RoundState.Height - 1; height > maxHeight {
+			maxHeight = height
+		}
+	}
+	return maxHeight
+}
+
+// SyncStatusMonitor periodically polls the sync status of the node, and publishes any changes
+// to the syncing subscribers.
+type SyncStatusMonitor struct {
+	provider   NodeStatusProvider
+	ethSubs    *subs.EthSubscriptionSet
+	legacySubs *subs.LegacyEthSubscriptionSet
+	interval   time.Duration
+	quitCh     chan struct{}
+	last       *subs.SyncStatus
+}
+
+func NewSyncStatusMonitor(
+	provider NodeStatusProvider,
+	ethSubs *subs.EthSubscriptionSet,
+	legacySubs *subs.LegacyEthSubscriptionSet,
+	interval time.Duration,
+) *SyncStatusMonitor {
+	return &SyncStatusMonitor{
+		provider:   provider,
+		ethSubs:    ethSubs,
+		legacySubs: legacySubs,
+		interval:   interval,
+		quitCh:     make(chan struct{}),
+	}
+}
+
+func (m *SyncStatusMonitor) Start() {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.poll()
+			case <-m.quitCh:
+				return
+			}
+		}
+	}()
+}
+
+func (m *SyncStatusMonitor) Stop() {
+	close(m.quitCh)
+}
+
+func (m *SyncStatusMonitor) poll() {
+	status, err := m.provider.SyncStatus()
+	if err != nil {
+		logger.Error("Failed to obtain node sync status", "err", err)
+		return
+	}
+	// Only publish when the node starts or stops syncing, or makes progress while syncing.
+	if m.last != nil && m.last.Syncing == status.Syncing && (!status.Syncing || *m.last == *status) {
+		return
+	}
+	m.last = status
+	if err := m.ethSubs.EmitSyncingEvent(*status); err != nil {
+		logger.Error("Failed to emit syncing event", "err", err)
+	}
+	if err := m.legacySubs.EmitSyncingEvent(*status); err != nil {
+		logger.Error("Failed to emit legacy syncing event", "err", err)
+	}
+}
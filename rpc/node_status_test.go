@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/loomnetwork/go-loom/common/evmcompat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/loomnetwork/loomchain/eth/subs"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+)
+
+type mockNodeStatusProvider struct {
+	status   subs.SyncStatus
+	numPeers int
+}
+
+func (p *mockNodeStatusProvider) SyncStatus() (*subs.SyncStatus, error) {
+	status := p.status
+	return &status, nil
+}
+
+func (p *mockNodeStatusProvider) NetInfo() (bool, int, error) {
+	return true, p.numPeers, nil
+}
+
+func TestQueryServerNodeStatus(t *testing.T) {
+	provider := &mockNodeStatusProvider{numPeers: 3}
+	qs := &QueryServer{ChainID: "default", NodeStatusProvider: provider}
+
+	chainID, err := qs.EthChainId()
+	require.NoError(t, err)
+	ethChainID, err := evmcompat.ToEthereumChainID("default")
+	require.NoError(t, err)
+	require.Equal(t, eth.EncBigInt(*ethChainID), chainID)
+
+	syncing, err := qs.EthSyncing()
+	require.NoError(t, err)
+	require.Equal(t, false, syncing)
+
+	provider.status = subs.SyncStatus{Syncing: true, StartingBlock: 10, CurrentBlock: 15, HighestBlock: 100}
+	syncing, err = qs.EthSyncing()
+	require.NoError(t, err)
+	require.Equal(t, &eth.JsonSyncStatus{
+		StartingBlock: "0xa",
+		CurrentBlock:  "0xf",
+		HighestBlock:  "0x64",
+	}, syncing)
+
+	listening, err := qs.NetListening()
+	require.NoError(t, err)
+	require.True(t, listening)
+	peerCount, err := qs.NetPeerCount()
+	require.NoError(t, err)
+	require.Equal(t, eth.Quantity("0x3"), peerCount)
+
+	// a node without a status provider is reported as a standalone node that's not syncing
+	qs.NodeStatusProvider = nil
+	syncing, err = qs.EthSyncing()
+	require.NoError(t, err)
+	require.Equal(t, false, syncing)
+	peerCount, err = qs.NetPeerCount()
+	require.NoError(t, err)
+	require.Equal(t, eth.ZeroedQuantity, peerCount)
+}
+
+func TestQueryServerWeb3Sha3(t *testing.T) {
+	qs := &QueryServer{}
+	hash, err := qs.Web3Sha3("0x68656c6c6f")
+	require.NoError(t, err)
+	require.Equal(t, eth.Data("0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8"), hash)
+
+	_, err = qs.Web3Sha3("0xzz")
+	require.Error(t, err)
+}
@@ -1,15 +1,17 @@
 package rpc
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/gorilla/websocket"
-
 	"github.com/gogo/protobuf/proto"
 	gtypes "github.com/loomnetwork/go-loom/types"
 	sha3 "github.com/miguelmota/go-solidity-sha3"
@@ -18,9 +20,11 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	ttypes "github.com/tendermint/tendermint/types"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/common/evmcompat"
 	"github.com/loomnetwork/go-loom/plugin"
 	"github.com/loomnetwork/go-loom/plugin/contractpb"
 	"github.com/loomnetwork/go-loom/plugin/types"
@@ -35,8 +39,10 @@ import (
 	"github.com/loomnetwork/loomchain/eth/subs"
 	"github.com/loomnetwork/loomchain/eth/utils"
 	levm "github.com/loomnetwork/loomchain/evm"
+	"github.com/loomnetwork/loomchain/features"
 	"github.com/loomnetwork/loomchain/log"
 	lcp "github.com/loomnetwork/loomchain/plugin"
+	"github.com/loomnetwork/loomchain/plugin/abibridge"
 	hsmpv "github.com/loomnetwork/loomchain/privval/hsm"
 	"github.com/loomnetwork/loomchain/receipts/common"
 	"github.com/loomnetwork/loomchain/registry"
@@ -136,6 +142,16 @@ type QueryServer struct {
 	Web3Cfg           *eth.Web3Config
 	totalStakedAmount *totalStakedAmount
 	DPOSCfg           *config.DPOSConfig
+	// If this is nil eth_gasPrice will always return zero.
+	GasPriceProvider GasPriceProvider
+	// If this is nil eth_syncing, net_listening & net_peerCount will report a standalone node.
+	NodeStatusProvider NodeStatusProvider
+	// Go contracts that can be called via eth_call, if this is nil only EVM contracts can be called.
+	ABIBridge *abibridge.Bridge
+	// If this is nil the pending block will be empty, and pending txs won't be visible to clients.
+	MempoolProvider MempoolProvider
+	// Guards Web3Cfg & GasPriceProvider, which can be replaced while the server is running.
+	reloadMutex sync.RWMutex
 }
 
 type totalStakedAmount struct {
@@ -145,6 +161,27 @@ type totalStakedAmount struct {
 
 var _ QueryService = &QueryServer{}
 
+// SetWeb3Config replaces the Web3 config & gas price provider used by the server, this is used to
+// apply config changes without restarting the node.
+func (s *QueryServer) SetWeb3Config(cfg *eth.Web3Config, gasPriceProvider GasPriceProvider) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+	s.Web3Cfg = cfg
+	s.GasPriceProvider = gasPriceProvider
+}
+
+func (s *QueryServer) web3Config() *eth.Web3Config {
+	s.reloadMutex.RLock()
+	defer s.reloadMutex.RUnlock()
+	return s.Web3Cfg
+}
+
+func (s *QueryServer) gasPriceProvider() GasPriceProvider {
+	s.reloadMutex.RLock()
+	defer s.reloadMutex.RUnlock()
+	return s.GasPriceProvider
+}
+
 // Query returns data of given contract from the application states
 // The contract parameter should be a hex-encoded local address prefixed by 0x
 func (s *QueryServer) Query(caller, contract string, query []byte, vmType vm.VMType) ([]byte, error) {
@@ -261,30 +298,38 @@ func (s *QueryServer) queryEvm(state loomchain.State, caller, contract loom.Addr
 		return nil, errors.Wrap(err, "failed to resolve account address")
 	}
 
-	var createABM levm.AccountBalanceManagerFactoryFunc
-	if s.NewABMFactory != nil {
-		pvm := lcp.NewPluginVM(
-			s.Loader,
-			state,
-			s.CreateRegistry(state),
-			nil,
-			log.Default,
-			s.NewABMFactory,
-			nil,
-			nil,
-		)
-		createABM, err = s.NewABMFactory(pvm)
-		if err != nil {
-			return nil, err
-		}
+	createABM, err := s.createABMFactory(state)
+	if err != nil {
+		return nil, err
 	}
 	vm := levm.NewLoomVm(state, nil, nil, createABM, false)
 	return vm.StaticCall(callerAddr, contract, query)
 }
 
+// Returns nil if the EVM shouldn't have access to account balances.
+func (s *QueryServer) createABMFactory(state loomchain.State) (levm.AccountBalanceManagerFactoryFunc, error) {
+	if s.NewABMFactory == nil {
+		return nil, nil
+	}
+	pvm := lcp.NewPluginVM(
+		s.Loader,
+		state,
+		s.CreateRegistry(state),
+		nil,
+		log.Default,
+		s.NewABMFactory,
+		nil,
+		nil,
+	)
+	return s.NewABMFactory(pvm)
+}
+
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_call
 func (s *QueryServer) EthCall(query eth.JsonTxCallObject, block eth.BlockHeight) (resp eth.Data, err error) {
-	snapshot := s.StateProvider.ReadOnlyState()
+	snapshot, err := s.readOnlyStateAtBlock(block)
+	if err != nil {
+		return resp, err
+	}
 	defer snapshot.Release()
 
 	var caller loom.Address
@@ -305,10 +350,35 @@ func (s *QueryServer) EthCall(query eth.JsonTxCallObject, block eth.BlockHeight)
 	if err != nil {
 		return resp, err
 	}
+	if s.ABIBridge != nil && snapshot.FeatureEnabled(features.EthTxABIBridgeFeature, false) {
+		if s.ABIBridge.ContractName(s.CreateRegistry(snapshot), contract) != "" {
+			bytes, err := s.queryABIBridge(snapshot, caller, contract, data)
+			return eth.EncBytes(bytes), err
+		}
+	}
 	bytes, err := s.queryEvm(snapshot, caller, contract, data)
 	return eth.EncBytes(bytes), err
 }
 
+// queryABIBridge calls a Go contract exposed via the ABI bridge.
+func (s *QueryServer) queryABIBridge(state loomchain.State, caller, contract loom.Address, query []byte) ([]byte, error) {
+	callerAddr, err := auth.ResolveAccountAddress(caller, state, s.AuthCfg, s.createAddressMapperCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve account address")
+	}
+	pvm := lcp.NewPluginVM(
+		s.Loader,
+		state,
+		s.CreateRegistry(state),
+		nil,
+		log.Default,
+		s.NewABMFactory,
+		nil,
+		nil,
+	)
+	return s.ABIBridge.Query(pvm, callerAddr, contract, query)
+}
+
 // GetCode returns the runtime byte-code of a contract running on a DAppChain's EVM.
 // Gives an error for non-EVM contracts.
 // contract - address of the contract in the form of a string. (Use loom.Address.String() to convert)
@@ -334,7 +404,10 @@ func (s *QueryServer) EthGetCode(address eth.Data, block eth.BlockHeight) (eth.D
 		return "", errors.Wrapf(err, "decoding input address parameter %v", address)
 	}
 
-	snapshot := s.StateProvider.ReadOnlyState()
+	snapshot, err := s.readOnlyStateAtBlock(block)
+	if err != nil {
+		return "", err
+	}
 	defer snapshot.Release()
 
 	evm := levm.NewLoomVm(snapshot, nil, nil, nil, false)
@@ -353,6 +426,11 @@ func (s *QueryServer) EthGetCode(address eth.Data, block eth.BlockHeight) (eth.D
 	return eth.EncBytes(code), nil
 }
 
+// CreateChainConfigCtx attempts to construct a read-only context of the ChainConfig contract.
+func (s *QueryServer) CreateChainConfigCtx(state loomchain.State) (contractpb.StaticContext, error) {
+	return s.createStaticContractCtx(state, "chainconfig")
+}
+
 // Attempts to construct the context of the Address Mapper contract.
 func (s *QueryServer) createAddressMapperCtx(state loomchain.State) (contractpb.StaticContext, error) {
 	return s.createStaticContractCtx(state, "addressmapper")
@@ -439,12 +517,12 @@ type WSEmptyResult struct{}
 
 func writer(ctx rpctypes.WSRPCContext, subs *loomchain.SubscriptionSet) pubsub.SubscriberFunc {
 	clientCtx := ctx
-	log.Debug("Adding handler", "remote", clientCtx.GetRemoteAddr())
+	logger.Debug("Adding handler", "remote", clientCtx.GetRemoteAddr())
 	return func(msg pubsub.Message) {
-		log.Debug("Received published message", "msg", msg.Body(), "remote", clientCtx.GetRemoteAddr())
+		logger.Debug("Received published message", "msg", msg.Body(), "remote", clientCtx.GetRemoteAddr())
 		defer func() {
 			if r := recover(); r != nil {
-				log.Error("Caught: WSEvent handler routine panic", "error", r)
+				logger.Error("Caught: WSEvent handler routine panic", "error", r)
 				err := fmt.Errorf("Caught: WSEvent handler routine panic")
 				clientCtx.WriteRPCResponse(rpctypes.RPCInternalError(rpctypes.JSONRPCStringID(""), err))
 				go subs.Purge(clientCtx.GetRemoteAddr())
@@ -478,12 +556,12 @@ func (s *QueryServer) UnSubscribe(wsCtx rpctypes.WSRPCContext, topic string) (*W
 
 func ethWriter(ctx rpctypes.WSRPCContext, subs *subs.LegacyEthSubscriptionSet) pubsub.SubscriberFunc {
 	clientCtx := ctx
-	log.Debug("Adding handler", "remote", clientCtx.GetRemoteAddr())
+	logger.Debug("Adding handler", "remote", clientCtx.GetRemoteAddr())
 	return func(msg pubsub.Message) {
-		log.Debug("Received published message", "msg", msg.Body(), "remote", clientCtx.GetRemoteAddr())
+		logger.Debug("Received published message", "msg", msg.Body(), "remote", clientCtx.GetRemoteAddr())
 		defer func() {
 			if r := recover(); r != nil {
-				log.Error("Caught: WSEvent handler routine panic", "error", r)
+				logger.Error("Caught: WSEvent handler routine panic", "error", r)
 				err := fmt.Errorf("Caught: WSEvent handler routine panic")
 				clientCtx.WriteRPCResponse(rpctypes.RPCInternalError(rpctypes.JSONRPCStringID(""), err))
 				go subs.Purge(clientCtx.GetRemoteAddr())
@@ -564,9 +642,7 @@ func (s *QueryServer) ContractEvents(
 		return nil, fmt.Errorf("toBlock must be equal or greater than")
 	}
 
-	// default to max 20 blocks for now.
-	maxRange := uint64(20)
-
+	maxRange := s.web3Config().GetLogsMaxBlockRange
 	if toBlock-fromBlock > maxRange {
 		return nil, fmt.Errorf("range exceeded, maximum range: %v", maxRange)
 	}
@@ -609,6 +685,37 @@ func (s *QueryServer) GetContractRecord(contractAddrStr string) (*types.Contract
 	return k, nil
 }
 
+// GetContractABI returns the JSON ABI generated for a Go contract exposed via the ABI bridge.
+func (s *QueryServer) GetContractABI(contractName string) (string, error) {
+	if s.ABIBridge == nil {
+		return "", errors.New("ABI bridge is disabled")
+	}
+	return s.ABIBridge.ContractABI(contractName)
+}
+
+// GetContractUpgrades returns the upgrade history of a Go contract, the contract can be specified
+// either by name or by address.
+func (s *QueryServer) GetContractUpgrades(contract string) (*registry.ContractUpgradeHistory, error) {
+	snapshot := s.StateProvider.ReadOnlyState()
+	defer snapshot.Release()
+
+	reg := s.CreateRegistry(snapshot)
+	contractAddr, err := loom.ParseAddress(contract)
+	if err != nil {
+		contractAddr, err = reg.Resolve(contract)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve contract %s", contract)
+		}
+	}
+	upgrades, err := reg.GetUpgrades(contractAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &registry.ContractUpgradeHistory{
+		Upgrades: upgrades,
+	}, nil
+}
+
 type DPOSTotalStakedResponse struct {
 	TotalStaked *gtypes.BigUInt
 }
@@ -695,7 +802,7 @@ func (s *QueryServer) GetEvmLogs(filter string) ([]byte, error) {
 
 	return query.DeprecatedQueryChain(
 		filter, s.BlockStore, snapshot, s.ReceiptHandlerProvider.Reader(), s.EvmAuxStore,
-		s.Web3Cfg.GetLogsMaxBlockRange,
+		s.web3Config().GetLogsMaxBlockRange,
 	)
 }
 
@@ -812,8 +919,12 @@ func (s *QueryServer) EthGetBlockByNumber(block eth.BlockHeight, full bool) (res
 		return nil, err
 	}
 
+	if block == "pending" {
+		return s.getPendingBlock(snapshot, full)
+	}
+
 	// Ethereum nodes seem to return null for a block that doesn't exist yet, so emulate them
-	if block == "pending" || height > uint64(snapshot.Block().Height) {
+	if height > uint64(snapshot.Block().Height) {
 		return nil, nil
 	}
 
@@ -961,12 +1072,55 @@ func (s *QueryServer) EthGetTransactionByHash(hash eth.Data) (resp eth.JsonTxObj
 
 		txObj, err = getTxByTendermintHash(s.BlockStore, txHash, s.EvmAuxStore)
 		if err != nil {
+			// The tx may not have been committed yet
+			if pendingTxObj, found := s.getPendingTx(txHash); found {
+				return pendingTxObj, nil
+			}
 			return resp, errors.Wrapf(err, "failed to find tx with hash %v", txHash)
 		}
 	}
 	return txObj, nil
 }
 
+// getPendingTx looks up a tx in the mempool by its Tendermint tx hash.
+func (s *QueryServer) getPendingTx(txHash []byte) (eth.JsonTxObject, bool) {
+	if s.MempoolProvider == nil {
+		return eth.JsonTxObject{}, false
+	}
+	pendingTxs, err := s.MempoolProvider.PendingTxs()
+	if err != nil {
+		logger.Error("Failed to obtain pending txs", "err", err)
+		return eth.JsonTxObject{}, false
+	}
+	for _, tx := range pendingTxs {
+		if bytes.Equal(tx.Hash(), txHash) {
+			txObj, err := query.GetPendingTxObject(tx)
+			if err != nil {
+				logger.Error("Failed to decode pending tx", "hash", eth.EncBytes(txHash), "err", err)
+				return eth.JsonTxObject{}, false
+			}
+			return txObj, true
+		}
+	}
+	return eth.JsonTxObject{}, false
+}
+
+// getPendingBlock returns the block that will follow the last committed block, containing the txs
+// that are currently in the mempool.
+func (s *QueryServer) getPendingBlock(snapshot loomchain.State, full bool) (*eth.JsonBlockObject, error) {
+	var pendingTxs []ttypes.Tx
+	if s.MempoolProvider != nil {
+		var err error
+		if pendingTxs, err = s.MempoolProvider.PendingTxs(); err != nil {
+			return nil, errors.Wrap(err, "failed to obtain pending txs")
+		}
+	}
+	blockResult := query.GetPendingBlockObject(
+		snapshot.Block().Height+1, snapshot.Block().CurrentHash, full, pendingTxs,
+	)
+	return &blockResult, nil
+}
+
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gettransactionbyblockHashAndIndex
 func (s *QueryServer) EthGetTransactionByBlockHashAndIndex(
 	hash eth.Data, index eth.Quantity,
@@ -1024,7 +1178,7 @@ func (s *QueryServer) EthGetLogs(filter eth.JsonFilter) (resp []eth.JsonLog, err
 	//       block store.
 	logs, err := query.QueryChain(
 		s.BlockStore, snapshot, ethFilter, s.ReceiptHandlerProvider.Reader(), s.EvmAuxStore,
-		s.Web3Cfg.GetLogsMaxBlockRange,
+		s.web3Config().GetLogsMaxBlockRange,
 	)
 	if err != nil {
 		return resp, err
@@ -1083,7 +1237,10 @@ func (s *QueryServer) EthNewFilter(filter eth.JsonFilter) (eth.Quantity, error)
 	return eth.Quantity(id), err
 }
 
-func (s *QueryServer) EthSubscribe(conn *websocket.Conn, method eth.Data, filter eth.JsonFilter) (eth.Data, error) {
+func (s *QueryServer) EthSubscribe(conn *eth.WSConn, method eth.Data, filter eth.JsonFilter) (eth.Data, error) {
+	if conn == nil {
+		return "", errors.New("subscriptions are only supported over websocket connections")
+	}
 	f, err := eth.DecLogFilter(filter)
 	if err != nil {
 		return "", errors.Wrapf(err, "decode filter")
@@ -1112,11 +1269,21 @@ func (s *QueryServer) EthGetTransactionCount(address eth.Data, block eth.BlockHe
 		return eth.ZeroedQuantity, err
 	}
 
-	// Currently loom nodes don't expose pending state to clients, but various web3 libs may call
-	// eth_getTransactionCount with "pending" so to make them work we just return the latest nonce
-	// based on the last committed block.
+	// The pending nonce is the latest nonce based on the last committed block, plus any txs from
+	// the account that are currently queued in the mempool.
 	if block == "pending" {
-		block = "latest"
+		nonce := auth.Nonce(snapshot, resolvedAddr)
+		if s.MempoolProvider == nil {
+			return eth.EncUint(nonce), nil
+		}
+		pendingTxs, err := s.MempoolProvider.PendingTxs()
+		if err != nil {
+			return eth.ZeroedQuantity, errors.Wrap(err, "failed to obtain pending txs")
+		}
+		resolveAccount := func(caller loom.Address) (loom.Address, error) {
+			return auth.ResolveAccountAddress(caller, snapshot, s.AuthCfg, s.createAddressMapperCtx)
+		}
+		return eth.EncUint(query.GetPendingNonce(nonce, resolvedAddr, pendingTxs, resolveAccount)), nil
 	}
 
 	height, err := eth.DecBlockHeight(snapshot.Block().Height, block)
@@ -1139,15 +1306,11 @@ func (s *QueryServer) EthGetBalance(address eth.Data, block eth.BlockHeight) (et
 		return "", errors.Wrapf(err, "decoding input address parameter %v", address)
 	}
 
-	snapshot := s.StateProvider.ReadOnlyState()
-	defer snapshot.Release()
-	height, err := eth.DecBlockHeight(snapshot.Block().Height, block)
+	snapshot, err := s.readOnlyStateAtBlock(block)
 	if err != nil {
-		return "", errors.Wrapf(err, "invalid block height %s", block)
-	}
-	if int64(height) != snapshot.Block().Height {
-		return "", errors.Errorf("height %s not latest", block)
+		return "", err
 	}
+	defer snapshot.Release()
 
 	ctx, err := s.createStaticContractCtx(snapshot, "ethcoin")
 	if err != nil {
@@ -1174,20 +1337,11 @@ func (s *QueryServer) EthGetStorageAt(local eth.Data, position string, block eth
 		return "", errors.Wrapf(err, "failed to decode address parameter %v", local)
 	}
 
-	snapshot := s.StateProvider.ReadOnlyState()
-	defer snapshot.Release()
-
-	if block == "" {
-		block = "latest"
-	}
-
-	height, err := eth.DecBlockHeight(snapshot.Block().Height, block)
+	snapshot, err := s.readOnlyStateAtBlock(block)
 	if err != nil {
-		return "", errors.Wrapf(err, "invalid block height %s", block)
-	}
-	if int64(height) != snapshot.Block().Height {
-		return "", errors.Wrapf(err, "unable to get storage at height %v", block)
+		return "", err
 	}
+	defer snapshot.Release()
 
 	evm := levm.NewLoomVm(snapshot, nil, nil, nil, false)
 	storage, err := evm.GetStorageAt(address, ethcommon.HexToHash(position).Bytes())
@@ -1197,12 +1351,91 @@ func (s *QueryServer) EthGetStorageAt(local eth.Data, position string, block eth
 	return eth.EncBytes(storage), nil
 }
 
+// EthEstimateGas executes the given call against a throwaway copy of the latest state, and returns
+// the amount of gas the call needs to succeed. If the call is reverted, or needs more gas than the
+// specified (or on-chain) gas limit, the error is returned to the client as a JSON-RPC error.
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_estimategas
 func (s *QueryServer) EthEstimateGas(query eth.JsonTxCallObject) (eth.Quantity, error) {
-	return eth.Quantity("0x0"), nil
+	snapshot := s.StateProvider.ReadOnlyState()
+	defer snapshot.Release()
+
+	var caller loom.Address
+	var err error
+	if len(query.From) > 0 {
+		caller, err = s.getEthAccount(snapshot, query.From)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		caller = loom.RootAddress(s.ChainID)
+	}
+
+	var contract *loom.Address
+	if len(query.To) > 0 {
+		addr, err := eth.DecDataToAddress(s.ChainID, query.To)
+		if err != nil {
+			return "", err
+		}
+		contract = &addr
+	}
+
+	var input []byte
+	if len(query.Data) > 2 {
+		input, err = eth.DecDataToBytes(query.Data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var value *loom.BigUInt
+	if len(query.Value) > 0 {
+		v, err := eth.DecQuantityToBigInt(query.Value)
+		if err != nil {
+			return "", err
+		}
+		value = loom.NewBigUInt(v)
+	}
+
+	gasCap := snapshot.Config().GetEvm().GetGasLimit()
+	if len(query.Gas) > 0 {
+		gas, err := eth.DecQuantityToUint(query.Gas)
+		if err != nil {
+			return "", err
+		}
+		if gasCap == 0 || gas < gasCap {
+			gasCap = gas
+		}
+	}
+
+	newState := func() (loomchain.State, levm.AccountBalanceManagerFactoryFunc, error) {
+		state := loomchain.NewThrowawayState(context.Background(), snapshot)
+		createABM, err := s.createABMFactory(state)
+		return state, createABM, err
+	}
+	gas, err := levm.EstimateGas(newState, caller, contract, input, value, gasCap)
+	if err != nil {
+		switch e := errors.Cause(err).(type) {
+		case *levm.ExecutionRevertedError:
+			return "", eth.NewError(eth.EcExecutionReverted, e.Error(), string(eth.EncBytes(e.Data)))
+		case *levm.OutOfGasError:
+			return "", eth.NewError(eth.EcServer, e.Error(), "")
+		}
+		return "", err
+	}
+	return eth.EncUint(gas), nil
 }
 
+// https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gasprice
 func (s *QueryServer) EthGasPrice() (eth.Quantity, error) {
-	return eth.Quantity("0x0"), nil
+	gasPriceProvider := s.gasPriceProvider()
+	if gasPriceProvider == nil {
+		return eth.ZeroedQuantity, nil
+	}
+	price, err := gasPriceProvider.GasPrice()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to determine gas price")
+	}
+	return eth.EncBigInt(*price), nil
 }
 
 func (s *QueryServer) EthNetVersion() (string, error) {
@@ -1216,6 +1449,88 @@ func (s *QueryServer) EthAccounts() ([]eth.Data, error) {
 	return []eth.Data{}, nil
 }
 
+// EthChainId implements https://github.com/ethereum/EIPs/blob/master/EIPS/eip-695.md
+// The chain ID is the same one that's used to sign & verify Ethereum txs sent to this chain.
+func (s *QueryServer) EthChainId() (eth.Quantity, error) {
+	chainID, err := evmcompat.ToEthereumChainID(s.ChainID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to derive Ethereum chain ID")
+	}
+	return eth.EncBigInt(*chainID), nil
+}
+
+// EthSyncing implements https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_syncing
+// Returns false if the node isn't catching up to the rest of the network.
+func (s *QueryServer) EthSyncing() (interface{}, error) {
+	if s.NodeStatusProvider == nil {
+		return false, nil
+	}
+	status, err := s.NodeStatusProvider.SyncStatus()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain node sync status")
+	}
+	if !status.Syncing {
+		return false, nil
+	}
+	return &eth.JsonSyncStatus{
+		StartingBlock: eth.EncInt(status.StartingBlock),
+		CurrentBlock:  eth.EncInt(status.CurrentBlock),
+		HighestBlock:  eth.EncInt(status.HighestBlock),
+	}, nil
+}
+
+// EthProtocolVersion implements https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_protocolversion
+// Loom doesn't implement the Ethereum wire protocol, so this returns the version of the eth
+// protocol the JSON-RPC API is modeled on.
+func (s *QueryServer) EthProtocolVersion() (string, error) {
+	return eth.ProtocolVersion, nil
+}
+
+// NetListening implements https://github.com/ethereum/wiki/wiki/JSON-RPC#net_listening
+func (s *QueryServer) NetListening() (bool, error) {
+	if s.NodeStatusProvider == nil {
+		return false, nil
+	}
+	listening, _, err := s.NodeStatusProvider.NetInfo()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain node net info")
+	}
+	return listening, nil
+}
+
+// NetPeerCount implements https://github.com/ethereum/wiki/wiki/JSON-RPC#net_peercount
+func (s *QueryServer) NetPeerCount() (eth.Quantity, error) {
+	if s.NodeStatusProvider == nil {
+		return eth.ZeroedQuantity, nil
+	}
+	_, numPeers, err := s.NodeStatusProvider.NetInfo()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain node net info")
+	}
+	return eth.EncInt(int64(numPeers)), nil
+}
+
+// Web3ClientVersion implements https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_clientversion
+func (s *QueryServer) Web3ClientVersion() (string, error) {
+	return fmt.Sprintf(
+		"loom/v%s/%s-%s/%s", loomchain.FullVersion(), runtime.GOOS, runtime.GOARCH, runtime.Version(),
+	), nil
+}
+
+// Web3Sha3 implements https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_sha3
+// Returns the Keccak-256 (not the standardized SHA3-256) hash of the given data.
+func (s *QueryServer) Web3Sha3(data eth.Data) (eth.Data, error) {
+	var input []byte
+	if data != eth.NoData {
+		var err error
+		input, err = eth.DecDataToBytes(data)
+		if err != nil {
+			return "", eth.NewErrorf(eth.EcInvalidParams, "Parse params", "invalid data: %v", err)
+		}
+	}
+	return eth.EncBytes(sha3.SoliditySHA3(input)), nil
+}
+
 func (s *QueryServer) getBlockHeightFromHash(hash []byte) (uint64, error) {
 	if nil != s.BlockIndexStore {
 		return s.BlockIndexStore.GetBlockHeightByHash(hash)
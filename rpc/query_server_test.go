@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	llog "github.com/loomnetwork/loomchain/log"
 	"github.com/loomnetwork/loomchain/plugin"
 	registry "github.com/loomnetwork/loomchain/registry/factory"
+	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/store"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -328,6 +330,7 @@ func testQueryServerContractEvents(t *testing.T) {
 		StateProvider: &stateProvider{},
 		BlockStore:    store.NewMockBlockStore(),
 		EventStore:    eventStore,
+		Web3Cfg:       eth.DefaultWeb3Config(),
 	}
 	bus := &QueryEventBus{
 		Subs:    *loomchain.NewSubscriptionSet(),
@@ -467,3 +470,39 @@ func testQueryServerGetContractRecord(t *testing.T) {
 		require.NotNil(t, err)
 	})
 }
+
+func TestUnsafeQueryServiceHandler(t *testing.T) {
+	llog.Setup("debug", "file://-")
+	unsafeLog := llog.Root.With("interface", "unsafe")
+
+	call := func(handler http.Handler, method, params, token string) (int, string) {
+		payload := `{"jsonrpc":"2.0","method":"` + method + `","params":` + params + `,"id":1}`
+		req := httptest.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		body, err := ioutil.ReadAll(rec.Result().Body)
+		require.NoError(t, err)
+		return rec.Result().StatusCode, string(body)
+	}
+	setLogLevelParams := `{"module":"unsafe-rpc-test","level":"info"}`
+
+	// without a token the routes that reconfigure the node aren't registered
+	handler := MakeUnsafeQueryServiceHandler(unsafeLog, nil, "")
+	_, body := call(handler, "unsafe_set_log_level", setLogLevelParams, "")
+	require.Contains(t, body, "Method not found")
+	_, body = call(handler, "unsafe_log_levels", `{}`, "")
+	require.NotContains(t, body, "error")
+
+	// with a token every request must carry it
+	handler = MakeUnsafeQueryServiceHandler(unsafeLog, nil, "secret")
+	status, _ := call(handler, "unsafe_set_log_level", setLogLevelParams, "")
+	require.Equal(t, http.StatusUnauthorized, status)
+	status, _ = call(handler, "unsafe_set_log_level", setLogLevelParams, "wrong")
+	require.Equal(t, http.StatusUnauthorized, status)
+	status, body = call(handler, "unsafe_set_log_level", setLogLevelParams, "secret")
+	require.Equal(t, http.StatusOK, status)
+	require.NotContains(t, body, "error")
+}
@@ -3,7 +3,6 @@ package rpc
 import (
 	"net/http"
 
-	"github.com/gorilla/websocket"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/libs/pubsub"
@@ -16,11 +15,15 @@ import (
 	"github.com/loomnetwork/loomchain"
 	"github.com/loomnetwork/loomchain/config"
 	"github.com/loomnetwork/loomchain/eth/subs"
+	levm "github.com/loomnetwork/loomchain/evm"
 	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/registry"
 	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/loomnetwork/loomchain/vm"
 )
 
+var logger = log.Module("rpc")
+
 // QueryService provides necessary methods for the client to query application states
 type QueryService interface {
 	Query(caller, contract string, query []byte, vmType vm.VMType) ([]byte, error)
@@ -51,7 +54,7 @@ type QueryService interface {
 	EthGetFilterLogs(id eth.Quantity) (interface{}, error)
 
 	EthNewFilter(filter eth.JsonFilter) (eth.Quantity, error)
-	EthSubscribe(conn *websocket.Conn, method eth.Data, filter eth.JsonFilter) (id eth.Data, err error)
+	EthSubscribe(conn *eth.WSConn, method eth.Data, filter eth.JsonFilter) (id eth.Data, err error)
 	EthUnsubscribe(id eth.Quantity) (unsubscribed bool, err error)
 
 	EthGetBalance(address eth.Data, block eth.BlockHeight) (eth.Quantity, error)
@@ -60,9 +63,22 @@ type QueryService interface {
 	EthNetVersion() (string, error)
 	EthGetTransactionCount(local eth.Data, block eth.BlockHeight) (eth.Quantity, error)
 	EthAccounts() ([]eth.Data, error)
+	EthChainId() (eth.Quantity, error)
+	EthSyncing() (interface{}, error)
+	EthProtocolVersion() (string, error)
+	NetListening() (bool, error)
+	NetPeerCount() (eth.Quantity, error)
+	Web3ClientVersion() (string, error)
+	Web3Sha3(data eth.Data) (eth.Data, error)
+
+	DebugTraceTransaction(hash eth.Data, cfg levm.TraceConfig) (interface{}, error)
+	DebugTraceCall(query eth.JsonTxCallObject, block eth.BlockHeight, cfg levm.TraceConfig) (interface{}, error)
+	DebugTraceBlockByNumber(block eth.BlockHeight, cfg levm.TraceConfig) ([]*TxTraceResult, error)
 
 	ContractEvents(fromBlock uint64, toBlock uint64, contract string) (*types.ContractEventsResult, error)
 	GetContractRecord(contractAddr string) (*types.ContractRecordResponse, error)
+	GetContractABI(contract string) (string, error)
+	GetContractUpgrades(contract string) (*registry.ContractUpgradeHistory, error)
 	DPOSTotalStaked() (*DPOSTotalStakedResponse, error)
 	GetCanonicalTxHash(block, txIndex uint64, evmTxHash eth.Data) (eth.Data, error)
 
@@ -98,7 +114,7 @@ func (b *QueryEventBus) Unsubscribe(ctx context.Context, subscriber string, quer
 }
 
 func (b *QueryEventBus) UnsubscribeAll(ctx context.Context, subscriber string) error {
-	log.Debug("Removing WS event subscriber", "address", subscriber)
+	logger.Debug("Removing WS event subscriber", "address", subscriber)
 	b.EthSubs.Purge(subscriber)
 	b.Subs.Purge(subscriber)
 	return nil
@@ -109,34 +125,21 @@ func MakeQueryServiceHandler(svc QueryService, logger log.TMLogger, bus *QueryEv
 	// set up websocket route
 	codec := amino.NewCodec()
 	wsmux := http.NewServeMux()
-	routes := map[string]*rpcserver.RPCFunc{}
-	routes["query"] = rpcserver.NewRPCFunc(svc.Query, "caller,contract,query,vmType")
-	routes["env"] = rpcserver.NewRPCFunc(svc.QueryEnv, "")
-	routes["nonce"] = rpcserver.NewRPCFunc(svc.Nonce, "key,account")
-	routes["subevents"] = rpcserver.NewWSRPCFunc(svc.Subscribe, "topics")
-	routes["unsubevents"] = rpcserver.NewWSRPCFunc(svc.UnSubscribe, "topic")
-	routes["resolve"] = rpcserver.NewRPCFunc(svc.Resolve, "name")
-	routes["evmtxreceipt"] = rpcserver.NewRPCFunc(svc.EvmTxReceipt, "txHash")
-	routes["getevmcode"] = rpcserver.NewRPCFunc(svc.GetEvmCode, "contract")
-	routes["getevmlogs"] = rpcserver.NewRPCFunc(svc.GetEvmLogs, "filter")
-	routes["newevmfilter"] = rpcserver.NewRPCFunc(svc.NewEvmFilter, "filter")
-	routes["newblockevmfilter"] = rpcserver.NewRPCFunc(svc.NewBlockEvmFilter, "")
-	routes["newpendingtransactionevmfilter"] = rpcserver.NewRPCFunc(svc.NewPendingTransactionEvmFilter, "")
-	routes["getevmfilterchanges"] = rpcserver.NewRPCFunc(svc.GetEvmFilterChanges, "id")
-	routes["evmunsubscribe"] = rpcserver.NewRPCFunc(svc.EvmUnSubscribe, "id")
-	routes["uninstallevmfilter"] = rpcserver.NewRPCFunc(svc.UninstallEvmFilter, "id")
-	routes["getblockheight"] = rpcserver.NewRPCFunc(svc.GetBlockHeight, "")
-	routes["getevmblockbynumber"] = rpcserver.NewRPCFunc(svc.GetEvmBlockByNumber, "number,full")
-	routes["getevmblockbyhash"] = rpcserver.NewRPCFunc(svc.GetEvmBlockByHash, "hash,full")
-	routes["getevmtransactionbyhash"] = rpcserver.NewRPCFunc(svc.GetEvmTransactionByHash, "txHash")
-	routes["evmsubscribe"] = rpcserver.NewWSRPCFunc(svc.EvmSubscribe, "method,filter")
-	routes["contractevents"] = rpcserver.NewRPCFunc(svc.ContractEvents, "fromBlock,toBlock,contract")
-	routes["contractrecord"] = rpcserver.NewRPCFunc(svc.GetContractRecord, "contract")
-	routes["dpos_total_staked"] = rpcserver.NewRPCFunc(svc.DPOSTotalStaked, "")
-	routes["canonical_tx_hash"] = rpcserver.NewRPCFunc(svc.GetCanonicalTxHash, "block,txIndex,evmTxHash")
+	routes := makeQueryRoutes(svc, nil)
 	rpcserver.RegisterRPCFuncs(wsmux, routes, codec, logger)
 	wm := rpcserver.NewWebsocketManager(routes, codec, rpcserver.EventSubscriber(bus))
-	wsmux.HandleFunc("/queryws", wm.WebsocketHandler)
+	wsmux.HandleFunc("/queryws", func(w http.ResponseWriter, req *http.Request) {
+		limiter := clientLimiterFromContext(req.Context())
+		if limiter == nil {
+			wm.WebsocketHandler(w, req)
+			return
+		}
+		// The messages received on the connection are dispatched by Tendermint, so the routes
+		// are bound to the client that opened the connection to apply its rate limits.
+		limitedRoutes := makeQueryRoutes(svc, limiter.wrapFunc)
+		rpcserver.NewWebsocketManager(limitedRoutes, codec, rpcserver.EventSubscriber(bus)).
+			WebsocketHandler(w, req)
+	})
 
 	// setup default route
 	mux := http.NewServeMux()
@@ -147,6 +150,9 @@ func MakeQueryServiceHandler(svc QueryService, logger log.TMLogger, bus *QueryEv
 			w.WriteHeader(http.StatusOK)
 			return
 		}
+		if !isWebSocketConnection(req) && !clientLimiterFromContext(req.Context()).checkRequest(w, req) {
+			return
+		}
 		wsmux.ServeHTTP(w, req)
 	})
 
@@ -156,6 +162,53 @@ func MakeQueryServiceHandler(svc QueryService, logger log.TMLogger, bus *QueryEv
 	return mux
 }
 
+// makeQueryRoutes returns the routes served by the /query endpoint, if wrap is specified it's
+// used to wrap the QueryService method called by each route.
+func makeQueryRoutes(
+	svc QueryService, wrap func(method string, f interface{}) interface{},
+) map[string]*rpcserver.RPCFunc {
+	routes := map[string]*rpcserver.RPCFunc{}
+	addRoute := func(method string, f interface{}, args string) {
+		if wrap != nil {
+			f = wrap(method, f)
+		}
+		routes[method] = rpcserver.NewRPCFunc(f, args)
+	}
+	addWSRoute := func(method string, f interface{}, args string) {
+		if wrap != nil {
+			f = wrap(method, f)
+		}
+		routes[method] = rpcserver.NewWSRPCFunc(f, args)
+	}
+	addRoute("query", svc.Query, "caller,contract,query,vmType")
+	addRoute("env", svc.QueryEnv, "")
+	addRoute("nonce", svc.Nonce, "key,account")
+	addWSRoute("subevents", svc.Subscribe, "topics")
+	addWSRoute("unsubevents", svc.UnSubscribe, "topic")
+	addRoute("resolve", svc.Resolve, "name")
+	addRoute("evmtxreceipt", svc.EvmTxReceipt, "txHash")
+	addRoute("getevmcode", svc.GetEvmCode, "contract")
+	addRoute("getevmlogs", svc.GetEvmLogs, "filter")
+	addRoute("newevmfilter", svc.NewEvmFilter, "filter")
+	addRoute("newblockevmfilter", svc.NewBlockEvmFilter, "")
+	addRoute("newpendingtransactionevmfilter", svc.NewPendingTransactionEvmFilter, "")
+	addRoute("getevmfilterchanges", svc.GetEvmFilterChanges, "id")
+	addRoute("evmunsubscribe", svc.EvmUnSubscribe, "id")
+	addRoute("uninstallevmfilter", svc.UninstallEvmFilter, "id")
+	addRoute("getblockheight", svc.GetBlockHeight, "")
+	addRoute("getevmblockbynumber", svc.GetEvmBlockByNumber, "number,full")
+	addRoute("getevmblockbyhash", svc.GetEvmBlockByHash, "hash,full")
+	addRoute("getevmtransactionbyhash", svc.GetEvmTransactionByHash, "txHash")
+	addWSRoute("evmsubscribe", svc.EvmSubscribe, "method,filter")
+	addRoute("contractevents", svc.ContractEvents, "fromBlock,toBlock,contract")
+	addRoute("contractrecord", svc.GetContractRecord, "contract")
+	addRoute("contractabi", svc.GetContractABI, "contract")
+	addRoute("contractupgrades", svc.GetContractUpgrades, "contract")
+	addRoute("dpos_total_staked", svc.DPOSTotalStaked, "")
+	addRoute("canonical_tx_hash", svc.GetCanonicalTxHash, "block,txIndex,evmTxHash")
+	return routes
+}
+
 func createDefaultEthRoutes(svc QueryService, chainID string) map[string]eth.RPCFunc {
 	routes := map[string]eth.RPCFunc{}
 	routes["eth_blockNumber"] = eth.NewRPCFunc(svc.EthBlockNumber, "")
@@ -189,8 +242,19 @@ func createDefaultEthRoutes(svc QueryService, chainID string) map[string]eth.RPC
 	routes["eth_estimateGas"] = eth.NewRPCFunc(svc.EthEstimateGas, "query")
 	routes["eth_gasPrice"] = eth.NewRPCFunc(svc.EthGasPrice, "")
 	routes["net_version"] = eth.NewRPCFunc(svc.EthNetVersion, "")
+	routes["net_listening"] = eth.NewRPCFunc(svc.NetListening, "")
+	routes["net_peerCount"] = eth.NewRPCFunc(svc.NetPeerCount, "")
+	routes["eth_chainId"] = eth.NewRPCFunc(svc.EthChainId, "")
+	routes["eth_syncing"] = eth.NewRPCFunc(svc.EthSyncing, "")
+	routes["eth_protocolVersion"] = eth.NewRPCFunc(svc.EthProtocolVersion, "")
+	routes["web3_clientVersion"] = eth.NewRPCFunc(svc.Web3ClientVersion, "")
+	routes["web3_sha3"] = eth.NewRPCFunc(svc.Web3Sha3, "data")
 	routes["eth_getTransactionCount"] = eth.NewRPCFunc(svc.EthGetTransactionCount, "local,block")
-	routes["eth_sendRawTransaction"] = NewSendRawTransactionRPCFunc(chainID, rpccore.BroadcastTxSync)
+	routes["eth_sendRawTransaction"] = NewSendRawTransactionRPCFunc(chainID, broadcastTxSync)
+
+	routes["debug_traceTransaction"] = eth.NewRPCFunc(svc.DebugTraceTransaction, "hash,cfg")
+	routes["debug_traceCall"] = eth.NewRPCFunc(svc.DebugTraceCall, "query,block,cfg")
+	routes["debug_traceBlockByNumber"] = eth.NewRPCFunc(svc.DebugTraceBlockByNumber, "block,cfg")
 	return routes
 }
 
@@ -207,13 +271,26 @@ func MakeEthQueryServiceHandler(logger log.TMLogger, hub *Hub, routes map[string
 			w.WriteHeader(http.StatusOK)
 			return
 		}
+		// The rate limits are applied to each call as it's dispatched by the JSON-RPC handler.
 		wsmux.ServeHTTP(w, req)
 	})
 	return mux
 }
 
-// MakeUnsafeQueryServiceHandler returns a http handler for unsafe RPC routes
-func MakeUnsafeQueryServiceHandler(logger log.TMLogger) http.Handler {
+// ConfigReloader applies changes made to the node config file to the running node.
+type ConfigReloader interface {
+	// ReloadConfig re-reads & validates the config file, applies any changes that can be applied
+	// to the running node, and returns all the changes that were detected.
+	ReloadConfig() (*config.ConfigChanges, error)
+}
+
+// MakeUnsafeQueryServiceHandler returns a http handler for unsafe RPC routes. If an auth token is
+// specified all requests must carry it, otherwise the routes that change the configuration of the
+// running node (unsafe_set_log_level & unsafe_reload_config) won't be available. If the config
+// reloader is nil the unsafe_reload_config route won't be available either.
+func MakeUnsafeQueryServiceHandler(
+	logger log.TMLogger, configReloader ConfigReloader, authToken string,
+) http.Handler {
 	codec := amino.NewCodec()
 	mux := http.NewServeMux()
 	routes := map[string]*rpcserver.RPCFunc{}
@@ -226,6 +303,41 @@ func MakeUnsafeQueryServiceHandler(logger log.TMLogger) http.Handler {
 	routes["unsafe_stop_cpu_profiler"] = rpcserver.NewRPCFunc(rpccore.UnsafeStopCPUProfiler, "")
 	routes["unsafe_write_heap_profile"] = rpcserver.NewRPCFunc(rpccore.UnsafeWriteHeapProfile, "filename")
 
+	// logging API
+	routes["unsafe_log_levels"] = rpcserver.NewRPCFunc(UnsafeLogLevels, "")
+
+	if authToken == "" {
+		logger.Info("UnsafeRPCAuthToken isn't set, unsafe_set_log_level & unsafe_reload_config are disabled")
+	} else {
+		routes["unsafe_set_log_level"] = rpcserver.NewRPCFunc(UnsafeSetLogLevel, "module,level")
+		if configReloader != nil {
+			routes["unsafe_reload_config"] = rpcserver.NewRPCFunc(configReloader.ReloadConfig, "")
+		}
+	}
+
 	rpcserver.RegisterRPCFuncs(mux, routes, codec, logger)
+	if authToken != "" {
+		return AuthTokenMiddleware(authToken, mux)
+	}
 	return mux
 }
+
+// ResultLogLevels contains the current log level of each module that has its own level.
+type ResultLogLevels struct {
+	Levels []log.ModuleLevel `json:"levels"`
+}
+
+// UnsafeSetLogLevel changes the log level of a module (or of all modules if the module is "*")
+// on the running node, the change is not persisted to the config.
+func UnsafeSetLogLevel(module, level string) (*ResultLogLevels, error) {
+	if err := log.SetModuleLevel(module, level); err != nil {
+		return nil, err
+	}
+	logger.Info("Log level changed", "target", module, "level", level)
+	return &ResultLogLevels{Levels: log.ModuleLevels()}, nil
+}
+
+// UnsafeLogLevels returns the current log levels of the running node.
+func UnsafeLogLevels() (*ResultLogLevels, error) {
+	return &ResultLogLevels{Levels: log.ModuleLevels()}, nil
+}
@@ -0,0 +1,362 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/ulule/limiter"
+	"github.com/ulule/limiter/drivers/store/memory"
+
+	"github.com/loomnetwork/loomchain/config"
+	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+)
+
+const (
+	rejectReasonRateLimit    = "rate_limit"
+	rejectReasonResponseSize = "response_size"
+)
+
+var (
+	rejectedRequestCount metrics.Counter
+)
+
+func init() {
+	rejectedRequestCount = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "loomchain",
+		Subsystem: "query_service",
+		Name:      "rejected_request_count",
+		Help:      "Number of requests rejected due to rate limits or quotas.",
+	}, []string{"method", "reason"})
+}
+
+// RateLimiter enforces the per-method request limits on the clients of the /query and /eth
+// endpoints, and the maximum response size. Clients are identified by their API key if they
+// provide a valid one, and by their IP otherwise.
+type RateLimiter struct {
+	// Guards all the other fields, which are replaced when the config is reloaded.
+	mutex   sync.RWMutex
+	cfg     *config.RPCRateLimitConfig
+	apiKeys map[string]bool
+	// limiters for each method, keyed by method name
+	limiters map[string]*methodLimiters
+}
+
+type methodLimiters struct {
+	ip     *limiter.Limiter
+	apiKey *limiter.Limiter
+}
+
+// NewRateLimiter creates a new rate limiter from the given config. The limiters only keep track of
+// requests in memory, so limits aren't shared between nodes.
+func NewRateLimiter(cfg *config.RPCRateLimitConfig) *RateLimiter {
+	rl := &RateLimiter{
+		cfg:      cfg,
+		apiKeys:  make(map[string]bool),
+		limiters: make(map[string]*methodLimiters),
+	}
+	for _, key := range cfg.APIKeys {
+		rl.apiKeys[key] = true
+	}
+	for _, m := range cfg.Methods {
+		period := time.Duration(m.Period) * time.Second
+		ml := &methodLimiters{}
+		if m.IPLimit > 0 {
+			ml.ip = limiter.New(memory.NewStore(), limiter.Rate{Period: period, Limit: m.IPLimit})
+		}
+		if m.APIKeyLimit > 0 {
+			ml.apiKey = limiter.New(memory.NewStore(), limiter.Rate{Period: period, Limit: m.APIKeyLimit})
+		}
+		rl.limiters[m.Method] = ml
+	}
+	return rl
+}
+
+// Reload replaces the limits with the ones in the given config, any requests made by clients so far
+// won't count towards the new limits.
+func (rl *RateLimiter) Reload(cfg *config.RPCRateLimitConfig) {
+	reloaded := NewRateLimiter(cfg)
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	rl.cfg = reloaded.cfg
+	rl.apiKeys = reloaded.apiKeys
+	rl.limiters = reloaded.limiters
+}
+
+func (rl *RateLimiter) maxResponseSize() int {
+	rl.mutex.RLock()
+	defer rl.mutex.RUnlock()
+	return rl.cfg.MaxResponseSize
+}
+
+// Handler wraps the given handler so that the requests it receives (including websocket
+// connections) can be checked against the rate limits of the client that sent them, and so that
+// responses that exceed the maximum response size are replaced by an error. The rate limits are
+// checked by the RPC servers as they dispatch each call, see clientLimiterFromContext.
+func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodOptions {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		reqLogger := requestLogger(req.Context(), logger)
+		clientKey, isAPIKey := rl.clientKey(req)
+		req = req.WithContext(contextWithClientLimiter(req.Context(), &clientLimiter{
+			rl:       rl,
+			key:      clientKey,
+			isAPIKey: isAPIKey,
+			logger:   reqLogger,
+		}))
+
+		maxResponseSize := rl.maxResponseSize()
+		if maxResponseSize <= 0 || isWebSocketConnection(req) {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			WriteResponse(w, eth.JsonRpcErrorResponse{
+				Version: "2.0",
+				Error:   *eth.NewErrorf(eth.EcInternal, "Http error", "error reading message body %v", err),
+			})
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		lw := &limitedResponseWriter{
+			ResponseWriter: w,
+			maxSize:        maxResponseSize,
+			statusCode:     http.StatusOK,
+		}
+		next.ServeHTTP(lw, req)
+		if lw.exceeded {
+			requestID, methods := parseRequestMethods(req, body)
+			method := strings.Join(methods, ",")
+			rejectedRequestCount.With("method", method, "reason", rejectReasonResponseSize).Add(1)
+			reqLogger.Debug("RPC request rejected", "method", method, "reason", rejectReasonResponseSize)
+			WriteResponse(w, eth.JsonRpcErrorResponse{
+				Version: "2.0",
+				ID:      requestID,
+				Error: *eth.NewErrorf(
+					eth.EcLimitExceeded, "Response size limit exceeded",
+					"response exceeds the maximum size of %d bytes", maxResponseSize,
+				),
+			})
+			return
+		}
+		lw.flush(reqLogger)
+	})
+}
+
+// clientKey returns the key that identifies the client that sent the request, and true if the key
+// is an API key.
+func (rl *RateLimiter) clientKey(req *http.Request) (string, bool) {
+	rl.mutex.RLock()
+	defer rl.mutex.RUnlock()
+
+	if rl.cfg.APIKeyHeader != "" {
+		if apiKey := req.Header.Get(rl.cfg.APIKeyHeader); apiKey != "" && rl.apiKeys[apiKey] {
+			return apiKey, true
+		}
+	}
+	if rl.cfg.TrustForwardedFor {
+		if forwardedFor := req.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			return strings.TrimSpace(strings.Split(forwardedFor, ",")[0]), false
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr, false
+	}
+	return host, false
+}
+
+func (rl *RateLimiter) limitReached(method, clientKey string, isAPIKey bool, logger log.TMLogger) bool {
+	rl.mutex.RLock()
+	ml, ok := rl.limiters[method]
+	if !ok {
+		ml, ok = rl.limiters["*"]
+	}
+	rl.mutex.RUnlock()
+	if !ok {
+		return false
+	}
+	lmt := ml.ip
+	if isAPIKey {
+		lmt = ml.apiKey
+	}
+	if lmt == nil {
+		return false
+	}
+	lmtCtx, err := lmt.Get(context.TODO(), method+":"+clientKey)
+	// Doesn't look like the in-memory store will ever return an error, but just in case don't
+	// reject requests if it does.
+	if err != nil {
+		logger.Error("Failed to check RPC rate limit", "method", method, "err", err)
+		return false
+	}
+	return lmtCtx.Reached
+}
+
+type clientLimiterContextKey struct{}
+
+func contextWithClientLimiter(ctx context.Context, cl *clientLimiter) context.Context {
+	return context.WithValue(ctx, clientLimiterContextKey{}, cl)
+}
+
+// clientLimiterFromContext returns the rate limiter of the client that sent the request the given
+// context belongs to, or nil if the request isn't subject to any rate limits.
+func clientLimiterFromContext(ctx context.Context) *clientLimiter {
+	cl, _ := ctx.Value(clientLimiterContextKey{}).(*clientLimiter)
+	return cl
+}
+
+// clientLimiter applies the rate limits of a single client to the calls made by the client, all the
+// methods of a nil clientLimiter allow every call through.
+type clientLimiter struct {
+	rl       *RateLimiter
+	key      string
+	isAPIKey bool
+	logger   log.TMLogger
+}
+
+// check returns an error if the client has exceeded the rate limit of the given method.
+func (cl *clientLimiter) check(method string, logger log.TMLogger) *eth.Error {
+	if cl == nil || !cl.rl.limitReached(method, cl.key, cl.isAPIKey, logger) {
+		return nil
+	}
+	rejectedRequestCount.With("method", method, "reason", rejectReasonRateLimit).Add(1)
+	logger.Debug("RPC request rejected", "method", method, "reason", rejectReasonRateLimit)
+	return eth.NewErrorf(eth.EcLimitExceeded, "Rate limit exceeded", "too many %s requests", method)
+}
+
+// checkRequest writes an error response and returns false if the client has exceeded the rate
+// limit of any of the methods called by the given request. This is only used for the requests
+// served by the Tendermint RPC server, which doesn't support batch requests.
+func (cl *clientLimiter) checkRequest(w http.ResponseWriter, req *http.Request) bool {
+	if cl == nil {
+		return true
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		WriteResponse(w, eth.JsonRpcErrorResponse{
+			Version: "2.0",
+			Error:   *eth.NewErrorf(eth.EcInternal, "Http error", "error reading message body %v", err),
+		})
+		return false
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	requestID, methods := parseRequestMethods(req, body)
+	for _, method := range methods {
+		if jsonErr := cl.check(method, cl.logger); jsonErr != nil {
+			WriteResponse(w, eth.JsonRpcErrorResponse{
+				Version: "2.0",
+				ID:      requestID,
+				Error:   *jsonErr,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// wrapFunc returns a function with the same signature as the given RPC function, which checks the
+// rate limit of the given method before calling the RPC function. The last value returned by the
+// RPC function must be an error.
+func (cl *clientLimiter) wrapFunc(method string, f interface{}) interface{} {
+	if cl == nil {
+		return f
+	}
+	fv := reflect.ValueOf(f)
+	ft := fv.Type()
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		jsonErr := cl.check(method, cl.logger)
+		if jsonErr == nil {
+			return fv.Call(args)
+		}
+		results := make([]reflect.Value, ft.NumOut())
+		for i := range results {
+			results[i] = reflect.New(ft.Out(i)).Elem()
+		}
+		results[len(results)-1].Set(reflect.ValueOf(jsonErr))
+		return results
+	}).Interface()
+}
+
+// parseRequestMethods extracts the ID & method names from a JSON-RPC request (or batch of requests),
+// or from the URL path of a URI request (e.g. /nonce?key=...).
+func parseRequestMethods(req *http.Request, body []byte) (*json.RawMessage, []string) {
+	if id, methods := parseMessageMethods(body); len(methods) > 0 {
+		return id, methods
+	}
+	if method := strings.Trim(req.URL.Path, "/"); method != "" {
+		return nil, []string{method}
+	}
+	return nil, nil
+}
+
+// parseMessageMethods extracts the ID & method names from a JSON-RPC request (or batch of requests).
+func parseMessageMethods(body []byte) (*json.RawMessage, []string) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+	var batch []eth.JsonRpcRequest
+	if err := json.Unmarshal(body, &batch); err == nil {
+		methods := make([]string, 0, len(batch))
+		for _, r := range batch {
+			methods = append(methods, r.Method)
+		}
+		return nil, methods
+	}
+	var single eth.JsonRpcRequest
+	if err := json.Unmarshal(body, &single); err == nil && single.Method != "" {
+		return single.ID, []string{single.Method}
+	}
+	return nil, nil
+}
+
+// limitedResponseWriter buffers the response, and discards it if it exceeds the maximum size.
+type limitedResponseWriter struct {
+	http.ResponseWriter
+	maxSize    int
+	statusCode int
+	buf        bytes.Buffer
+	exceeded   bool
+}
+
+func (w *limitedResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+func (w *limitedResponseWriter) Write(data []byte) (int, error) {
+	if w.exceeded {
+		return len(data), nil
+	}
+	if w.buf.Len()+len(data) > w.maxSize {
+		w.exceeded = true
+		w.buf.Reset()
+		return len(data), nil
+	}
+	return w.buf.Write(data)
+}
+
+func (w *limitedResponseWriter) flush(logger log.TMLogger) {
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+		logger.Debug("Failed to write RPC response", "err", err)
+	}
+}
@@ -0,0 +1,200 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/loomnetwork/loomchain/config"
+	"github.com/loomnetwork/loomchain/rpc/eth"
+)
+
+func testRateLimitConfig() *config.RPCRateLimitConfig {
+	cfg := config.DefaultRPCRateLimitConfig()
+	cfg.Enabled = true
+	cfg.APIKeys = []string{"key1"}
+	cfg.Methods = []*config.RPCMethodRateLimit{
+		{Method: "*", Period: 60, IPLimit: 3, APIKeyLimit: 0},
+		{Method: "eth_getLogs", Period: 60, IPLimit: 1, APIKeyLimit: 2},
+	}
+	return cfg
+}
+
+func doRateLimitedRequest(
+	t *testing.T, handler http.Handler, remoteAddr, apiKey, body string,
+) *eth.JsonRpcErrorResponse {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp eth.JsonRpcErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	if resp.Error.Code == 0 {
+		return nil
+	}
+	return &resp
+}
+
+// newRateLimitedHandler returns a handler that checks requests against the rate limits the same
+// way the /query endpoint does.
+func newRateLimitedHandler(rl *RateLimiter) http.Handler {
+	return rl.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !clientLimiterFromContext(req.Context()).checkRequest(w, req) {
+			return
+		}
+		if strings.Contains(req.URL.Path, "big") {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + strings.Repeat("a", 64) + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+}
+
+// newRateLimitedEthHandler returns the /eth endpoint handler wrapped by the given rate limiter.
+func newRateLimitedEthHandler(rl *RateLimiter) http.Handler {
+	return rl.Handler(MakeEthQueryServiceHandler(logger, nil, createDefaultEthRoutes(&MockQueryService{}, "default")))
+}
+
+func TestRateLimiterHandler(t *testing.T) {
+	handler := newRateLimitedEthHandler(NewRateLimiter(testRateLimitConfig()))
+	getLogs := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`
+	blockNumber := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`
+
+	// per-IP limits
+	require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", getLogs))
+	resp := doRateLimitedRequest(t, handler, "1.1.1.1:1001", "", getLogs)
+	require.NotNil(t, resp)
+	require.Equal(t, eth.EcLimitExceeded, resp.Error.Code)
+	require.Nil(t, doRateLimitedRequest(t, handler, "2.2.2.2:1000", "", getLogs))
+
+	// methods without their own limits should fall back to the "*" limits
+	for i := 0; i < 3; i++ {
+		require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", blockNumber))
+	}
+	require.NotNil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", blockNumber))
+
+	// per-API-key limits, invalid keys should be subject to the per-IP limits
+	require.NotNil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "key2", getLogs))
+	require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "key1", getLogs))
+	require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "key1", getLogs))
+	require.NotNil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "key1", getLogs))
+	for i := 0; i < 5; i++ {
+		require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "key1", blockNumber))
+	}
+
+	// each call in a batch should count against the limits once, and only the calls that exceed
+	// the limits should be rejected
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("["+blockNumber+","+getLogs+","+getLogs+"]"))
+	req.RemoteAddr = "5.5.5.5:1000"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	var resps []eth.JsonRpcErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resps))
+	require.Len(t, resps, 3)
+	require.Equal(t, eth.ErrorCode(0), resps[0].Error.Code)
+	require.Equal(t, eth.ErrorCode(0), resps[1].Error.Code)
+	require.Equal(t, eth.EcLimitExceeded, resps[2].Error.Code)
+}
+
+func TestRateLimitedQueryHandler(t *testing.T) {
+	cfg := testRateLimitConfig()
+	cfg.MaxResponseSize = 64
+	handler := newRateLimitedHandler(NewRateLimiter(cfg))
+
+	// URI requests are limited by the method in the path
+	req := httptest.NewRequest(http.MethodGet, "/nonce?key=abc", nil)
+	req.RemoteAddr = "3.3.3.3:1000"
+	for i := 0; i < 4; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		var errResp eth.JsonRpcErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+		if i < 3 {
+			require.Equal(t, eth.ErrorCode(0), errResp.Error.Code)
+		} else {
+			require.Equal(t, eth.EcLimitExceeded, errResp.Error.Code)
+		}
+	}
+
+	// max response size
+	req = httptest.NewRequest(http.MethodGet, "/big", nil)
+	req.RemoteAddr = "4.4.4.4:1000"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	var errResp eth.JsonRpcErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+	require.Equal(t, eth.EcLimitExceeded, errResp.Error.Code)
+}
+
+func TestRateLimitedBatch(t *testing.T) {
+	limiter := &clientLimiter{rl: NewRateLimiter(testRateLimitConfig()), key: "1.1.1.1", logger: logger}
+	funcMap := map[string]eth.RPCFunc{
+		"eth_getLogs": eth.NewRPCFunc(func() (eth.Quantity, error) { return "0x1", nil }, ""),
+	}
+	getLogs := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`
+
+	// each call in a batch should count against the limits, and only the calls that exceed the
+	// limits should be rejected
+	out, jsonErr := handleMessage([]byte("["+getLogs+","+getLogs+"]"), funcMap, nil, limiter, logger)
+	require.Nil(t, jsonErr)
+	var resps []eth.JsonRpcErrorResponse
+	require.NoError(t, json.Unmarshal(out, &resps))
+	require.Len(t, resps, 2)
+	require.Equal(t, eth.ErrorCode(0), resps[0].Error.Code)
+	require.Equal(t, eth.EcLimitExceeded, resps[1].Error.Code)
+
+	// calls from clients that aren't rate limited should go through
+	out, jsonErr = handleMessage([]byte("["+getLogs+","+getLogs+"]"), funcMap, nil, nil, logger)
+	require.Nil(t, jsonErr)
+	require.NoError(t, json.Unmarshal(out, &resps))
+	require.Len(t, resps, 2)
+	require.Equal(t, eth.ErrorCode(0), resps[0].Error.Code)
+	require.Equal(t, eth.ErrorCode(0), resps[1].Error.Code)
+}
+
+func TestRateLimitedFunc(t *testing.T) {
+	limiter := &clientLimiter{rl: NewRateLimiter(testRateLimitConfig()), key: "1.1.1.1", logger: logger}
+	var calls int
+	nonce := limiter.wrapFunc("nonce", func(key, account string) (uint64, error) {
+		calls++
+		return 5, nil
+	}).(func(string, string) (uint64, error))
+
+	for i := 0; i < 3; i++ {
+		n, err := nonce("key", "")
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), n)
+	}
+	n, err := nonce("key", "")
+	require.Error(t, err)
+	jsonErr, ok := err.(*eth.Error)
+	require.True(t, ok)
+	require.Equal(t, eth.EcLimitExceeded, jsonErr.Code)
+	require.Equal(t, uint64(0), n)
+	require.Equal(t, 3, calls)
+}
+
+func TestRateLimiterReload(t *testing.T) {
+	rl := NewRateLimiter(testRateLimitConfig())
+	handler := newRateLimitedEthHandler(rl)
+	getLogs := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`
+
+	require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", getLogs))
+	require.NotNil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", getLogs))
+
+	cfg := testRateLimitConfig()
+	cfg.Methods[1].IPLimit = 2
+	rl.Reload(cfg)
+	require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", getLogs))
+	require.Nil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", getLogs))
+	require.NotNil(t, doRateLimitedRequest(t, handler, "1.1.1.1:1000", "", getLogs))
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/loomnetwork/loomchain/log"
+)
+
+// RequestIDHeader is the HTTP header used to correlate RPC requests with the log entries they
+// generate. If a client sets the header on a request the same ID will be used in the logs,
+// otherwise a random ID is generated. The ID is always returned in the response header.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of the given context with the given request ID attached to it.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID attached to the given context, or an empty string if
+// the context doesn't have a request ID attached to it.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// requestLogger returns a logger that tags all log entries with the request ID attached to the
+// given context, or the given logger as is if the context doesn't have a request ID.
+func requestLogger(ctx context.Context, logger log.TMLogger) log.TMLogger {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return logger.With("request_id", requestID)
+	}
+	return logger
+}
+
+// RequestIDHandler wraps the given handler so that every request is assigned an ID, which is
+// attached to the request context, and a debug log entry containing the ID, the RPC method(s)
+// called, and the time taken is written once the request has been handled.
+// Websocket connections are assigned an ID when they're opened, the messages received on the
+// connection are tagged with IDs derived from it by the websocket handler.
+func RequestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodOptions {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		requestID := req.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		req = req.WithContext(ContextWithRequestID(req.Context(), requestID))
+
+		if isWebSocketConnection(req) {
+			logger.Debug("RPC websocket connection opened",
+				"request_id", requestID,
+				"path", req.URL.Path,
+				"remote", req.RemoteAddr,
+			)
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		var methods []string
+		if body, err := ioutil.ReadAll(req.Body); err == nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			_, methods = parseRequestMethods(req, body)
+		}
+
+		start := time.Now()
+		next.ServeHTTP(w, req)
+		logger.Debug("RPC request handled",
+			"request_id", requestID,
+			"method", strings.Join(methods, ","),
+			"path", req.URL.Path,
+			"remote", req.RemoteAddr,
+			"took", time.Since(start),
+		)
+	})
+}
+
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	var ctxRequestID string
+	handler := RequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctxRequestID = RequestIDFromContext(req.Context())
+	}))
+
+	// ID provided by the client
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"method":"eth_blockNumber"}`))
+	req.Header.Set(RequestIDHeader, "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, "abc", ctxRequestID)
+	require.Equal(t, "abc", rec.Header().Get(RequestIDHeader))
+
+	// generated ID
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"method":"eth_blockNumber"}`))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.NotEmpty(t, ctxRequestID)
+	require.Equal(t, ctxRequestID, rec.Header().Get(RequestIDHeader))
+
+	// websocket connections are assigned an ID too
+	ctxRequestID = ""
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Connection", "upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.NotEmpty(t, ctxRequestID)
+}
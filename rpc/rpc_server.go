@@ -1,11 +1,13 @@
 package rpc
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/loomnetwork/loomchain/log"
+	"github.com/loomnetwork/loomchain/rpc/eth"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	amino "github.com/tendermint/go-amino"
@@ -36,14 +38,37 @@ func init() {
 }
 
 // RPCServer starts up HTTP servers that handle client requests.
+// If a tx forwarder is specified txs submitted to the node are forwarded to another node, instead
+// of being added to the local mempool. If a rate limiter is specified it's applied to all requests
+// made to the /query and /eth endpoints. If an auth token is specified requests to the unsafe RPC
+// must carry the token, otherwise the unsafe RPC routes that reconfigure the node are disabled.
 func RPCServer(
 	qsvc QueryService, chainID string, logger log.TMLogger, bus *QueryEventBus, bindAddr string,
-	enableUnsafeRPC bool, unsafeRPCBindAddress string,
+	enableUnsafeRPC bool, unsafeRPCBindAddress string, wsCfg *eth.WebSocketConfig,
+	txForwarder *TxForwarder, rateLimiter *RateLimiter, unsafeRPCAuthToken string,
+	configReloader ConfigReloader,
 ) error {
+	wsCfg = wsCfg.WithDefaults()
 	queryHandler := MakeQueryServiceHandler(qsvc, logger, bus)
-	hub := newHub()
+	hub := newHub(wsCfg)
 	go hub.run()
-	ethHandler := MakeEthQueryServiceHandler(logger, hub, createDefaultEthRoutes(qsvc, chainID))
+	ethRoutes := createDefaultEthRoutes(qsvc, chainID)
+	if txForwarder != nil {
+		txForwarder.overrideRoutes(rpccore.Routes)
+		ethRoutes["eth_sendRawTransaction"] = NewSendRawTransactionRPCFunc(chainID, txForwarder.BroadcastTxSync)
+	} else {
+		// txs queued by the nonce handler must be reported to clients as accepted
+		rpccore.Routes["broadcast_tx_async"] = rpcserver.NewRPCFunc(broadcastTxAsync, "tx")
+		rpccore.Routes["broadcast_tx_sync"] = rpcserver.NewRPCFunc(broadcastTxSync, "tx")
+		rpccore.Routes["broadcast_tx_commit"] = rpcserver.NewRPCFunc(broadcastTxCommit, "tx")
+	}
+	ethHandler := MakeEthQueryServiceHandler(logger, hub, ethRoutes)
+	if rateLimiter != nil {
+		queryHandler = rateLimiter.Handler(queryHandler)
+		ethHandler = rateLimiter.Handler(ethHandler)
+	}
+	queryHandler = RequestIDHandler(queryHandler)
+	ethHandler = RequestIDHandler(ethHandler)
 
 	// Add the nonce route to the TM routes so clients can query the nonce from the /websocket
 	// and /rpc endpoints.
@@ -94,7 +119,7 @@ func RPCServer(
 
 	if enableUnsafeRPC {
 		unsafeLogger := logger.With("interface", "unsafe")
-		unsafeHandler := MakeUnsafeQueryServiceHandler(unsafeLogger)
+		unsafeHandler := MakeUnsafeQueryServiceHandler(unsafeLogger, configReloader, unsafeRPCAuthToken)
 		unsafeListener, err := rpcserver.Listen(
 			unsafeRPCBindAddress,
 			rpcserver.Config{MaxOpenConnections: 0},
@@ -136,6 +161,21 @@ func stripPrefix(prefix string, h http.Handler) http.Handler {
 	})
 }
 
+// AuthTokenMiddleware rejects requests that don't carry the given token in an
+// "Authorization: Bearer <token>" header.
+func AuthTokenMiddleware(token string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodOptions {
+			reqToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) != 1 {
+				http.Error(w, "invalid auth token", http.StatusUnauthorized)
+				return
+			}
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
 func CORSMethodMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 
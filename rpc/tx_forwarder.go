@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"github.com/pkg/errors"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxForwarder forwards txs submitted to a read-only replica node to an upstream node that accepts
+// txs, e.g. one of the validators.
+type TxForwarder struct {
+	client *rpcclient.JSONRPCClient
+}
+
+// NewTxForwarder creates a forwarder that will submit txs to the Tendermint RPC endpoint of the
+// upstream node.
+func NewTxForwarder(upstreamURI string) *TxForwarder {
+	return &TxForwarder{
+		client: rpcclient.NewJSONRPCClient(upstreamURI),
+	}
+}
+
+func (f *TxForwarder) BroadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	var result ctypes.ResultBroadcastTx
+	if err := f.forward("broadcast_tx_async", tx, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (f *TxForwarder) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	var result ctypes.ResultBroadcastTx
+	if err := f.forward("broadcast_tx_sync", tx, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (f *TxForwarder) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	var result ctypes.ResultBroadcastTxCommit
+	if err := f.forward("broadcast_tx_commit", tx, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (f *TxForwarder) forward(method string, tx types.Tx, result interface{}) error {
+	if _, err := f.client.Call(method, map[string]interface{}{"tx": tx}, result); err != nil {
+		return errors.Wrap(err, "failed to forward tx to upstream node")
+	}
+	return nil
+}
+
+// overrideRoutes replaces the Tendermint routes that submit txs to the local mempool with routes
+// that forward txs to the upstream node.
+func (f *TxForwarder) overrideRoutes(routes map[string]*rpcserver.RPCFunc) {
+	routes["broadcast_tx_async"] = rpcserver.NewRPCFunc(f.BroadcastTxAsync, "tx")
+	routes["broadcast_tx_sync"] = rpcserver.NewRPCFunc(f.BroadcastTxSync, "tx")
+	routes["broadcast_tx_commit"] = rpcserver.NewRPCFunc(f.BroadcastTxCommit, "tx")
+}
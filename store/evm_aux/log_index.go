@@ -0,0 +1,185 @@
+package evmaux
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/loomnetwork/go-loom/plugin/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	goutil "github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	logAddressIndexPrefix = []byte("lia")
+	logTopicIndexPrefix   = []byte("lit")
+	logIndexStartKey      = []byte("lis")
+)
+
+// logIndexKey returns the log index key for the given indexed value (contract address or topic0)
+// and tx position. The value is length-prefixed so that scanning the keys of one value never picks
+// up the keys of another value that happens to share a prefix with it.
+func logIndexKey(prefix []byte, value []byte, height uint64, txIndex uint32) []byte {
+	key := logIndexValuePrefix(prefix, value)
+	key = append(key, blockHeightToBytes(height)...)
+	txIndexB := make([]byte, 4)
+	binary.BigEndian.PutUint32(txIndexB, txIndex)
+	return append(key, txIndexB...)
+}
+
+func logIndexValuePrefix(prefix []byte, value []byte) []byte {
+	key := make([]byte, 0, len(prefix)+1+len(value)+12)
+	key = append(key, prefix...)
+	key = append(key, byte(len(value)))
+	return append(key, value...)
+}
+
+// IndexBlockLogs adds the logs in the given receipts to the log index, which maps contract
+// addresses & topic0 values to the positions of the txs that emitted logs matching them.
+// The first block indexed becomes the start of the index, see GetLogIndexStart.
+func (s *EvmAuxStore) IndexBlockLogs(height uint64, receipts []*types.EvmTxReceipt) error {
+	batch := new(leveldb.Batch)
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		txIndex := uint32(receipt.TransactionIndex)
+		for _, eventLog := range receipt.Logs {
+			if eventLog.Address != nil && len(eventLog.Address.Local) > 0 {
+				batch.Put(logIndexKey(logAddressIndexPrefix, eventLog.Address.Local, height, txIndex), []byte{1})
+			}
+			if len(eventLog.Topics) > 0 {
+				batch.Put(logIndexKey(logTopicIndexPrefix, []byte(eventLog.Topics[0]), height, txIndex), []byte{1})
+			}
+		}
+	}
+
+	start, err := s.GetLogIndexStart()
+	if err != nil {
+		return err
+	}
+	if start == 0 {
+		batch.Put(logIndexStartKey, blockHeightToBytes(height))
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+	return errors.Wrap(s.db.Write(batch, nil), "failed to write log index")
+}
+
+// GetLogIndexStart returns the height of the first block covered by the log index, or zero if
+// nothing has been indexed yet. All blocks from the start of the index onwards are indexed.
+func (s *EvmAuxStore) GetLogIndexStart() (uint64, error) {
+	data, err := s.db.Get(logIndexStartKey, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to load log index start")
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// SetLogIndexStart moves the start of the log index, should only be called once all the blocks
+// from the new start height onwards have been indexed.
+func (s *EvmAuxStore) SetLogIndexStart(height uint64) error {
+	return errors.Wrap(
+		s.db.Put(logIndexStartKey, blockHeightToBytes(height), nil),
+		"failed to save log index start",
+	)
+}
+
+// GetIndexedLogHeights uses the log index to look up the heights of the blocks within the given
+// range that contain txs that emitted logs from any of the given contract addresses, with a topic0
+// matching any of the given topics. Either list may be empty, in which case it's not used to filter
+// the blocks, but at least one of them must be non-empty. The heights are returned in ascending
+// order.
+func (s *EvmAuxStore) GetIndexedLogHeights(
+	addresses [][]byte, topics []string, from, to uint64,
+) ([]uint64, error) {
+	if len(addresses) == 0 && len(topics) == 0 {
+		return nil, errors.New("no addresses or topics specified")
+	}
+
+	var matches map[logPosition]bool
+	if len(addresses) > 0 {
+		values := make([][]byte, 0, len(addresses))
+		for _, addr := range addresses {
+			values = append(values, addr)
+		}
+		var err error
+		if matches, err = s.getIndexedPositions(logAddressIndexPrefix, values, from, to); err != nil {
+			return nil, err
+		}
+	}
+	if len(topics) > 0 {
+		values := make([][]byte, 0, len(topics))
+		for _, topic := range topics {
+			values = append(values, []byte(topic))
+		}
+		topicMatches, err := s.getIndexedPositions(logTopicIndexPrefix, values, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			matches = topicMatches
+		} else {
+			for pos := range matches {
+				if !topicMatches[pos] {
+					delete(matches, pos)
+				}
+			}
+		}
+	}
+
+	seen := make(map[uint64]bool, len(matches))
+	heights := make([]uint64, 0, len(matches))
+	for pos := range matches {
+		if !seen[pos.height] {
+			seen[pos.height] = true
+			heights = append(heights, pos.height)
+		}
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
+
+// logPosition identifies a tx by block height & tx index.
+type logPosition struct {
+	height  uint64
+	txIndex uint32
+}
+
+func (s *EvmAuxStore) getIndexedPositions(
+	prefix []byte, values [][]byte, from, to uint64,
+) (map[logPosition]bool, error) {
+	positions := make(map[logPosition]bool)
+	for _, value := range values {
+		valuePrefix := logIndexValuePrefix(prefix, value)
+		start := append(append([]byte{}, valuePrefix...), blockHeightToBytes(from)...)
+		var limit []byte
+		if to == math.MaxUint64 {
+			limit = goutil.BytesPrefix(valuePrefix).Limit
+		} else {
+			limit = append(append([]byte{}, valuePrefix...), blockHeightToBytes(to+1)...)
+		}
+		iter := s.db.NewIterator(&goutil.Range{Start: start, Limit: limit}, nil)
+		for iter.Next() {
+			key := iter.Key()
+			if len(key) != len(valuePrefix)+12 {
+				continue
+			}
+			positions[logPosition{
+				height:  binary.BigEndian.Uint64(key[len(valuePrefix) : len(valuePrefix)+8]),
+				txIndex: binary.BigEndian.Uint32(key[len(valuePrefix)+8:]),
+			}] = true
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return nil, errors.Wrap(err, "failed to read log index")
+		}
+	}
+	return positions, nil
+}
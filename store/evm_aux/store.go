@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	goleveldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	goutil "github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -41,7 +42,17 @@ func blockHeightToBytes(height uint64) []byte {
 }
 
 func LoadStore() (*EvmAuxStore, error) {
-	evmAuxDB, err := goleveldb.OpenFile(EvmAuxDBName, nil)
+	return loadStore(nil)
+}
+
+// LoadReadOnlyStore opens the existing store in read-only mode, any attempt to write to the store
+// will fail.
+func LoadReadOnlyStore() (*EvmAuxStore, error) {
+	return loadStore(&opt.Options{ErrorIfMissing: true, ReadOnly: true})
+}
+
+func loadStore(o *opt.Options) (*EvmAuxStore, error) {
+	evmAuxDB, err := goleveldb.OpenFile(EvmAuxDBName, o)
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -74,3 +75,64 @@ func TestBloomFilterOperation(t *testing.T) {
 	require.Equal(t, true, bytes.Equal(bf, bf1))
 	evmAuxStore.ClearData()
 }
+
+func TestLogIndex(t *testing.T) {
+	evmAuxStore, err := LoadStore()
+	require.NoError(t, err)
+	defer evmAuxStore.ClearData()
+	defer evmAuxStore.Close()
+
+	addr1 := []byte("addr1")
+	addr2 := []byte("addr2")
+	newReceipt := func(txIndex int32, addr []byte, topics ...string) *types.EvmTxReceipt {
+		return &types.EvmTxReceipt{
+			TransactionIndex: txIndex,
+			Logs: []*types.EventData{
+				{Address: &types.Address{Local: addr}, Topics: topics},
+			},
+		}
+	}
+
+	start, err := evmAuxStore.GetLogIndexStart()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), start)
+
+	require.NoError(t, evmAuxStore.IndexBlockLogs(5, nil))
+	require.NoError(t, evmAuxStore.IndexBlockLogs(6, []*types.EvmTxReceipt{
+		newReceipt(0, addr1, "topicA", "topicB"),
+		newReceipt(1, addr2, "topicB"),
+	}))
+	require.NoError(t, evmAuxStore.IndexBlockLogs(8, []*types.EvmTxReceipt{newReceipt(0, addr1, "topicB")}))
+	require.NoError(t, evmAuxStore.IndexBlockLogs(10, []*types.EvmTxReceipt{newReceipt(3, addr2, "topicA")}))
+
+	// the index should start at the first block that was indexed, even if it had no logs
+	start, err = evmAuxStore.GetLogIndexStart()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), start)
+
+	heights, err := evmAuxStore.GetIndexedLogHeights([][]byte{addr1}, nil, 1, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{6, 8}, heights)
+
+	heights, err = evmAuxStore.GetIndexedLogHeights([][]byte{addr1, addr2}, nil, 7, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{8, 10}, heights)
+
+	// only topic0 is indexed
+	heights, err = evmAuxStore.GetIndexedLogHeights(nil, []string{"topicB"}, 1, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{6, 8}, heights)
+
+	heights, err = evmAuxStore.GetIndexedLogHeights([][]byte{addr2}, []string{"topicA"}, 1, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10}, heights)
+
+	heights, err = evmAuxStore.GetIndexedLogHeights([][]byte{[]byte("addr")}, nil, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, heights, 0)
+
+	require.NoError(t, evmAuxStore.SetLogIndexStart(1))
+	start, err = evmAuxStore.GetLogIndexStart()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), start)
+}
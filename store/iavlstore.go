@@ -8,7 +8,6 @@ import (
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/loomnetwork/go-loom/plugin"
 	"github.com/loomnetwork/go-loom/util"
-	"github.com/loomnetwork/loomchain/log"
 	"github.com/pkg/errors"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/tendermint/iavl"
@@ -105,7 +104,7 @@ func (s *IAVLStore) RangeWithLimit(prefix []byte, limit int) plugin.RangeData {
 
 	keys, values, _, err := s.tree.GetRangeWithProof(prefix, prefixRangeEnd(prefix), limit)
 	if err != nil {
-		log.Error("failed to get range", "err", err)
+		logger.Error("failed to get range", "err", err)
 		return ret
 	}
 	for i, k := range keys {
@@ -114,7 +113,7 @@ func (s *IAVLStore) RangeWithLimit(prefix []byte, limit int) plugin.RangeData {
 		if util.HasPrefix(k, prefix) {
 			k, err = util.UnprefixKey(k, prefix)
 			if err != nil {
-				log.Error("failed to unprefix key", "key", k, "prefix", prefix, "err", err)
+				logger.Error("failed to unprefix key", "key", k, "prefix", prefix, "err", err)
 				k = nil
 			}
 
@@ -132,6 +131,11 @@ func (s *IAVLStore) RangeWithLimit(prefix []byte, limit int) plugin.RangeData {
 	return ret
 }
 
+// RangeFrom implements RangeFromReader.
+func (s *IAVLStore) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return rangeTreeFrom(s.tree.ImmutableTree, prefix, start, limit)
+}
+
 func (s *IAVLStore) Hash() []byte {
 	return s.tree.Hash()
 }
@@ -168,7 +172,7 @@ func (s *IAVLStore) SaveVersion() ([]byte, int64, error) {
 	// Every X versions we should persist to disk
 	if flushInterval == 0 || ((oldVersion+1)%flushInterval == 0) {
 		if flushInterval != 0 {
-			log.Info("[IAVLStore] Flushing mem to disk", "version", oldVersion+1)
+			logger.Info("[IAVLStore] Flushing mem to disk", "version", oldVersion+1)
 			hash, version, err = s.tree.FlushMemVersionDisk()
 		} else {
 			hash, version, err = s.tree.SaveVersion()
@@ -248,6 +252,27 @@ func NewIAVLStore(db dbm.DB, maxVersions, targetVersion, flushInterval int64) (*
 	}, nil
 }
 
+// GetSnapshotAt returns a read-only snapshot of a previously saved version of the store.
+// Returns ErrStatePruned if the version has been deleted from the store.
+func (s *IAVLStore) GetSnapshotAt(version int64) (Snapshot, error) {
+	tree, err := s.getImmutableTree(version)
+	if err != nil {
+		return nil, err
+	}
+	return &iavlImmutableTreeSnapshot{tree: tree}, nil
+}
+
+func (s *IAVLStore) getImmutableTree(version int64) (*iavl.ImmutableTree, error) {
+	if version > 0 && version < s.tree.Version() && !s.tree.VersionExists(version) {
+		return nil, ErrStatePruned
+	}
+	tree, err := s.tree.GetImmutable(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load immutable tree for version %v", version)
+	}
+	return tree, nil
+}
+
 type iavlStoreSnapshot struct {
 	*IAVLStore
 }
@@ -255,3 +280,51 @@ type iavlStoreSnapshot struct {
 func (s *iavlStoreSnapshot) Release() {
 	// noop
 }
+
+// iavlImmutableTreeSnapshot is a read-only view of a single version of an IAVL tree.
+type iavlImmutableTreeSnapshot struct {
+	tree *iavl.ImmutableTree
+}
+
+func (s *iavlImmutableTreeSnapshot) Has(key []byte) bool {
+	return s.tree.Has(key)
+}
+
+func (s *iavlImmutableTreeSnapshot) Get(key []byte) []byte {
+	_, val := s.tree.Get(key)
+	return val
+}
+
+func (s *iavlImmutableTreeSnapshot) Range(prefix []byte) plugin.RangeData {
+	ret := make(plugin.RangeData, 0)
+
+	keys, values, _, err := s.tree.GetRangeWithProof(prefix, prefixRangeEnd(prefix), 0)
+	if err != nil {
+		logger.Error("failed to get range", "err", err)
+		return ret
+	}
+	for i, k := range keys {
+		if !util.HasPrefix(k, prefix) {
+			continue // Skip this key as it does not have the prefix
+		}
+		k, err = util.UnprefixKey(k, prefix)
+		if err != nil {
+			logger.Error("failed to unprefix key", "key", k, "prefix", prefix, "err", err)
+			k = nil
+		}
+		ret = append(ret, &plugin.RangeEntry{
+			Key:   k,
+			Value: values[i],
+		})
+	}
+	return ret
+}
+
+// RangeFrom implements RangeFromReader.
+func (s *iavlImmutableTreeSnapshot) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return rangeTreeFrom(s.tree, prefix, start, limit)
+}
+
+func (s *iavlImmutableTreeSnapshot) Release() {
+	s.tree = nil
+}
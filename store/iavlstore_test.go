@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/loomnetwork/go-loom/util"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/iavl"
 	"github.com/tendermint/tendermint/libs/db"
@@ -101,6 +102,43 @@ func testOrphans(t *testing.T, store *IAVLStore, diskDb db.DB, flushInterval int
 	require.Equal(t, 0, bytes.Compare([]byte(""), k2Value))
 }
 
+func TestIAVLStoreGetSnapshotAt(t *testing.T) {
+	store, err := NewIAVLStore(db.NewMemDB(), 0, 0, 0)
+	require.NoError(t, err)
+
+	prefix := []byte("pre")
+	store.Set(util.PrefixKey(prefix, []byte("k1")), []byte("Fred"))
+	_, _, err = store.SaveVersion()
+	require.NoError(t, err)
+	store.Set(util.PrefixKey(prefix, []byte("k1")), []byte("Mary"))
+	store.Set(util.PrefixKey(prefix, []byte("k2")), []byte("John"))
+	_, _, err = store.SaveVersion()
+	require.NoError(t, err)
+
+	snap, err := store.GetSnapshotAt(1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("Fred"), snap.Get(util.PrefixKey(prefix, []byte("k1"))))
+	require.False(t, snap.Has(util.PrefixKey(prefix, []byte("k2"))))
+	require.Len(t, snap.Range(prefix), 1)
+	snap.Release()
+
+	snap, err = store.GetSnapshotAt(2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("Mary"), snap.Get(util.PrefixKey(prefix, []byte("k1"))))
+	require.Len(t, snap.Range(prefix), 2)
+	snap.Release()
+
+	_, err = store.GetSnapshotAt(3)
+	require.Error(t, err)
+
+	store.maxVersions = 2
+	_, _, err = store.SaveVersion()
+	require.NoError(t, err)
+	require.NoError(t, store.Prune())
+	_, err = store.GetSnapshotAt(1)
+	require.Equal(t, ErrStatePruned, err)
+}
+
 func TestIavl(t *testing.T) {
 	numBlocks = 20
 	blockSize = 5
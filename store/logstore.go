@@ -129,3 +129,10 @@ func (s *LogStore) Prune() error {
 func (s *LogStore) GetSnapshot() Snapshot {
 	return s.store.GetSnapshot()
 }
+
+func (s *LogStore) GetSnapshotAt(version int64) (Snapshot, error) {
+	if hs, ok := s.store.(HistoricalKVStore); ok {
+		return hs.GetSnapshotAt(version)
+	}
+	return nil, ErrHistoricalSnapshotsNotSupported
+}
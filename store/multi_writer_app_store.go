@@ -13,7 +13,6 @@ import (
 	"github.com/loomnetwork/go-loom/util"
 	"github.com/loomnetwork/loomchain/db"
 	"github.com/loomnetwork/loomchain/features"
-	"github.com/loomnetwork/loomchain/log"
 	"github.com/pkg/errors"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/tendermint/iavl"
@@ -174,6 +173,18 @@ func (s *MultiWriterAppStore) Range(prefix []byte) plugin.RangeData {
 	return s.appStore.Range(prefix)
 }
 
+// RangeFrom implements RangeFromReader.
+func (s *MultiWriterAppStore) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	if len(prefix) == 0 {
+		panic(errors.New("Range over nil prefix not implemented"))
+	}
+
+	if bytes.Equal(prefix, vmPrefix) || util.HasPrefix(prefix, vmPrefix) {
+		return RangeFrom(s.evmStore, prefix, start, limit)
+	}
+	return s.appStore.RangeFrom(prefix, start, limit)
+}
+
 func (s *MultiWriterAppStore) Hash() []byte {
 	return s.appStore.Hash()
 }
@@ -270,6 +281,17 @@ func (s *MultiWriterAppStore) GetSnapshot() Snapshot {
 	return newMultiWriterStoreSnapshot(evmDbSnapshot, appStoreTree)
 }
 
+// GetSnapshotAt returns a read-only snapshot of a previously saved version of the store.
+// Returns ErrStatePruned if the version has been deleted from the store.
+func (s *MultiWriterAppStore) GetSnapshotAt(version int64) (Snapshot, error) {
+	appStoreTree, err := s.appStore.getImmutableTree(version)
+	if err != nil {
+		return nil, err
+	}
+	evmDbSnapshot := s.evmStore.GetSnapshot(version)
+	return newMultiWriterStoreSnapshot(evmDbSnapshot, appStoreTree), nil
+}
+
 type multiWriterStoreSnapshot struct {
 	evmDbSnapshot db.Snapshot
 	appStoreTree  *iavl.ImmutableTree
@@ -335,7 +357,7 @@ func (s *multiWriterStoreSnapshot) Range(prefix []byte) plugin.RangeData {
 	// Otherwise iterate over the IAVL tree
 	keys, values, _, err := s.appStoreTree.GetRangeWithProof(prefix, prefixRangeEnd(prefix), 0)
 	if err != nil {
-		log.Error("failed to get range", "prefix", string(prefix), "err", err)
+		logger.Error("failed to get range", "prefix", string(prefix), "err", err)
 		return ret
 	}
 
@@ -359,3 +381,15 @@ func (s *multiWriterStoreSnapshot) Range(prefix []byte) plugin.RangeData {
 
 	return ret
 }
+
+// RangeFrom implements RangeFromReader.
+func (s *multiWriterStoreSnapshot) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	if len(prefix) == 0 {
+		panic(errors.New("Range over nil prefix not implemented"))
+	}
+
+	if bytes.Equal(prefix, vmPrefix) || util.HasPrefix(prefix, vmPrefix) {
+		return sortedRangeFrom(s.Range(prefix), start, limit)
+	}
+	return rangeTreeFrom(s.appStoreTree, prefix, start, limit)
+}
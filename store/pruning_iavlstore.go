@@ -184,6 +184,13 @@ func (s *PruningIAVLStore) GetSnapshot() Snapshot {
 	}
 }
 
+func (s *PruningIAVLStore) GetSnapshotAt(version int64) (Snapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.store.GetSnapshotAt(version)
+}
+
 func (s *PruningIAVLStore) prune() error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/loomnetwork/go-loom/plugin"
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/tendermint/iavl"
+)
+
+// RangeFromReader is implemented by stores that can read a limited number of the keys that follow
+// a given key without loading all the keys that share the same prefix.
+type RangeFromReader interface {
+	// RangeFrom returns at most limit keys & values that are prefixed by the given prefix (with
+	// a zero byte separator between the prefix and the key), and that come after the start key, in
+	// ascending key order. If the start key is empty the range begins at the first key with the
+	// prefix, if the limit is zero all the matching keys are returned.
+	RangeFrom(prefix, start []byte, limit int) plugin.RangeData
+}
+
+// RangeFrom returns at most limit keys & values from the given reader that are prefixed by the
+// given prefix, and that come after the start key, see RangeFromReader.RangeFrom for details.
+// If the reader doesn't implement RangeFromReader all the keys with the prefix are loaded and
+// sorted to find the ones that follow the start key.
+func RangeFrom(reader KVReader, prefix, start []byte, limit int) plugin.RangeData {
+	if r, ok := reader.(RangeFromReader); ok {
+		return r.RangeFrom(prefix, start, limit)
+	}
+	return sortedRangeFrom(reader.Range(prefix), start, limit)
+}
+
+// sortedRangeFrom sorts the given entries by key, and returns at most limit of the entries that
+// come after the start key.
+func sortedRangeFrom(entries plugin.RangeData, start []byte, limit int) plugin.RangeData {
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Key, entries[j].Key) < 0
+	})
+	first := 0
+	if len(start) > 0 {
+		first = sort.Search(len(entries), func(i int) bool {
+			return bytes.Compare(entries[i].Key, start) > 0
+		})
+	}
+	entries = entries[first:]
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// rangeTreeFrom iterates over the keys in the given tree that follow the start key (prefixed by
+// the given prefix), and stops as soon as it has found the requested number of keys.
+func rangeTreeFrom(tree *iavl.ImmutableTree, prefix, start []byte, limit int) plugin.RangeData {
+	ret := make(plugin.RangeData, 0)
+
+	from := prefix
+	if len(start) > 0 {
+		// The zero byte makes the range exclusive of the start key itself.
+		from = append(util.PrefixKey(prefix, start), 0)
+	}
+	tree.IterateRange(from, prefixRangeEnd(prefix), true, func(key, value []byte) bool {
+		// The tree range includes all the keys that have the prefix, but it doesn't check for the zero
+		// byte after the prefix.
+		if !util.HasPrefix(key, prefix) {
+			return false
+		}
+		k, err := util.UnprefixKey(key, prefix)
+		if err != nil {
+			logger.Error("failed to unprefix key", "key", key, "prefix", prefix, "err", err)
+			return false
+		}
+		ret = append(ret, &plugin.RangeEntry{
+			Key:   k,
+			Value: value,
+		})
+		return limit > 0 && len(ret) >= limit
+	})
+	return ret
+}
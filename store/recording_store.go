@@ -0,0 +1,39 @@
+package store
+
+// KVWrite is a single write made to a store, Deleted is set if the key was deleted.
+type KVWrite struct {
+	Key     []byte
+	Value   []byte
+	Deleted bool
+}
+
+// RecordingStore wraps a VersionedKVStore and records all the writes made to it, it's used to
+// inspect the state changes made by individual txs when blocks are replayed.
+type RecordingStore struct {
+	VersionedKVStore
+	writes []KVWrite
+}
+
+func NewRecordingStore(store VersionedKVStore) *RecordingStore {
+	return &RecordingStore{
+		VersionedKVStore: store,
+	}
+}
+
+func (s *RecordingStore) Set(key, value []byte) {
+	s.writes = append(s.writes, KVWrite{Key: key, Value: value})
+	s.VersionedKVStore.Set(key, value)
+}
+
+func (s *RecordingStore) Delete(key []byte) {
+	s.writes = append(s.writes, KVWrite{Key: key, Deleted: true})
+	s.VersionedKVStore.Delete(key)
+}
+
+// TakeWrites returns the writes recorded since the last call to TakeWrites, in the order they
+// were made.
+func (s *RecordingStore) TakeWrites() []KVWrite {
+	writes := s.writes
+	s.writes = nil
+	return writes
+}
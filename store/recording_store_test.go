@@ -0,0 +1,30 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/db"
+)
+
+func TestRecordingStore(t *testing.T) {
+	iavlStore, err := NewIAVLStore(db.NewMemDB(), 0, 0, 0)
+	require.NoError(t, err)
+	s := NewRecordingStore(iavlStore)
+
+	tx := WrapAtomic(s).BeginTx()
+	tx.Set([]byte("k1"), []byte("v1"))
+	tx.Set([]byte("k2"), []byte("v2"))
+	tx.Delete([]byte("k1"))
+	require.Empty(t, s.TakeWrites())
+	tx.Commit()
+
+	require.Equal(t, []KVWrite{
+		{Key: []byte("k1"), Value: []byte("v1")},
+		{Key: []byte("k2"), Value: []byte("v2")},
+		{Key: []byte("k1"), Deleted: true},
+	}, s.TakeWrites())
+	require.Empty(t, s.TakeWrites())
+	require.Equal(t, []byte("v2"), iavlStore.Get([]byte("k2")))
+	require.False(t, iavlStore.Has([]byte("k1")))
+}
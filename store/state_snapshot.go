@@ -0,0 +1,455 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/loomnetwork/go-loom/util"
+	"github.com/pkg/errors"
+	amino "github.com/tendermint/go-amino"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/loomnetwork/loomchain/db"
+)
+
+const (
+	// StateSnapshotFormat is the version of the state snapshot format written by StateSnapshotWriter.
+	StateSnapshotFormat = 1
+	// StateSnapshotManifestFile is the name of the file the snapshot manifest is written to.
+	StateSnapshotManifestFile = "manifest.json"
+	// StateSnapshotTendermintFile is the name of the file the Tendermint block & state at the
+	// snapshot height are written to.
+	StateSnapshotTendermintFile = "tendermint"
+	// DefaultStateSnapshotChunkSize is the default max size of each snapshot chunk (in bytes).
+	DefaultStateSnapshotChunkSize = 16 * 1024 * 1024
+)
+
+// SnapshotDB identifies the DB a snapshot record should be written to.
+type SnapshotDB byte
+
+const (
+	SnapshotAppDB SnapshotDB = 1
+	SnapshotEvmDB SnapshotDB = 2
+)
+
+// StateSnapshotManifest describes the contents of a state snapshot.
+type StateSnapshotManifest struct {
+	Format int
+	Height int64
+	// Hash of the app store at Height, this should match the app hash in the header of the block at
+	// Height + 1.
+	AppHash cmn.HexBytes
+	// Root of the EVM state at Height, only set if the snapshot includes the contents of evm.db.
+	EvmRoot cmn.HexBytes `json:",omitempty"`
+	Chunks  []StateSnapshotChunk
+	// Tendermint block & state at Height, these are needed to start a node from the snapshot.
+	Tendermint *StateSnapshotChunk `json:",omitempty"`
+}
+
+// StateSnapshotChunk is a single file in a snapshot.
+type StateSnapshotChunk struct {
+	File string
+	Size int64
+	// Hex encoded SHA-256 hash of the chunk
+	Hash string
+}
+
+// StateSnapshotWriter writes snapshot records to a sequence of chunk files, each chunk is hashed so
+// that its integrity can be verified before it's imported.
+type StateSnapshotWriter struct {
+	dir        string
+	chunkSize  int
+	buf        bytes.Buffer
+	chunks     []StateSnapshotChunk
+	tendermint *StateSnapshotChunk
+}
+
+// NewStateSnapshotWriter creates a writer that will write the snapshot to the given directory,
+// which must not already contain a snapshot.
+func NewStateSnapshotWriter(dir string, chunkSize int) (*StateSnapshotWriter, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultStateSnapshotChunkSize
+	}
+	if _, err := os.Stat(filepath.Join(dir, StateSnapshotManifestFile)); err == nil {
+		return nil, errors.Errorf("%s already contains a snapshot", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot dir %s", dir)
+	}
+	return &StateSnapshotWriter{dir: dir, chunkSize: chunkSize}, nil
+}
+
+// Write adds a single key/value record to the snapshot.
+func (w *StateSnapshotWriter) Write(target SnapshotDB, key, value []byte) error {
+	var lenBuf [binary.MaxVarintLen64]byte
+	w.buf.WriteByte(byte(target))
+	n := binary.PutUvarint(lenBuf[:], uint64(len(key)))
+	w.buf.Write(lenBuf[:n])
+	w.buf.Write(key)
+	n = binary.PutUvarint(lenBuf[:], uint64(len(value)))
+	w.buf.Write(lenBuf[:n])
+	w.buf.Write(value)
+
+	if w.buf.Len() >= w.chunkSize {
+		return w.flushChunk()
+	}
+	return nil
+}
+
+// WriteTendermintState writes the (serialized) Tendermint block & state at the snapshot height to
+// the snapshot.
+func (w *StateSnapshotWriter) WriteTendermintState(data []byte) error {
+	chunk, err := writeSnapshotFile(w.dir, StateSnapshotTendermintFile, data)
+	if err != nil {
+		return err
+	}
+	w.tendermint = chunk
+	return nil
+}
+
+// Finish writes out any buffered records, and the snapshot manifest.
+func (w *StateSnapshotWriter) Finish(height int64, appHash, evmRoot []byte) (*StateSnapshotManifest, error) {
+	if w.buf.Len() > 0 {
+		if err := w.flushChunk(); err != nil {
+			return nil, err
+		}
+	}
+	manifest := &StateSnapshotManifest{
+		Format:     StateSnapshotFormat,
+		Height:     height,
+		AppHash:    appHash,
+		EvmRoot:    evmRoot,
+		Chunks:     w.chunks,
+		Tendermint: w.tendermint,
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(w.dir, StateSnapshotManifestFile), data, 0644); err != nil {
+		return nil, errors.Wrap(err, "failed to write snapshot manifest")
+	}
+	return manifest, nil
+}
+
+func (w *StateSnapshotWriter) flushChunk() error {
+	chunk, err := writeSnapshotFile(w.dir, fmt.Sprintf("chunk-%06d", len(w.chunks)+1), w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	w.chunks = append(w.chunks, *chunk)
+	w.buf.Reset()
+	return nil
+}
+
+func writeSnapshotFile(dir, name string, data []byte) (*StateSnapshotChunk, error) {
+	hash := sha256.Sum256(data)
+	chunk := &StateSnapshotChunk{
+		File: name,
+		Size: int64(len(data)),
+		Hash: hex.EncodeToString(hash[:]),
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, chunk.File), data, 0644); err != nil {
+		return nil, errors.Wrapf(err, "failed to write snapshot chunk %s", chunk.File)
+	}
+	return chunk, nil
+}
+
+// readSnapshotFile loads a file from the snapshot, and verifies its hash matches the one in the
+// manifest.
+func readSnapshotFile(dir string, chunk *StateSnapshotChunk) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snapshot chunk %s", chunk.File)
+	}
+	hash := sha256.Sum256(data)
+	if hex.EncodeToString(hash[:]) != chunk.Hash {
+		return nil, errors.Errorf("hash mismatch in snapshot chunk %s", chunk.File)
+	}
+	return data, nil
+}
+
+// ReadStateSnapshotManifest loads the manifest of the snapshot in the given directory.
+func ReadStateSnapshotManifest(dir string) (*StateSnapshotManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, StateSnapshotManifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot manifest")
+	}
+	var manifest StateSnapshotManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse snapshot manifest")
+	}
+	if manifest.Format != StateSnapshotFormat {
+		return nil, errors.Errorf("unsupported snapshot format %d", manifest.Format)
+	}
+	return &manifest, nil
+}
+
+// ReadStateSnapshot verifies the hash of each chunk in the snapshot, and then passes every record
+// in the chunk to the given function.
+func ReadStateSnapshot(
+	dir string, manifest *StateSnapshotManifest, fn func(target SnapshotDB, key, value []byte) error,
+) error {
+	for i := range manifest.Chunks {
+		chunk := &manifest.Chunks[i]
+		data, err := readSnapshotFile(dir, chunk)
+		if err != nil {
+			return err
+		}
+		r := bufio.NewReader(bytes.NewReader(data))
+		for {
+			target, err := r.ReadByte()
+			if err == io.EOF {
+				break
+			}
+			key, err := readSnapshotBytes(r)
+			if err != nil {
+				return errors.Wrapf(err, "malformed record in snapshot chunk %s", chunk.File)
+			}
+			value, err := readSnapshotBytes(r)
+			if err != nil {
+				return errors.Wrapf(err, "malformed record in snapshot chunk %s", chunk.File)
+			}
+			if err := fn(SnapshotDB(target), key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ReadStateSnapshotTendermintState loads the (serialized) Tendermint block & state from the snapshot,
+// and verifies its hash matches the one in the manifest.
+func ReadStateSnapshotTendermintState(dir string, manifest *StateSnapshotManifest) ([]byte, error) {
+	if manifest.Tendermint == nil {
+		return nil, errors.New("snapshot doesn't contain the Tendermint state")
+	}
+	return readSnapshotFile(dir, manifest.Tendermint)
+}
+
+func readSnapshotBytes(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// The IAVL node DB stores each node under 'n' + node hash, and the root node hash of each version
+// under 'r' + big-endian version.
+func iavlNodeKey(hash []byte) []byte {
+	return append([]byte{'n'}, hash...)
+}
+
+func iavlRootKey(version int64) []byte {
+	key := make([]byte, 9)
+	key[0] = 'r'
+	binary.BigEndian.PutUint64(key[1:], uint64(version))
+	return key
+}
+
+// iavlNodeChildren decodes a serialized IAVL node and returns the hashes of its children, leaf
+// nodes have no children. The node is encoded as: height, size, version, key, and then either the
+// value (for leaf nodes), or the left & right child hashes (for inner nodes).
+func iavlNodeChildren(buf []byte) ([][]byte, error) {
+	height, n, err := amino.DecodeInt8(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode node height")
+	}
+	buf = buf[n:]
+	if height == 0 {
+		return nil, nil
+	}
+	for _, field := range []string{"size", "version"} {
+		_, n, err = amino.DecodeVarint(buf)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode node %s", field)
+		}
+		buf = buf[n:]
+	}
+	_, n, err = amino.DecodeByteSlice(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode node key")
+	}
+	buf = buf[n:]
+	leftHash, n, err := amino.DecodeByteSlice(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode left node hash")
+	}
+	buf = buf[n:]
+	rightHash, _, err := amino.DecodeByteSlice(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode right node hash")
+	}
+	return [][]byte{leftHash, rightHash}, nil
+}
+
+// ExportIAVLStoreSnapshot writes all the nodes of the IAVL tree stored in the given DB at the given
+// version to the snapshot. Returns the root hash of the tree, which is the app hash at that version.
+// Nodes are copied as is, so the tree rebuilt from the snapshot has exactly the same hash.
+func ExportIAVLStoreSnapshot(appDB dbm.DB, version int64, w *StateSnapshotWriter) ([]byte, error) {
+	rootKey := iavlRootKey(version)
+	if !appDB.Has(rootKey) {
+		return nil, errors.Errorf(
+			"version %d not found in app store, it may have been pruned or not flushed to disk", version,
+		)
+	}
+	rootHash := appDB.Get(rootKey)
+	if err := w.Write(SnapshotAppDB, rootKey, rootHash); err != nil {
+		return nil, err
+	}
+
+	pending := [][]byte{}
+	if len(rootHash) > 0 {
+		pending = append(pending, rootHash)
+	}
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		key := iavlNodeKey(hash)
+		node := appDB.Get(key)
+		if node == nil {
+			return nil, errors.Errorf("IAVL node %X not found", hash)
+		}
+		if err := w.Write(SnapshotAppDB, key, node); err != nil {
+			return nil, err
+		}
+		children, err := iavlNodeChildren(node)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode IAVL node %X", hash)
+		}
+		pending = append(pending, children...)
+	}
+	return rootHash, nil
+}
+
+// ExportEvmStoreRoot returns the EVM state root the EvmStore in the given DB had at the given version.
+func ExportEvmStoreRoot(evmDB db.DBWrapper, version int64) ([]byte, error) {
+	evmStore := NewEvmStore(evmDB, 1)
+	root, rootVersion := evmStore.getLastSavedRoot(version)
+	if root == nil {
+		return nil, errors.Errorf("EVM root for version %d not found", version)
+	}
+	if rootVersion > version {
+		return nil, errors.Errorf("EVM root for version %d not found", version)
+	}
+	return root, nil
+}
+
+// EvmStoreKey returns the key under which the EvmStore stores a node of the EVM state trie
+// (or contract code) in evm.db.
+func EvmStoreKey(hash []byte) []byte {
+	return util.PrefixKey(vmPrefix, hash)
+}
+
+// ImportStateSnapshot rebuilds the app store (and the EVM store if the snapshot contains EVM state)
+// from the snapshot in the given directory, and verifies the rebuilt stores match the hashes in the
+// snapshot manifest. The given DBs must be empty. If appHash is not nil the app hash of the rebuilt
+// store must also match it, this should be an app hash obtained from a trusted source, e.g. the
+// header of the block following the snapshot height.
+func ImportStateSnapshot(
+	dir string, appDB dbm.DB, evmDB db.DBWrapper, appHash []byte,
+) (*StateSnapshotManifest, error) {
+	manifest, err := ReadStateSnapshotManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if appHash != nil && !bytes.Equal(appHash, manifest.AppHash) {
+		return nil, errors.Errorf("snapshot app hash %X doesn't match expected app hash %X", manifest.AppHash, appHash)
+	}
+	if manifest.EvmRoot != nil && evmDB == nil {
+		return nil, errors.New("snapshot contains EVM state, but no EVM DB was provided")
+	}
+	if !isDBEmpty(appDB) {
+		return nil, errors.New("app DB isn't empty")
+	}
+	if evmDB != nil && !isDBEmpty(evmDB) {
+		return nil, errors.New("EVM DB isn't empty")
+	}
+
+	appBatch := appDB.NewBatch()
+	var evmBatch dbm.Batch
+	if evmDB != nil {
+		evmBatch = evmDB.NewBatch()
+	}
+	numPending := 0
+	err = ReadStateSnapshot(dir, manifest, func(target SnapshotDB, key, value []byte) error {
+		switch target {
+		case SnapshotAppDB:
+			appBatch.Set(key, value)
+		case SnapshotEvmDB:
+			if evmBatch == nil {
+				return errors.New("snapshot contains EVM state, but no EVM DB was provided")
+			}
+			evmBatch.Set(key, value)
+		default:
+			return errors.Errorf("invalid snapshot record target %d", target)
+		}
+		numPending++
+		if numPending >= 10000 {
+			appBatch.Write()
+			appBatch = appDB.NewBatch()
+			if evmBatch != nil {
+				evmBatch.Write()
+				evmBatch = evmDB.NewBatch()
+			}
+			numPending = 0
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if evmBatch != nil && manifest.EvmRoot != nil {
+		evmBatch.Set(evmRootKey(manifest.Height), manifest.EvmRoot)
+		evmBatch.WriteSync()
+	}
+	appBatch.WriteSync()
+
+	iavlStore, err := NewIAVLStore(appDB, 0, manifest.Height, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load imported app store")
+	}
+	if iavlStore.Version() != manifest.Height {
+		return nil, errors.Errorf(
+			"imported app store is at height %d, expected %d", iavlStore.Version(), manifest.Height,
+		)
+	}
+	if !bytes.Equal(iavlStore.Hash(), manifest.AppHash) {
+		return nil, errors.Errorf(
+			"imported app store hash %X doesn't match snapshot app hash %X", iavlStore.Hash(), manifest.AppHash,
+		)
+	}
+	if manifest.EvmRoot != nil {
+		evmStore := NewEvmStore(evmDB, 1)
+		if err := evmStore.LoadVersion(manifest.Height); err != nil {
+			return nil, errors.Wrap(err, "failed to load imported EVM store")
+		}
+		// this will check that the EVM root in the app store matches the one in the EVM store
+		if _, err := NewMultiWriterAppStore(iavlStore, evmStore, false); err != nil {
+			return nil, errors.Wrap(err, "imported EVM state doesn't match imported app store")
+		}
+	}
+	return manifest, nil
+}
+
+func isDBEmpty(db dbm.DB) bool {
+	it := db.Iterator(nil, nil)
+	defer it.Close()
+	return !it.Valid()
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/db"
+)
+
+func TestIAVLStoreSnapshotExportImport(t *testing.T) {
+	srcDB := db.NewMemDB()
+	srcStore, err := NewIAVLStore(srcDB, 0, 0, 0)
+	require.NoError(t, err)
+	for v := 1; v <= 3; v++ {
+		for i := 0; i < 50; i++ {
+			srcStore.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d-%d", i, v)))
+		}
+		_, _, err := srcStore.SaveVersion()
+		require.NoError(t, err)
+	}
+	snap, err := srcStore.GetSnapshotAt(2)
+	require.NoError(t, err)
+	defer snap.Release()
+	tree, err := srcStore.tree.GetImmutable(2)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "state-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewStateSnapshotWriter(dir, 512)
+	require.NoError(t, err)
+	appHash, err := ExportIAVLStoreSnapshot(srcDB, 2, w)
+	require.NoError(t, err)
+	require.Equal(t, tree.Hash(), appHash)
+	require.NoError(t, w.WriteTendermintState([]byte("tendermint state")))
+	manifest, err := w.Finish(2, appHash, nil)
+	require.NoError(t, err)
+	require.True(t, len(manifest.Chunks) > 1)
+
+	manifest, err = ReadStateSnapshotManifest(dir)
+	require.NoError(t, err)
+	tmState, err := ReadStateSnapshotTendermintState(dir, manifest)
+	require.NoError(t, err)
+	require.Equal(t, []byte("tendermint state"), tmState)
+
+	// can't overwrite an existing snapshot
+	_, err = NewStateSnapshotWriter(dir, 512)
+	require.Error(t, err)
+
+	// the app hash must match the expected one if specified
+	_, err = ImportStateSnapshot(dir, db.NewMemDB(), nil, []byte{1, 2, 3})
+	require.Error(t, err)
+
+	destDB := db.NewMemDB()
+	_, err = ImportStateSnapshot(dir, destDB, nil, appHash)
+	require.NoError(t, err)
+	destStore, err := NewIAVLStore(destDB, 0, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), destStore.Version())
+	require.Equal(t, appHash, destStore.Hash())
+	require.Equal(t, snap.Get([]byte("key7")), destStore.Get([]byte("key7")))
+
+	// can't import into a non-empty DB
+	_, err = ImportStateSnapshot(dir, destDB, nil, nil)
+	require.Error(t, err)
+
+	// corrupted chunks should be detected
+	chunkPath := filepath.Join(dir, manifest.Chunks[0].File)
+	data, err := ioutil.ReadFile(chunkPath)
+	require.NoError(t, err)
+	data[len(data)-1]++
+	require.NoError(t, ioutil.WriteFile(chunkPath, data, 0644))
+	_, err = ImportStateSnapshot(dir, db.NewMemDB(), nil, nil)
+	require.Error(t, err)
+
+	tmPath := filepath.Join(dir, manifest.Tendermint.File)
+	require.NoError(t, ioutil.WriteFile(tmPath, []byte("modified state"), 0644))
+	_, err = ReadStateSnapshotTendermintState(dir, manifest)
+	require.Error(t, err)
+}
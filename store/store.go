@@ -3,8 +3,21 @@ package store
 import (
 	"github.com/loomnetwork/go-loom/plugin"
 	"github.com/loomnetwork/go-loom/util"
+	"github.com/pkg/errors"
+
+	"github.com/loomnetwork/loomchain/log"
 )
 
+var logger = log.Module("store")
+
+// ErrHistoricalSnapshotsNotSupported is returned when a snapshot of a previous version is requested
+// from a store that doesn't retain previous versions.
+var ErrHistoricalSnapshotsNotSupported = errors.New("store doesn't support historical snapshots")
+
+// ErrStatePruned is returned when a snapshot of a previous version is requested from a store, but
+// that version has already been removed from the store.
+var ErrStatePruned = errors.New("state pruned")
+
 // KVReader interface for reading data out of a store
 type KVReader interface {
 	// Get returns nil iff key doesn't exist. Panics on nil key.
@@ -56,6 +69,14 @@ type VersionedKVStore interface {
 	GetSnapshot() Snapshot
 }
 
+// HistoricalKVStore is implemented by versioned stores that can provide read-only snapshots of
+// previously saved versions of the store.
+type HistoricalKVStore interface {
+	// GetSnapshotAt returns a snapshot of the store at the given version, or an error if the version
+	// doesn't exist (or no longer exists).
+	GetSnapshotAt(version int64) (Snapshot, error)
+}
+
 type cacheItem struct {
 	Value   []byte
 	Deleted bool
@@ -119,6 +140,11 @@ func (c *cacheTx) Range(prefix []byte) plugin.RangeData {
 	return c.store.Range(prefix)
 }
 
+// RangeFrom implements RangeFromReader, like Range it doesn't take pending writes into account.
+func (c *cacheTx) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return RangeFrom(c.store, prefix, start, limit)
+}
+
 func (c *cacheTx) Has(key []byte) bool {
 	if item, ok := c.cache[string(key)]; ok {
 		return !item.Deleted
@@ -160,6 +186,11 @@ func (a *atomicWrapStore) BeginTx() KVStoreTx {
 	return newCacheTx(a)
 }
 
+// RangeFrom implements RangeFromReader.
+func (a *atomicWrapStore) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return RangeFrom(a.KVStore, prefix, start, limit)
+}
+
 func WrapAtomic(store KVStore) AtomicKVStore {
 	return &atomicWrapStore{
 		KVStore: store,
@@ -175,6 +206,11 @@ func (r *prefixReader) Range(prefix []byte) plugin.RangeData {
 	return r.reader.Range(util.PrefixKey(r.prefix, prefix))
 }
 
+// RangeFrom implements RangeFromReader.
+func (r *prefixReader) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return RangeFrom(r.reader, util.PrefixKey(r.prefix, prefix), start, limit)
+}
+
 func (r *prefixReader) Get(key []byte) []byte {
 	return r.reader.Get(util.PrefixKey(r.prefix, key))
 }
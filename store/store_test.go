@@ -313,6 +313,31 @@ func (ts *StoreTestSuite) TestStoreRange() {
 	ts.VerifyRange(ts.store, prefixes, entries)
 }
 
+func (ts *StoreTestSuite) TestStoreRangeFrom() {
+	require := ts.Require()
+	prefixes, _ := populateStore(ts.store)
+	keys := func(entries plugin.RangeData) []string {
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			keys[i] = string(entry.Key)
+		}
+		return keys
+	}
+
+	for i := 0; i < 2; i++ {
+		require.Equal([]string{"1", "2"}, keys(RangeFrom(ts.store, prefixes[1], nil, 2)), ts.StoreName)
+		require.Equal([]string{"3", "4"}, keys(RangeFrom(ts.store, prefixes[1], []byte("2"), 2)), ts.StoreName)
+		require.Equal([]string{"2", "3", "4"}, keys(RangeFrom(ts.store, prefixes[1], []byte("1"), 0)), ts.StoreName)
+		// the start key doesn't have to exist
+		require.Equal([]string{"3"}, keys(RangeFrom(ts.store, prefixes[1], []byte("25"), 1)), ts.StoreName)
+		require.Len(RangeFrom(ts.store, prefixes[1], []byte("4"), 2), 0, ts.StoreName)
+		require.Equal([]string{"\x00", "\xff"}, keys(RangeFrom(ts.store, prefixes[2], nil, 0)), ts.StoreName)
+
+		_, _, err := ts.store.SaveVersion()
+		require.NoError(err)
+	}
+}
+
 func (ts *StoreTestSuite) VerifyConcurrentSnapshots() {
 	require := ts.Require()
 	// start one writer go-routine and a bunch of reader go-routines
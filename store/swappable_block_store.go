@@ -0,0 +1,49 @@
+package store
+
+import (
+	"sync"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// SwappableBlockStore forwards all calls to a block store that can be replaced while the node is
+// running, this allows the block store cache to be reconfigured without a restart.
+type SwappableBlockStore struct {
+	mutex sync.RWMutex
+	store BlockStore
+}
+
+var _ BlockStore = &SwappableBlockStore{}
+
+func NewSwappableBlockStore(store BlockStore) *SwappableBlockStore {
+	return &SwappableBlockStore{store: store}
+}
+
+// Swap replaces the current block store with the given one.
+func (s *SwappableBlockStore) Swap(store BlockStore) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.store = store
+}
+
+func (s *SwappableBlockStore) current() BlockStore {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.store
+}
+
+func (s *SwappableBlockStore) GetBlockByHeight(height *int64) (*ctypes.ResultBlock, error) {
+	return s.current().GetBlockByHeight(height)
+}
+
+func (s *SwappableBlockStore) GetBlockRangeByHeight(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	return s.current().GetBlockRangeByHeight(minHeight, maxHeight)
+}
+
+func (s *SwappableBlockStore) GetBlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	return s.current().GetBlockResults(height)
+}
+
+func (s *SwappableBlockStore) GetTxResult(txHash []byte) (*ctypes.ResultTx, error) {
+	return s.current().GetTxResult(txHash)
+}
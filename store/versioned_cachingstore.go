@@ -12,6 +12,7 @@ import (
 	"github.com/go-kit/kit/metrics"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	loom "github.com/loomnetwork/go-loom"
+	"github.com/loomnetwork/go-loom/plugin"
 	"github.com/pkg/errors"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 )
@@ -322,6 +323,11 @@ func NewVersionedCachingStore(
 	}, nil
 }
 
+// RangeFrom implements RangeFromReader, ranges aren't cached so this reads from the source store.
+func (c *versionedCachingStore) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return RangeFrom(c.VersionedKVStore, prefix, start, limit)
+}
+
 func (c *versionedCachingStore) Delete(key []byte) {
 	var err error
 
@@ -378,6 +384,14 @@ func (c *versionedCachingStore) GetSnapshot() Snapshot {
 	)
 }
 
+// GetSnapshotAt bypasses the cache, since it only ever contains recent versions of the store.
+func (c *versionedCachingStore) GetSnapshotAt(version int64) (Snapshot, error) {
+	if hs, ok := c.VersionedKVStore.(HistoricalKVStore); ok {
+		return hs.GetSnapshotAt(version)
+	}
+	return nil, ErrHistoricalSnapshotsNotSupported
+}
+
 // CachingStoreSnapshot is a read-only CachingStore with specified version
 type versionedCachingStoreSnapshot struct {
 	Snapshot
@@ -396,6 +410,11 @@ func newVersionedCachingStoreSnapshot(snapshot Snapshot, cache *versionedBigCach
 	}
 }
 
+// RangeFrom implements RangeFromReader.
+func (c *versionedCachingStoreSnapshot) RangeFrom(prefix, start []byte, limit int) plugin.RangeData {
+	return RangeFrom(c.Snapshot, prefix, start, limit)
+}
+
 func (c *versionedCachingStoreSnapshot) Delete(key []byte) {
 	panic("[versionedCachingStoreSnapshot] Delete() not implemented")
 }
@@ -4,16 +4,21 @@ package tx_handler
 
 import (
 	"fmt"
+	"math/big"
 
 	etypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/golang/protobuf/proto"
 	"github.com/loomnetwork/go-loom"
+	ptypes "github.com/loomnetwork/go-loom/plugin/types"
 	"github.com/loomnetwork/go-loom/types"
 	"github.com/loomnetwork/loomchain"
 	"github.com/loomnetwork/loomchain/auth"
+	"github.com/loomnetwork/loomchain/builtin/plugins/chainconfig"
 	"github.com/loomnetwork/loomchain/eth/utils"
 	"github.com/loomnetwork/loomchain/features"
+	"github.com/loomnetwork/loomchain/plugin"
+	"github.com/loomnetwork/loomchain/plugin/abibridge"
 	"github.com/loomnetwork/loomchain/registry/factory"
 	"github.com/loomnetwork/loomchain/vm"
 	"github.com/pkg/errors"
@@ -23,6 +28,10 @@ import (
 type EthTxHandler struct {
 	*vm.Manager
 	CreateRegistry factory.RegistryFactoryFunc
+	// Go contracts that can be called by Ethereum txs, if this is nil only EVM contracts can be called.
+	ABIBridge *abibridge.Bridge
+	// Used to store receipts for txs that call Go contracts via the ABI bridge.
+	ReceiptWriter loomchain.WriteReceiptHandler
 }
 
 func (h *EthTxHandler) ProcessTx(
@@ -66,6 +75,10 @@ func (h *EthTxHandler) ProcessTx(
 		return r, errors.New("tx value can't be negative")
 	}
 
+	if err := h.checkMinGasPrice(state, ethTx.GasPrice()); err != nil {
+		return r, err
+	}
+
 	// Only do basic validation in CheckTx, don't execute the actual EVM deploy/call
 	if isCheckTx {
 		return r, nil
@@ -100,6 +113,16 @@ func (h *EthTxHandler) ProcessTx(
 		}
 	} else { // call
 		to := loom.UnmarshalAddressPB(msg.To)
+		if h.isBridgedContract(state, to) {
+			if ethTx.Value().Sign() > 0 {
+				return r, errors.New("can't transfer value to a Go contract")
+			}
+			r.Data, err = h.callBridgedContract(state, origin, to, ethTx.Data())
+			if err != nil {
+				return r, errors.Wrap(err, "contract call failed")
+			}
+			return r, nil
+		}
 		r.Data, err = vmInstance.Call(origin, to, ethTx.Data(), loom.NewBigUInt(ethTx.Value()))
 		if err != nil {
 			return r, errors.Wrap(err, "contract call failed")
@@ -107,3 +130,69 @@ func (h *EthTxHandler) ProcessTx(
 	}
 	return r, nil
 }
+
+// checkMinGasPrice rejects txs with a gas price below the minimum set in the ChainConfig contract.
+func (h *EthTxHandler) checkMinGasPrice(state loomchain.State, gasPrice *big.Int) error {
+	if !state.FeatureEnabled(features.ChainCfgVersion1_5, false) {
+		return nil
+	}
+	vmInstance, err := h.Manager.InitVM(vm.VMType_PLUGIN, state)
+	if err != nil {
+		return err
+	}
+	pvm, ok := vmInstance.(*plugin.PluginVM)
+	if !ok {
+		return errors.New("failed to initialize plugin VM")
+	}
+	ctx, err := plugin.NewInternalContractContext("chainconfig", pvm, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to create ChainConfig contract context")
+	}
+	minGasPrice, err := chainconfig.GetMinGasPrice(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to load min gas price")
+	}
+	if gasPrice.Cmp(minGasPrice) < 0 {
+		return fmt.Errorf("tx gas price %v is below the minimum gas price %v", gasPrice, minGasPrice)
+	}
+	return nil
+}
+
+func (h *EthTxHandler) isBridgedContract(state loomchain.State, addr loom.Address) bool {
+	if h.ABIBridge == nil || !state.FeatureEnabled(features.EthTxABIBridgeFeature, false) {
+		return false
+	}
+	return h.ABIBridge.ContractName(h.CreateRegistry(state), addr) != ""
+}
+
+// callBridgedContract calls a Go contract via the ABI bridge, and stores a receipt containing the
+// events emitted by the contract, returns the hash of the receipt.
+func (h *EthTxHandler) callBridgedContract(
+	state loomchain.State, caller, addr loom.Address, input []byte,
+) ([]byte, error) {
+	vmInstance, err := h.Manager.InitVM(vm.VMType_PLUGIN, state)
+	if err != nil {
+		return nil, err
+	}
+	pvm, ok := vmInstance.(*plugin.PluginVM)
+	if !ok {
+		return nil, errors.New("failed to initialize plugin VM")
+	}
+	_, logs, err := h.ABIBridge.Call(pvm, caller, addr, input)
+	if h.ReceiptWriter == nil {
+		return nil, err
+	}
+
+	var events []*ptypes.EventData
+	if err == nil {
+		events = h.ReceiptWriter.GetEventsFromLogs(logs, state.Block().Height, caller, addr, input)
+	}
+	txHash, errSaveReceipt := h.ReceiptWriter.CacheReceipt(state, caller, addr, events, err, nil)
+	if errSaveReceipt != nil {
+		if err == nil {
+			return nil, errors.Wrap(errSaveReceipt, "failed to create tx receipt")
+		}
+		return nil, errors.Wrapf(err, "failed to create tx receipt: %v", errSaveReceipt)
+	}
+	return txHash, err
+}
@@ -4,6 +4,7 @@ package tx_handler
 
 import (
 	"github.com/loomnetwork/loomchain"
+	"github.com/loomnetwork/loomchain/plugin/abibridge"
 	"github.com/loomnetwork/loomchain/registry/factory"
 	"github.com/loomnetwork/loomchain/vm"
 	"github.com/pkg/errors"
@@ -12,6 +13,8 @@ import (
 type EthTxHandler struct {
 	*vm.Manager
 	CreateRegistry factory.RegistryFactoryFunc
+	ABIBridge      *abibridge.Bridge
+	ReceiptWriter  loomchain.WriteReceiptHandler
 }
 
 func (h *EthTxHandler) ProcessTx(